@@ -10,32 +10,42 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/util"
 )
 
 type LogOpts struct {
 	Dir, ContainerID, ClientIP string
 }
 
-func LogTo(ctx context.Context, r io.Reader, opts LogOpts) {
+// containerDir returns the log dir of the container, created if not exist
+func containerDir(opts LogOpts) (string, error) {
 	logDir := opts.Dir
 	if !strings.HasPrefix(logDir, "/") {
 		pwd, err := os.Getwd()
 		if err != nil {
-			logrus.Errorf("audit get pwd error: %s", err)
-			return
+			return "", fmt.Errorf("get pwd error: %s", err)
 		}
 		logDir = path.Join(pwd, logDir)
 	}
 
-	logDir = path.Join(logDir, opts.ContainerID[:12])
+	logDir = path.Join(logDir, util.ShortID(opts.ContainerID, 12))
 	_, err := os.Stat(logDir)
 	if os.IsNotExist(err) {
 		logrus.Debugf("create dir %s", logDir)
 		if err := os.MkdirAll(logDir, 0755); err != nil {
-			logrus.Errorf("mkdir error: %s", err)
-			return
+			return "", fmt.Errorf("mkdir error: %s", err)
 		}
 	}
+	return logDir, nil
+}
+
+func LogTo(ctx context.Context, r io.Reader, opts LogOpts) {
+	logDir, err := containerDir(opts)
+	if err != nil {
+		logrus.Errorf("audit %s", err)
+		return
+	}
 	fPath := path.Join(logDir, fmt.Sprintf("%s-%d.log",
 		strings.Split(opts.ClientIP, ":")[0], time.Now().Unix()),
 	)
@@ -67,3 +77,26 @@ func LogTo(ctx context.Context, r io.Reader, opts LogOpts) {
 		start += int64(n)
 	}
 }
+
+// Record appends the action of the client to the actions.log of the container
+func Record(opts LogOpts, action string) {
+	logDir, err := containerDir(opts)
+	if err != nil {
+		logrus.Errorf("audit %s", err)
+		return
+	}
+
+	fPath := path.Join(logDir, "actions.log")
+	f, err := os.OpenFile(fPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		logrus.Errorf("audit open file [%s] error: %s", fPath, err)
+		return
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s %s %s\n", time.Now().Format(time.RFC3339),
+		strings.Split(opts.ClientIP, ":")[0], action)
+	if err != nil {
+		logrus.Errorf("audit write file error: %s", err)
+	}
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordShortID(t *testing.T) {
+	// the containerd IDs are named by the user, shorter than 12
+	dir := t.TempDir()
+	Record(LogOpts{Dir: dir, ContainerID: "redis", ClientIP: "1.2.3.4:5678"}, "stop")
+
+	bs, err := os.ReadFile(filepath.Join(dir, "redis", "actions.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(bs), " 1.2.3.4 stop\n") {
+		t.Errorf("unexpected record %q", bs)
+	}
+}
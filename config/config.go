@@ -9,12 +9,57 @@ var SHELL_LIST = [...]string{
 }
 
 type DockerConfig struct {
-	DockerHost string // default is /var/run/docker.sock
+	DockerHost string   // default is /var/run/docker.sock
+	Hosts      []string // several daemons, the containers are located by their hosts
+	CertPath   string   // the directory of ca.pem, cert.pem and key.pem for the TCP hosts
+	ConfigDir  string   // the config directory of the docker CLI, normally is $HOME/.docker
+	Contexts   []string // the docker contexts to serve, "all" means all of them
 	PsOptions  string
+	DebugImage string // the image of the debug helper containers, empty to disable
 }
 
 type KubeConfig struct {
-	ConfigPath string // normally is $HOME/.kube/config
+	ConfigPath string   // normally is $HOME/.kube/config, or a list of paths like $KUBECONFIG
+	Contexts   []string // the contexts(clusters) to serve, "all" means all of them
+	DebugImage string   // the image of the ephemeral debug containers, empty to disable
+
+	// list options
+	Namespaces        []string // empty means all namespaces
+	ExcludeNamespaces []string
+	LabelSelector     string // e.g. app=nginx,tier!=cache
+	FieldSelector     string // e.g. spec.nodeName=node1,status.phase=Running
+	OnlyReady         bool   // only list the ready containers
+}
+
+type ContainerdConfig struct {
+	Address    string   // default is /run/containerd/containerd.sock
+	Namespaces []string // empty means all namespaces
+	FifoDir    string   // where to create the exec FIFOs
+}
+
+type PodmanConfig struct {
+	Host string // default is /run/podman/podman.sock
+}
+
+type CRIConfig struct {
+	Endpoint string // CRI runtime socket, e.g. /var/run/crio/crio.sock
+}
+
+type SSHConfig struct {
+	Hosts      []string // [user@]host[:port]
+	User       string   // default user, default is the current user
+	KeyPath    string   // private key, default is ~/.ssh/id_*
+	Password   string
+	KnownHosts string // normally is $HOME/.ssh/known_hosts
+	Insecure   bool   // skip the host key checking
+	LogFile    string // file to tail for the logs, use journalctl if empty
+}
+
+type LocalConfig struct {
+	Shell   string // default is $SHELL
+	Chroot  string // run the shell in this root
+	Dir     string // working directory
+	LogFile string // file to tail for the logs
 }
 
 type GRPCConfig struct {
@@ -24,10 +69,15 @@ type GRPCConfig struct {
 }
 
 type BackendConfig struct {
-	Type   string // docker or kubectl (for now)
-	Docker DockerConfig
-	Kube   KubeConfig
-	GRPC   GRPCConfig
+	Type       string // docker, kube, containerd, podman, cri, ssh, local or grpc, or several of them separated by commas
+	Docker     DockerConfig
+	Kube       KubeConfig
+	Containerd ContainerdConfig
+	Podman     PodmanConfig
+	CRI        CRIConfig
+	SSH        SSHConfig
+	Local      LocalConfig
+	GRPC       GRPCConfig
 }
 
 type ControlConfig struct {
@@ -76,6 +126,15 @@ func New() *Config {
 		Backend: BackendConfig{
 			Docker: DockerConfig{},
 			Kube:   KubeConfig{},
+			Containerd: ContainerdConfig{
+				Namespaces: []string{},
+			},
+			Podman: PodmanConfig{},
+			CRI:    CRIConfig{},
+			SSH: SSHConfig{
+				Hosts: []string{},
+			},
+			Local: LocalConfig{},
 			GRPC: GRPCConfig{
 				Servers: []string{},
 			},
@@ -0,0 +1,292 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// compositeCli serves several backends at the same time, the containers
+// are tagged with their backends and the calls are routed by the IDs
+type compositeCli struct {
+	names      []string
+	clis       map[string]Cli
+	containers *types.Containers
+	duplicated sync.Map // the namespaced IDs, logged once
+}
+
+// namespaced returns the ID of a container whose ID is listed by an
+// earlier backend already, e.g. the same container seen through docker
+// and cri, the backend IDs don't have a colon
+func namespaced(backend, id string) string {
+	return backend + ":" + id
+}
+
+// backendID returns the ID of the container in its backend
+func backendID(c types.Container) string {
+	return strings.TrimPrefix(c.ID, c.Backend+":")
+}
+
+func newCompositeCli(names []string, clis map[string]Cli) *compositeCli {
+	logrus.Infof("New composite client: %v", names)
+	return &compositeCli{
+		names:      names,
+		clis:       clis,
+		containers: &types.Containers{},
+	}
+}
+
+func (cc *compositeCli) List(ctx context.Context) []types.Container {
+	lists := make([][]types.Container, len(cc.names))
+
+	wg := sync.WaitGroup{}
+	for i, name := range cc.names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			lists[i] = cc.clis[name].List(ctx)
+			for j := range lists[i] {
+				lists[i][j].Backend = name
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	// the IDs listed by an earlier backend are namespaced by the later
+	// backends, so each of them is routed to its own backend
+	containers := []types.Container{}
+	ids := make(map[string]bool)
+	for i, list := range lists {
+		for _, c := range list {
+			if ids[c.ID] {
+				id := namespaced(cc.names[i], c.ID)
+				if _, logged := cc.duplicated.LoadOrStore(id, true); !logged {
+					logrus.Warnf("container %s of %s is listed by another backend, "+
+						"it's renamed to %s", c.ID, cc.names[i], id)
+				}
+				c.ID = id
+			}
+			ids[c.ID] = true
+			containers = append(containers, c)
+		}
+	}
+
+	cc.containers.Set(containers)
+	return containers
+}
+
+// find returns the container and its backend
+func (cc *compositeCli) find(ctx context.Context, cid string) (types.Container, Cli, error) {
+	c := cc.containers.Find(cid)
+	if c.ID == "" {
+		cc.List(ctx)
+		c = cc.containers.Find(cid)
+	}
+	if c.ID == "" {
+		return c, nil, fmt.Errorf("container %s not found", cid)
+	}
+	return c, cc.clis[c.Backend], nil
+}
+
+// route returns the backend of the container, and the container with the
+// ID of the backend
+func (cc *compositeCli) route(ctx context.Context, c types.Container) (types.Container, Cli, error) {
+	cli, ok := cc.clis[c.Backend]
+	if !ok {
+		found, foundCli, err := cc.find(ctx, c.ID)
+		if err != nil {
+			return c, nil, err
+		}
+		c.ID, c.Backend, cli = found.ID, found.Backend, foundCli
+	}
+	c.ID = backendID(c)
+	return c, cli, nil
+}
+
+func (cc *compositeCli) GetInfo(ctx context.Context, cid string) types.Container {
+	c, cli, err := cc.find(ctx, cid)
+	if err != nil {
+		logrus.Error(err)
+		return types.Container{}
+	}
+	info := cli.GetInfo(ctx, backendID(c))
+	if info.ID != "" {
+		// the namespaced one
+		info.ID, info.Backend = c.ID, c.Backend
+	}
+	return info
+}
+
+func (cc *compositeCli) Start(ctx context.Context, cid string) error {
+	c, cli, err := cc.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Start(ctx, backendID(c))
+}
+
+func (cc *compositeCli) Stop(ctx context.Context, cid string) error {
+	c, cli, err := cc.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Stop(ctx, backendID(c))
+}
+
+func (cc *compositeCli) Restart(ctx context.Context, cid string) error {
+	c, cli, err := cc.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Restart(ctx, backendID(c))
+}
+
+func (cc *compositeCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	c, cli, err := cc.route(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Exec(ctx, c)
+}
+
+func (cc *compositeCli) Attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	c, cli, err := cc.route(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	attacher, ok := cli.(Attacher)
+	if !ok {
+		return nil, fmt.Errorf("attach is not supported by the %s backend", c.Backend)
+	}
+	return attacher.Attach(ctx, c)
+}
+
+func (cc *compositeCli) Stats(ctx context.Context, c types.Container) (<-chan types.Stats, error) {
+	c, cli, err := cc.route(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	reporter, ok := cli.(StatsReporter)
+	if !ok {
+		return nil, fmt.Errorf("stats is not supported by the %s backend", c.Backend)
+	}
+	return reporter.Stats(ctx, c)
+}
+
+func (cc *compositeCli) processManager(ctx context.Context, c types.Container) (ProcessManager, types.Container, error) {
+	c, cli, err := cc.route(ctx, c)
+	if err != nil {
+		return nil, c, err
+	}
+	manager, ok := cli.(ProcessManager)
+	if !ok {
+		return nil, c, fmt.Errorf("processes are not supported by the %s backend", c.Backend)
+	}
+	return manager, c, nil
+}
+
+func (cc *compositeCli) Top(ctx context.Context, c types.Container) ([]types.Process, error) {
+	manager, c, err := cc.processManager(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return manager.Top(ctx, c)
+}
+
+func (cc *compositeCli) Signal(ctx context.Context, c types.Container, pid int, signal string) error {
+	manager, c, err := cc.processManager(ctx, c)
+	if err != nil {
+		return err
+	}
+	return manager.Signal(ctx, c, pid, signal)
+}
+
+// Health checks all the backends which support it
+func (cc *compositeCli) Health() error {
+	errs := []string{}
+	for _, name := range cc.names {
+		if checker, ok := cc.clis[name].(HealthChecker); ok {
+			if err := checker.Health(); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Watch merges the events of all the backends which have them
+func (cc *compositeCli) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	out := make(chan types.ContainerEvent, 64)
+	wg := sync.WaitGroup{}
+	for _, name := range cc.names {
+		watcher, ok := cc.clis[name].(Watcher)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(name string, events <-chan types.ContainerEvent) {
+			defer wg.Done()
+			for event := range events {
+				event.Container.Backend = name
+				// namespaced as the list does
+				listed := cc.containers.Find(event.Container.ID)
+				if listed.ID == event.Container.ID && listed.Backend != name {
+					event.Container.ID = namespaced(name, event.Container.ID)
+				}
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(name, watcher.Watch(ctx))
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Locations collects the locations of all the backends which have them
+func (cc *compositeCli) Locations() []types.Location {
+	locations := []types.Location{}
+	for _, name := range cc.names {
+		if locator, ok := cc.clis[name].(Locator); ok {
+			for _, loc := range locator.Locations() {
+				loc.Backend = name
+				locations = append(locations, loc)
+			}
+		}
+	}
+	return locations
+}
+
+func (cc *compositeCli) Close() error {
+	var err error
+	for _, name := range cc.names {
+		if e := cc.clis[name].Close(); e != nil {
+			logrus.Errorf("close %s backend error: %s", name, e)
+			err = e
+		}
+	}
+	return err
+}
+
+func (cc *compositeCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	c, cli, err := cc.find(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	opts.ID = backendID(c)
+	return cli.Logs(ctx, opts)
+}
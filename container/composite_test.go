@@ -0,0 +1,256 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// fakeCli serves the given containers and records the calls
+type fakeCli struct {
+	containers []types.Container
+	calls      []string
+	closed     bool
+}
+
+func (f *fakeCli) GetInfo(ctx context.Context, cid string) types.Container {
+	f.calls = append(f.calls, "info "+cid)
+	for _, c := range f.containers {
+		if c.ID == cid {
+			c.Shell = "/bin/sh"
+			return c
+		}
+	}
+	return types.Container{}
+}
+
+func (f *fakeCli) List(context.Context) []types.Container {
+	return append([]types.Container{}, f.containers...)
+}
+
+func (f *fakeCli) Start(ctx context.Context, cid string) error {
+	f.calls = append(f.calls, "start "+cid)
+	return nil
+}
+
+func (f *fakeCli) Stop(ctx context.Context, cid string) error {
+	f.calls = append(f.calls, "stop "+cid)
+	return nil
+}
+
+func (f *fakeCli) Restart(ctx context.Context, cid string) error {
+	f.calls = append(f.calls, "restart "+cid)
+	return nil
+}
+
+func (f *fakeCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	f.calls = append(f.calls, "exec "+c.ID)
+	return nil, nil
+}
+
+func (f *fakeCli) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	f.calls = append(f.calls, "logs "+opts.ID)
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+// fakeAttacher is a fakeCli supporting attach
+type fakeAttacher struct {
+	*fakeCli
+}
+
+func (f fakeAttacher) Attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	f.calls = append(f.calls, "attach "+c.ID)
+	return nil, nil
+}
+
+// fakeWatcher is a fakeCli publishing the events of its hub
+type fakeWatcher struct {
+	*fakeCli
+	hub *types.EventHub
+}
+
+func (f fakeWatcher) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	return f.hub.Subscribe(ctx)
+}
+
+func (f *fakeCli) lastCall() string {
+	if len(f.calls) == 0 {
+		return ""
+	}
+	return f.calls[len(f.calls)-1]
+}
+
+func TestComposite(t *testing.T) {
+	ctx := context.Background()
+	docker := &fakeCli{containers: []types.Container{
+		{ID: "aaaaaaaaaaaaaaaa", Name: "web"},
+		{ID: "cccccccccccccccc", Name: "dup"},
+	}}
+	kube := fakeAttacher{&fakeCli{containers: []types.Container{
+		{ID: "bbbbbbbbbbbbbbbb", Name: "nginx", PodName: "nginx-7d9c"},
+		{ID: "cccccccccccccccc", Name: "dup"},
+	}}}
+	cli := newCompositeCli([]string{"docker", "kube"},
+		map[string]Cli{"docker": docker, "kube": kube})
+
+	t.Run("list", func(t *testing.T) {
+		containers := cli.List(ctx)
+		if len(containers) != 4 {
+			t.Fatalf("expect 4 containers, got %d", len(containers))
+		}
+		backends := []string{}
+		for _, c := range containers {
+			backends = append(backends, c.Backend+" "+c.ID)
+		}
+		// the duplicated ID of the later backend is namespaced
+		expect := "[docker aaaaaaaaaaaaaaaa docker cccccccccccccccc " +
+			"kube bbbbbbbbbbbbbbbb kube kube:cccccccccccccccc]"
+		if fmt.Sprint(backends) != expect {
+			t.Errorf("unexpected backends %v", backends)
+		}
+	})
+
+	t.Run("get info", func(t *testing.T) {
+		c := cli.GetInfo(ctx, "bbbbbbbbbbbb")
+		if c.ID != "bbbbbbbbbbbbbbbb" || c.Backend != "kube" || c.Shell != "/bin/sh" {
+			t.Errorf("unexpected container %+v", c)
+		}
+		if c := cli.GetInfo(ctx, "dddd"); c.ID != "" {
+			t.Errorf("unexpected container %+v", c)
+		}
+	})
+
+	t.Run("route", func(t *testing.T) {
+		if _, err := cli.Exec(ctx, cli.GetInfo(ctx, "bbbbbbbbbbbb")); err != nil {
+			t.Fatal(err)
+		}
+		if call := kube.lastCall(); call != "exec bbbbbbbbbbbbbbbb" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if err := cli.Restart(ctx, "aaaaaaaaaaaa"); err != nil {
+			t.Fatal(err)
+		}
+		if call := docker.lastCall(); call != "restart aaaaaaaaaaaaaaaa" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if _, err := cli.Logs(ctx, types.LogOptions{ID: "cccccccccccc"}); err != nil {
+			t.Fatal(err)
+		}
+		if call := docker.lastCall(); call != "logs cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if err := cli.Stop(ctx, "dddd"); err == nil {
+			t.Error("expect error of unknown container")
+		}
+	})
+
+	t.Run("duplicated", func(t *testing.T) {
+		// the abbreviated ID of the list page
+		c := cli.GetInfo(ctx, "kube:ccccccc")
+		if c.ID != "kube:cccccccccccccccc" || c.Backend != "kube" || c.Shell != "/bin/sh" {
+			t.Fatalf("unexpected container %+v", c)
+		}
+		if call := kube.lastCall(); call != "info cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if _, err := cli.Exec(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if call := kube.lastCall(); call != "exec cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if err := cli.Stop(ctx, c.ID); err != nil {
+			t.Fatal(err)
+		}
+		if call := kube.lastCall(); call != "stop cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+		if _, err := cli.Logs(ctx, types.LogOptions{ID: "kube:cccccccccccccccc"}); err != nil {
+			t.Fatal(err)
+		}
+		if call := kube.lastCall(); call != "logs cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+
+		// the one of the first backend is kept
+		if c := cli.GetInfo(ctx, "cccccccccccc"); c.Backend != "docker" {
+			t.Errorf("unexpected container %+v", c)
+		}
+		if call := docker.lastCall(); call != "info cccccccccccccccc" {
+			t.Errorf("unexpected call %q", call)
+		}
+	})
+
+	t.Run("attach", func(t *testing.T) {
+		if _, err := cli.Attach(ctx, cli.GetInfo(ctx, "bbbbbbbbbbbb")); err != nil {
+			t.Fatal(err)
+		}
+		if call := kube.lastCall(); call != "attach bbbbbbbbbbbbbbbb" {
+			t.Errorf("unexpected call %q", call)
+		}
+		_, err := cli.Attach(ctx, cli.GetInfo(ctx, "aaaaaaaaaaaa"))
+		if err == nil || !strings.Contains(err.Error(), "not supported by the docker backend") {
+			t.Errorf("expect error of the unsupported backend, got %v", err)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		cli.Close()
+		if !docker.closed || !kube.closed {
+			t.Error("backends are not closed")
+		}
+	})
+}
+
+func TestCompositeWatch(t *testing.T) {
+	docker := fakeWatcher{&fakeCli{}, new(types.EventHub)}
+	kube := &fakeCli{}
+	cli := newCompositeCli([]string{"docker", "kube"},
+		map[string]Cli{"docker": docker, "kube": kube})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := cli.Watch(ctx)
+	docker.hub.Publish(types.ContainerEvent{
+		Action:    types.ContainerCreated,
+		Container: types.Container{ID: "aaaaaaaaaaaaaaaa"},
+	})
+	event := <-events
+	if event.Action != types.ContainerCreated || event.Container.Backend != "docker" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	// the ID listed by an earlier backend is namespaced
+	cli.containers.Set([]types.Container{{ID: "bbbbbbbbbbbbbbbb", Backend: "kube"}})
+	docker.hub.Publish(types.ContainerEvent{
+		Action:    types.ContainerRemoved,
+		Container: types.Container{ID: "bbbbbbbbbbbbbbbb"},
+	})
+	if event := <-events; event.Container.ID != "docker:bbbbbbbbbbbbbbbb" {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestNewCliBackend(t *testing.T) {
+	_, err := NewCliBackend(config.BackendConfig{Type: "local,local"})
+	if err == nil || !strings.Contains(err.Error(), "duplicated") {
+		t.Errorf("expect error of duplicated backends, got %v", err)
+	}
+	_, err = NewCliBackend(config.BackendConfig{Type: "local,unknown"})
+	if err == nil || !strings.Contains(err.Error(), "unknown backend type") {
+		t.Errorf("expect error of unknown backend, got %v", err)
+	}
+}
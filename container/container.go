@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/container/containerd"
+	"github.com/wrfly/container-web-tty/container/cri"
 	"github.com/wrfly/container-web-tty/container/docker"
 	"github.com/wrfly/container-web-tty/container/grpc"
 	"github.com/wrfly/container-web-tty/container/kube"
+	"github.com/wrfly/container-web-tty/container/local"
+	"github.com/wrfly/container-web-tty/container/podman"
+	"github.com/wrfly/container-web-tty/container/ssh"
 	"github.com/wrfly/container-web-tty/types"
 )
 
@@ -29,17 +35,102 @@ type Cli interface {
 	Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error)
 }
 
-// NewCliBackend returns the client backend
-func NewCliBackend(conf config.BackendConfig) (cli Cli, err error) {
-	switch conf.Type {
+// HealthChecker is implemented by the backends which can tell
+// whether they are ready to serve, e.g. the caches are synced
+type HealthChecker interface {
+	Health() error
+}
+
+// Attacher is implemented by the backends which can attach to the main
+// process of a container, the TTY detaches without signaling the process
+// when it exits
+type Attacher interface {
+	Attach(ctx context.Context, container types.Container) (types.TTY, error)
+}
+
+// Watcher is implemented by the backends which know the changes of the
+// containers, the events are sent until the context is done
+type Watcher interface {
+	Watch(ctx context.Context) <-chan types.ContainerEvent
+}
+
+// StatsReporter is implemented by the backends which know the resource
+// usage of the containers, the stats are sent periodically until the
+// context is done, and the channel is closed when the stream ends
+type StatsReporter interface {
+	Stats(ctx context.Context, container types.Container) (<-chan types.Stats, error)
+}
+
+// ProcessManager is implemented by the backends which can list the
+// processes of the containers and send signals to them, the signal
+// is the name without the SIG prefix, e.g. TERM
+type ProcessManager interface {
+	Top(ctx context.Context, container types.Container) ([]types.Process, error)
+	Signal(ctx context.Context, container types.Container, pid int, signal string) error
+}
+
+// Locator is implemented by the backends which serve the containers of
+// several locations, it reports the connection state of each of them
+type Locator interface {
+	Locations() []types.Location
+}
+
+// NewCliBackend returns the client backend, several backends
+// separated by commas are served at the same time
+func NewCliBackend(conf config.BackendConfig) (Cli, error) {
+	names := strings.Split(conf.Type, ",")
+	if len(names) == 1 {
+		return newCli(conf.Type, conf)
+	}
+
+	seen := make(map[string]bool, len(names))
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if seen[names[i]] {
+			return nil, fmt.Errorf("duplicated backend type %s", names[i])
+		}
+		seen[names[i]] = true
+	}
+
+	clis := make(map[string]Cli, len(names))
+	for _, name := range names {
+		cli, err := newCli(name, conf)
+		if err != nil {
+			for _, cli := range clis {
+				cli.Close()
+			}
+			return nil, fmt.Errorf("create %s backend error: %s", name, err)
+		}
+		clis[name] = cli
+	}
+
+	return newCompositeCli(names, clis), nil
+}
+
+func newCli(backend string, conf config.BackendConfig) (cli Cli, err error) {
+	switch backend {
 	case "docker":
-		cli, err = docker.NewCli(conf.Docker)
+		if len(conf.Docker.Hosts) > 1 || len(conf.Docker.Contexts) != 0 {
+			cli, err = docker.NewMultiCli(conf.Docker)
+		} else {
+			cli, err = docker.NewCli(conf.Docker)
+		}
 	case "kube":
 		cli, err = kube.NewCli(conf.Kube)
+	case "containerd":
+		cli, err = containerd.NewCli(conf.Containerd)
+	case "podman":
+		cli, err = podman.NewCli(conf.Podman)
+	case "cri":
+		cli, err = cri.NewCli(conf.CRI)
+	case "ssh":
+		cli, err = ssh.NewCli(conf.SSH)
+	case "local":
+		cli, err = local.NewCli(conf.Local)
 	case "grpc":
 		cli, err = grpc.NewCli(conf.GRPC)
 	default:
-		err = fmt.Errorf("unknown backend type %s", conf.Type)
+		err = fmt.Errorf("unknown backend type %s", backend)
 	}
 
 	return
@@ -0,0 +1,522 @@
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	versionapi "github.com/containerd/containerd/api/services/version/v1"
+	"github.com/containerd/containerd/api/types/task"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+const (
+	// namespaceHeader is the grpc metadata key of the containerd namespace
+	namespaceHeader = "containerd-namespace"
+
+	stopTimeout = time.Second * 10
+
+	// labels set by nerdctl and the CRI plugin
+	labelNerdctlName     = "nerdctl/name"
+	labelNerdctlStateDir = "nerdctl/state-dir"
+	labelNerdctlLogURI   = "nerdctl/log-uri"
+	labelKubePodName     = "io.kubernetes.pod.name"
+	labelKubeContainer   = "io.kubernetes.container.name"
+)
+
+type ContainerdCli struct {
+	conn       *grpc.ClientConn
+	namespaces namespacesapi.NamespacesClient
+	containers containersapi.ContainersClient
+	tasks      tasksapi.TasksClient
+	snapshots  snapshotsapi.SnapshotsClient
+
+	// namespaces to list, all namespaces if empty
+	listNamespaces []string
+	fifoDir        string
+	cache          *types.Containers
+}
+
+func NewCli(conf config.ContainerdConfig) (*ContainerdCli, error) {
+	addr := conf.Address
+	if !strings.Contains(addr, "://") {
+		addr = "unix://" + addr
+	}
+	logrus.Infof("Containerd connecting to %s", addr)
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("create containerd client error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	version, err := versionapi.NewVersionClient(conn).Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	logrus.Infof("New containerd client: version [%s]", version.GetVersion())
+
+	if err := os.MkdirAll(conf.FifoDir, 0700); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("create fifo dir error: %s", err)
+	}
+
+	cli := &ContainerdCli{
+		conn:           conn,
+		namespaces:     namespacesapi.NewNamespacesClient(conn),
+		containers:     containersapi.NewContainersClient(conn),
+		tasks:          tasksapi.NewTasksClient(conn),
+		snapshots:      snapshotsapi.NewSnapshotsClient(conn),
+		listNamespaces: conf.Namespaces,
+		fifoDir:        conf.FifoDir,
+		cache:          &types.Containers{},
+	}
+	cli.List(context.Background())
+
+	return cli, nil
+}
+
+// withNamespace sets the containerd namespace of the request
+func withNamespace(ctx context.Context, ns string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, namespaceHeader, ns)
+}
+
+func (cd *ContainerdCli) getNamespaces(ctx context.Context) ([]string, error) {
+	if len(cd.listNamespaces) != 0 {
+		return cd.listNamespaces, nil
+	}
+	resp, err := cd.namespaces.List(ctx, &namespacesapi.ListNamespacesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	ns := make([]string, 0, len(resp.Namespaces))
+	for _, n := range resp.Namespaces {
+		ns = append(ns, n.Name)
+	}
+	return ns, nil
+}
+
+func (cd *ContainerdCli) List(ctx context.Context) []types.Container {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	namespaces, err := cd.getNamespaces(ctx)
+	if err != nil {
+		logrus.Errorf("list containerd namespaces error: %s", err)
+		return nil
+	}
+
+	containers := []types.Container{}
+	for _, ns := range namespaces {
+		nsCtx := withNamespace(ctx, ns)
+		cs, err := cd.containers.List(nsCtx, &containersapi.ListContainersRequest{})
+		if err != nil {
+			logrus.Errorf("list containers in namespace %s error: %s", ns, err)
+			continue
+		}
+		tasks, err := cd.tasks.List(nsCtx, &tasksapi.ListTasksRequest{})
+		if err != nil {
+			logrus.Errorf("list tasks in namespace %s error: %s", ns, err)
+			continue
+		}
+		processes := make(map[string]*task.Process, len(tasks.Tasks))
+		for _, p := range tasks.Tasks {
+			processes[p.ID] = p
+		}
+
+		for _, c := range cs.Containers {
+			container := convert2Container(ns, c, processes[c.ID])
+			if old := cd.cache.Find(c.ID); old.ID == c.ID {
+				container.Shell = old.Shell
+			}
+			containers = append(containers, container)
+		}
+	}
+
+	cd.cache.Set(containers)
+	logrus.Debugf("list %d containers", len(containers))
+
+	return containers
+}
+
+func convert2Container(ns string, c *containersapi.Container, p *task.Process) types.Container {
+	container := types.Container{
+		ID:            c.ID,
+		Name:          c.ID,
+		Image:         c.Image,
+		Namespace:     ns,
+		PodName:       c.Labels[labelKubePodName],
+		ContainerName: c.Labels[labelKubeContainer],
+		IPs:           []string{"null"},
+		State:         "created",
+		Status:        "no task",
+	}
+	if name := c.Labels[labelNerdctlName]; name != "" {
+		container.Name = name
+	} else if container.ContainerName != "" {
+		container.Name = container.ContainerName
+	}
+
+	if spec, err := parseSpec(c); err == nil && spec.Process != nil {
+		container.Command = strings.Join(spec.Process.Args, " ")
+	}
+
+	if p != nil {
+		container.State = strings.ToLower(p.Status.String())
+		switch p.Status {
+		case task.Status_STOPPED:
+			container.Status = fmt.Sprintf("exited (%d)", p.ExitStatus)
+		default:
+			container.Status = fmt.Sprintf("%s (pid %d)", container.State, p.Pid)
+		}
+	}
+
+	return container
+}
+
+func parseSpec(c *containersapi.Container) (*specs.Spec, error) {
+	if c.Spec == nil {
+		return nil, fmt.Errorf("container %s has no spec", c.ID)
+	}
+	spec := &specs.Spec{}
+	if err := json.Unmarshal(c.Spec.Value, spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (cd *ContainerdCli) GetInfo(ctx context.Context, cid string) types.Container {
+	container := cd.cache.Find(cid)
+	if container.ID == "" {
+		// maybe it's a new container
+		logrus.Debugf("container %s not in cache, list again", cid)
+		cd.List(ctx)
+		if container = cd.cache.Find(cid); container.ID == "" {
+			return types.Container{}
+		}
+	}
+
+	if container.Shell == "" {
+		shell := cd.getShell(ctx, container)
+		container.Shell = shell
+		cd.cache.SetShell(container.ID, shell)
+	}
+	logrus.Debugf("found valid container: %s (%s)", container.ID, container.Shell)
+	return container
+}
+
+func (cd *ContainerdCli) getTask(ctx context.Context, c types.Container) (*task.Process, error) {
+	resp, err := cd.tasks.Get(withNamespace(ctx, c.Namespace),
+		&tasksapi.GetRequest{ContainerID: c.ID})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Process, nil
+}
+
+// getShell finds the shell through the root of the task's init process,
+// this requires the server to run on the same host as containerd
+func (cd *ContainerdCli) getShell(ctx context.Context, c types.Container) string {
+	p, err := cd.getTask(ctx, c)
+	if err != nil || p.Pid == 0 {
+		logrus.Debugf("get task of container %s error: %v", c.ID, err)
+		return ""
+	}
+	root := filepath.Join("/proc", strconv.Itoa(int(p.Pid)), "root")
+	for _, sh := range config.SHELL_LIST {
+		if _, err := os.Stat(filepath.Join(root, sh)); err == nil {
+			logrus.Debugf("container [%s] use [%s]", c.ID, sh)
+			return sh
+		}
+	}
+	return ""
+}
+
+func (cd *ContainerdCli) find(ctx context.Context, cid string) (types.Container, error) {
+	c := cd.cache.Find(cid)
+	if c.ID == "" {
+		if c = cd.GetInfo(ctx, cid); c.ID == "" {
+			return c, fmt.Errorf("container %s not found", cid)
+		}
+	}
+	return c, nil
+}
+
+func (cd *ContainerdCli) Start(ctx context.Context, cid string) error {
+	c, err := cd.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	nsCtx := withNamespace(ctx, c.Namespace)
+
+	p, err := cd.getTask(ctx, c)
+	switch {
+	case status.Code(err) == codes.NotFound:
+	case err != nil:
+		return err
+	case p.Status == task.Status_RUNNING:
+		return fmt.Errorf("container %s is already running", cid)
+	case p.Status == task.Status_CREATED:
+		_, err = cd.tasks.Start(nsCtx, &tasksapi.StartRequest{ContainerID: c.ID})
+		return err
+	default:
+		// remove the stopped task before creating a new one
+		if _, err := cd.tasks.Delete(nsCtx, &tasksapi.DeleteTaskRequest{ContainerID: c.ID}); err != nil {
+			return err
+		}
+	}
+
+	resp, err := cd.containers.Get(nsCtx, &containersapi.GetContainerRequest{ID: c.ID})
+	if err != nil {
+		return err
+	}
+	container := resp.Container
+	mounts, err := cd.snapshots.Mounts(nsCtx, &snapshotsapi.MountsRequest{
+		Snapshotter: container.Snapshotter,
+		Key:         container.SnapshotKey,
+	})
+	if err != nil {
+		return fmt.Errorf("get rootfs mounts error: %s", err)
+	}
+
+	// keep the outputs in nerdctl's logs if there is one
+	logURI := container.Labels[labelNerdctlLogURI]
+	if _, err := cd.tasks.Create(nsCtx, &tasksapi.CreateTaskRequest{
+		ContainerID: c.ID,
+		Rootfs:      mounts.Mounts,
+		Stdout:      logURI,
+		Stderr:      logURI,
+	}); err != nil {
+		return err
+	}
+	_, err = cd.tasks.Start(nsCtx, &tasksapi.StartRequest{ContainerID: c.ID})
+	return err
+}
+
+func (cd *ContainerdCli) Stop(ctx context.Context, cid string) error {
+	c, err := cd.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	nsCtx := withNamespace(ctx, c.Namespace)
+
+	p, err := cd.getTask(ctx, c)
+	if err != nil {
+		return err
+	}
+	if p.Status == task.Status_RUNNING || p.Status == task.Status_PAUSED {
+		if err := cd.kill(nsCtx, c.ID, syscall.SIGTERM); err != nil {
+			return err
+		}
+		// kill it if it doesn't exit in time
+		if err := cd.wait(nsCtx, c.ID, stopTimeout); err != nil {
+			logrus.Warnf("container %s doesn't exit in %s, kill it", c.ID, stopTimeout)
+			if err := cd.kill(nsCtx, c.ID, syscall.SIGKILL); err != nil {
+				return err
+			}
+			if err := cd.wait(nsCtx, c.ID, stopTimeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = cd.tasks.Delete(nsCtx, &tasksapi.DeleteTaskRequest{ContainerID: c.ID})
+	return err
+}
+
+func (cd *ContainerdCli) kill(ctx context.Context, cid string, sig syscall.Signal) error {
+	_, err := cd.tasks.Kill(ctx, &tasksapi.KillRequest{
+		ContainerID: cid,
+		Signal:      uint32(sig),
+		All:         true,
+	})
+	return err
+}
+
+func (cd *ContainerdCli) wait(ctx context.Context, cid string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	_, err := cd.tasks.Wait(ctx, &tasksapi.WaitRequest{ContainerID: cid})
+	return err
+}
+
+func (cd *ContainerdCli) Restart(ctx context.Context, cid string) error {
+	if err := cd.Stop(ctx, cid); err != nil {
+		return err
+	}
+	return cd.Start(ctx, cid)
+}
+
+func (cd *ContainerdCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	if c.ID == "" {
+		return nil, fmt.Errorf("container not found")
+	}
+	if c.Namespace == "" {
+		c.Namespace = cd.cache.Find(c.ID).Namespace
+	}
+	nsCtx := withNamespace(ctx, c.Namespace)
+
+	resp, err := cd.containers.Get(nsCtx, &containersapi.GetContainerRequest{ID: c.ID})
+	if err != nil {
+		return nil, err
+	}
+	spec, err := parseSpec(resp.Container)
+	if err != nil {
+		return nil, err
+	}
+	p, err := cd.getTask(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := execProcess(spec, p.Pid, c)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debugf("exec cmd: %v", process.Args)
+
+	return newExecInjector(ctx, cd.tasks, cd.fifoDir, c.ID, c.Namespace, process)
+}
+
+// execProcess builds the process spec of the exec, based on the
+// container's init process
+func execProcess(spec *specs.Spec, pid uint32, c types.Container) (*specs.Process, error) {
+	process := specs.Process{}
+	if spec.Process != nil {
+		process = *spec.Process
+	}
+	process.Terminal = true
+	process.Args = []string{c.Shell}
+	opts := c.Exec
+	if len(opts.Args) != 0 {
+		process.Args = opts.Args
+	} else if opts.Cmd != "" {
+		process.Args = append(process.Args, "-c", opts.Cmd)
+	}
+	process.Env = append(append([]string{}, process.Env...),
+		"HISTCONTROL=ignoredups", "TERM=xterm")
+	if opts.Env != "" {
+		process.Env = append(process.Env, strings.Split(opts.Env, " ")...)
+	}
+	if opts.User != "" {
+		user, err := lookupUser(pid, opts.User)
+		if err != nil {
+			return nil, err
+		}
+		process.User = user
+	}
+	if opts.Privileged {
+		return nil, fmt.Errorf("privileged exec is not supported by the containerd backend")
+	}
+	if opts.Debug {
+		return nil, fmt.Errorf("the debug mode is not supported by the containerd backend")
+	}
+	return &process, nil
+}
+
+// lookupUser resolves "user[:group]" with the passwd and group files
+// inside the container
+func lookupUser(pid uint32, user string) (specs.User, error) {
+	root := filepath.Join("/proc", strconv.Itoa(int(pid)), "root")
+	name, group, _ := strings.Cut(user, ":")
+
+	u := specs.User{}
+	uid, gid, err := lookupID(filepath.Join(root, "etc", "passwd"), name, true)
+	if err != nil {
+		return u, fmt.Errorf("lookup user %s error: %s", name, err)
+	}
+	u.UID, u.GID = uid, gid
+
+	if group != "" {
+		gid, _, err := lookupID(filepath.Join(root, "etc", "group"), group, false)
+		if err != nil {
+			return u, fmt.Errorf("lookup group %s error: %s", group, err)
+		}
+		u.GID = gid
+	}
+	return u, nil
+}
+
+// lookupID finds the name or the numeric id in a passwd/group file
+func lookupID(file, name string, passwd bool) (uint32, uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), uint32(id), nil
+	}
+	bs, err := os.ReadFile(file)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, line := range strings.Split(string(bs), "\n") {
+		// name:password:id:gid:...
+		fields := strings.Split(line, ":")
+		if len(fields) < 3 || fields[0] != name {
+			continue
+		}
+		id, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return 0, 0, err
+		}
+		gid := id
+		if passwd && len(fields) > 3 {
+			if gid, err = strconv.ParseUint(fields[3], 10, 32); err != nil {
+				return 0, 0, err
+			}
+		}
+		return uint32(id), uint32(gid), nil
+	}
+	return 0, 0, fmt.Errorf("no such entry in %s", file)
+}
+
+func (cd *ContainerdCli) Close() error {
+	return cd.conn.Close()
+}
+
+// Logs reads the json logs written by nerdctl, containerd itself
+// doesn't keep the outputs of the containers
+func (cd *ContainerdCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	c, err := cd.find(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cd.containers.Get(withNamespace(ctx, c.Namespace),
+		&containersapi.GetContainerRequest{ID: c.ID})
+	if err != nil {
+		return nil, err
+	}
+	stateDir := resp.Container.Labels[labelNerdctlStateDir]
+	if stateDir == "" {
+		return nil, fmt.Errorf("logs of container %s are not available", c.ID)
+	}
+
+	logPath := filepath.Join(stateDir, c.ID+"-json.log")
+	rc, err := util.TailFile(ctx, logPath, util.ParseTail(opts.Tail), opts.Follow)
+	if err != nil {
+		return nil, err
+	}
+	return newJSONLogReader(rc), nil
+}
@@ -0,0 +1,411 @@
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	namespacesapi "github.com/containerd/containerd/api/services/namespaces/v1"
+	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	versionapi "github.com/containerd/containerd/api/services/version/v1"
+	apitypes "github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/fifo"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+const (
+	testNamespace   = "default"
+	testContainerID = "0123456789abcdef0123456789abcdef"
+)
+
+// fakeContainerd holds the states of the fake containerd services
+type fakeContainerd struct {
+	m          sync.Mutex
+	stateDir   string
+	task       *task.Process
+	execReq    *tasksapi.ExecProcessRequest
+	resizeReq  *tasksapi.ResizePtyRequest
+	createReq  *tasksapi.CreateTaskRequest
+	killed     []uint32
+	namespaces []string
+}
+
+func (f *fakeContainerd) recordNamespace(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.m.Lock()
+	f.namespaces = append(f.namespaces, md.Get(namespaceHeader)...)
+	f.m.Unlock()
+}
+
+type fakeVersion struct {
+	versionapi.UnimplementedVersionServer
+	*fakeContainerd
+}
+
+func (f fakeVersion) Version(context.Context, *emptypb.Empty) (*versionapi.VersionResponse, error) {
+	return &versionapi.VersionResponse{Version: "v2.0.0-fake"}, nil
+}
+
+type fakeNamespaces struct {
+	namespacesapi.UnimplementedNamespacesServer
+	*fakeContainerd
+}
+
+func (f fakeNamespaces) List(context.Context, *namespacesapi.ListNamespacesRequest) (*namespacesapi.ListNamespacesResponse, error) {
+	return &namespacesapi.ListNamespacesResponse{
+		Namespaces: []*namespacesapi.Namespace{{Name: testNamespace}},
+	}, nil
+}
+
+func (f *fakeContainerd) container() *containersapi.Container {
+	spec, _ := json.Marshal(specs.Spec{
+		Process: &specs.Process{
+			Args: []string{"nginx", "-g", "daemon off;"},
+			Env:  []string{"PATH=/usr/bin:/bin"},
+			Cwd:  "/",
+		},
+	})
+	return &containersapi.Container{
+		ID:    testContainerID,
+		Image: "docker.io/library/nginx:latest",
+		Labels: map[string]string{
+			labelNerdctlName:     "web",
+			labelNerdctlStateDir: f.stateDir,
+		},
+		Spec:        &anypb.Any{TypeUrl: "types.containerd.io/opencontainers/runtime-spec/1/Spec", Value: spec},
+		Snapshotter: "overlayfs",
+		SnapshotKey: testContainerID,
+	}
+}
+
+type fakeContainers struct {
+	containersapi.UnimplementedContainersServer
+	*fakeContainerd
+}
+
+func (f fakeContainers) Get(ctx context.Context, req *containersapi.GetContainerRequest) (*containersapi.GetContainerResponse, error) {
+	f.recordNamespace(ctx)
+	if req.ID != testContainerID {
+		return nil, status.Error(codes.NotFound, "container not found")
+	}
+	return &containersapi.GetContainerResponse{Container: f.container()}, nil
+}
+
+func (f fakeContainers) List(ctx context.Context, req *containersapi.ListContainersRequest) (*containersapi.ListContainersResponse, error) {
+	f.recordNamespace(ctx)
+	return &containersapi.ListContainersResponse{
+		Containers: []*containersapi.Container{f.container()},
+	}, nil
+}
+
+type fakeTasks struct {
+	tasksapi.UnimplementedTasksServer
+	*fakeContainerd
+}
+
+func (f fakeTasks) List(ctx context.Context, req *tasksapi.ListTasksRequest) (*tasksapi.ListTasksResponse, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.task == nil {
+		return &tasksapi.ListTasksResponse{}, nil
+	}
+	return &tasksapi.ListTasksResponse{Tasks: []*task.Process{f.task}}, nil
+}
+
+func (f fakeTasks) Get(ctx context.Context, req *tasksapi.GetRequest) (*tasksapi.GetResponse, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.task == nil {
+		return nil, status.Error(codes.NotFound, "no running task found")
+	}
+	return &tasksapi.GetResponse{Process: f.task}, nil
+}
+
+func (f fakeTasks) Create(ctx context.Context, req *tasksapi.CreateTaskRequest) (*tasksapi.CreateTaskResponse, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.createReq = req
+	f.task = &task.Process{ID: req.ContainerID, Pid: uint32(os.Getpid()), Status: task.Status_CREATED}
+	return &tasksapi.CreateTaskResponse{ContainerID: req.ContainerID, Pid: f.task.Pid}, nil
+}
+
+func (f fakeTasks) Exec(ctx context.Context, req *tasksapi.ExecProcessRequest) (*emptypb.Empty, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.execReq = req
+	return &emptypb.Empty{}, nil
+}
+
+// Start starts the task, or acts as the shim of the exec process
+// which echos the inputs
+func (f fakeTasks) Start(ctx context.Context, req *tasksapi.StartRequest) (*tasksapi.StartResponse, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if req.ExecID == "" {
+		f.task.Status = task.Status_RUNNING
+		return &tasksapi.StartResponse{Pid: f.task.Pid}, nil
+	}
+
+	execReq := f.execReq
+	go func() {
+		ctx := context.Background()
+		stdout, err := fifo.OpenFifo(ctx, execReq.Stdout, syscall.O_WRONLY, 0)
+		if err != nil {
+			return
+		}
+		defer stdout.Close()
+		stdin, err := fifo.OpenFifo(ctx, execReq.Stdin, syscall.O_RDONLY, 0)
+		if err != nil {
+			return
+		}
+		defer stdin.Close()
+		stdout.Write([]byte("$ "))
+		io.Copy(stdout, stdin)
+	}()
+	return &tasksapi.StartResponse{}, nil
+}
+
+func (f fakeTasks) ResizePty(ctx context.Context, req *tasksapi.ResizePtyRequest) (*emptypb.Empty, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.resizeReq = req
+	return &emptypb.Empty{}, nil
+}
+
+func (f fakeTasks) Kill(ctx context.Context, req *tasksapi.KillRequest) (*emptypb.Empty, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.killed = append(f.killed, req.Signal)
+	if req.ExecID == "" {
+		f.task.Status = task.Status_STOPPED
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (f fakeTasks) Wait(ctx context.Context, req *tasksapi.WaitRequest) (*tasksapi.WaitResponse, error) {
+	return &tasksapi.WaitResponse{}, nil
+}
+
+func (f fakeTasks) Delete(ctx context.Context, req *tasksapi.DeleteTaskRequest) (*tasksapi.DeleteResponse, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.task = nil
+	return &tasksapi.DeleteResponse{ID: req.ContainerID}, nil
+}
+
+func (f fakeTasks) DeleteProcess(ctx context.Context, req *tasksapi.DeleteProcessRequest) (*tasksapi.DeleteResponse, error) {
+	return &tasksapi.DeleteResponse{ID: req.ExecID}, nil
+}
+
+type fakeSnapshots struct {
+	snapshotsapi.UnimplementedSnapshotsServer
+	*fakeContainerd
+}
+
+func (f fakeSnapshots) Mounts(ctx context.Context, req *snapshotsapi.MountsRequest) (*snapshotsapi.MountsResponse, error) {
+	return &snapshotsapi.MountsResponse{
+		Mounts: []*apitypes.Mount{{Type: "overlay", Source: "overlay"}},
+	}, nil
+}
+
+func startFakeContainerd(t *testing.T) (*fakeContainerd, string) {
+	dir := t.TempDir()
+	fake := &fakeContainerd{
+		stateDir: dir,
+		task: &task.Process{
+			ID:     testContainerID,
+			Pid:    uint32(os.Getpid()),
+			Status: task.Status_RUNNING,
+		},
+	}
+
+	sock := filepath.Join(dir, "containerd.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	versionapi.RegisterVersionServer(srv, fakeVersion{fakeContainerd: fake})
+	namespacesapi.RegisterNamespacesServer(srv, fakeNamespaces{fakeContainerd: fake})
+	containersapi.RegisterContainersServer(srv, fakeContainers{fakeContainerd: fake})
+	tasksapi.RegisterTasksServer(srv, fakeTasks{fakeContainerd: fake})
+	snapshotsapi.RegisterSnapshotsServer(srv, fakeSnapshots{fakeContainerd: fake})
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	return fake, sock
+}
+
+func TestContainerdCli(t *testing.T) {
+	ctx := context.Background()
+	fake, sock := startFakeContainerd(t)
+
+	cli, err := NewCli(config.ContainerdConfig{
+		Address: sock,
+		FifoDir: filepath.Join(t.TempDir(), "fifo"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+
+	t.Run("list", func(t *testing.T) {
+		cs := cli.List(ctx)
+		if len(cs) != 1 {
+			t.Fatalf("expect 1 container, got %d", len(cs))
+		}
+		c := cs[0]
+		if c.Name != "web" || c.State != "running" || c.Namespace != testNamespace {
+			t.Errorf("unexpected container: %+v", c)
+		}
+		if c.Command != "nginx -g daemon off;" {
+			t.Errorf("unexpected command: %s", c.Command)
+		}
+	})
+
+	t.Run("get info", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID[:12])
+		if c.ID != testContainerID {
+			t.Fatalf("container not found: %+v", c)
+		}
+		if c.Shell == "" {
+			t.Error("shell not found")
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID)
+		c.Exec = types.ExecOptions{Env: "FOO=bar", User: "0:0"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fake.m.Lock()
+		process := specs.Process{}
+		json.Unmarshal(fake.execReq.Spec.Value, &process)
+		fake.m.Unlock()
+		if !process.Terminal || process.Args[0] != c.Shell || process.Cwd != "/" {
+			t.Errorf("unexpected exec process: %+v", process)
+		}
+		if env := strings.Join(process.Env, " "); !strings.Contains(env, "FOO=bar") {
+			t.Errorf("env not set: %s", env)
+		}
+
+		p := make([]byte, 10)
+		n, err := tty.Read(p)
+		if err != nil || string(p[:n]) != "$ " {
+			t.Fatalf("read prompt: %q, %v", p[:n], err)
+		}
+		if _, err := tty.Write([]byte("pwd")); err != nil {
+			t.Fatal(err)
+		}
+		n, err = tty.Read(p)
+		if err != nil || string(p[:n]) != "pwd" {
+			t.Fatalf("read echo: %q, %v", p[:n], err)
+		}
+
+		if err := tty.ResizeTerminal(80, 24); err != nil {
+			t.Error(err)
+		}
+		fake.m.Lock()
+		if r := fake.resizeReq; r == nil || r.Width != 80 || r.Height != 24 {
+			t.Errorf("unexpected resize: %v", r)
+		}
+		execID := fake.execReq.ExecID
+		fake.m.Unlock()
+
+		if err := tty.Exit(); err != nil {
+			t.Error(err)
+		}
+		if _, err := os.Stat(filepath.Join(cli.fifoDir, execID)); !os.IsNotExist(err) {
+			t.Errorf("fifo dir not removed: %v", err)
+		}
+	})
+
+	t.Run("privileged exec", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID)
+		c.Exec = types.ExecOptions{Privileged: true}
+		if _, err := cli.Exec(ctx, c); err == nil {
+			t.Error("expect error of privileged exec")
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		logs := []string{
+			`{"log":"line 1\n","stream":"stdout","time":"2024-01-01T00:00:00Z"}`,
+			`{"log":"line 2\n","stream":"stderr","time":"2024-01-01T00:00:01Z"}`,
+			`{"log":"line 3\n","stream":"stdout","time":"2024-01-01T00:00:02Z"}`,
+		}
+		logPath := filepath.Join(fake.stateDir, testContainerID+"-json.log")
+		if err := os.WriteFile(logPath, []byte(strings.Join(logs, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		rc, err := cli.Logs(ctx, types.LogOptions{ID: testContainerID, Tail: "2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		bs, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != "line 2\r\nline 3\r\n" {
+			t.Errorf("unexpected logs: %q", bs)
+		}
+	})
+
+	t.Run("stop and start", func(t *testing.T) {
+		if err := cli.Stop(ctx, testContainerID); err != nil {
+			t.Fatal(err)
+		}
+		fake.m.Lock()
+		if len(fake.killed) == 0 || fake.killed[len(fake.killed)-1] != uint32(syscall.SIGTERM) {
+			t.Errorf("task not terminated: %v", fake.killed)
+		}
+		if fake.task != nil {
+			t.Error("task not deleted")
+		}
+		fake.m.Unlock()
+
+		if err := cli.Restart(ctx, testContainerID); err == nil {
+			t.Error("expect error of restarting a container without task")
+		}
+
+		if err := cli.Start(ctx, testContainerID); err != nil {
+			t.Fatal(err)
+		}
+		fake.m.Lock()
+		defer fake.m.Unlock()
+		if fake.createReq == nil || len(fake.createReq.Rootfs) != 1 {
+			t.Errorf("unexpected create request: %v", fake.createReq)
+		}
+		if fake.task == nil || fake.task.Status != task.Status_RUNNING {
+			t.Errorf("task not started: %v", fake.task)
+		}
+		for _, ns := range fake.namespaces {
+			if ns != testNamespace {
+				t.Errorf("unexpected namespace: %s", ns)
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package containerd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// jsonLog is a line of the json-file log driver
+type jsonLog struct {
+	Log    string `json:"log"`
+	Stream string `json:"stream"`
+	Time   string `json:"time"`
+}
+
+type jsonLogReader struct {
+	rc      io.ReadCloser
+	scanner *bufio.Scanner
+	buff    bytes.Buffer
+}
+
+func newJSONLogReader(rc io.ReadCloser) io.ReadCloser {
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 4096), 1<<20)
+	return &jsonLogReader{
+		rc:      rc,
+		scanner: scanner,
+	}
+}
+
+// Read converts the json lines to terminal outputs
+func (r *jsonLogReader) Read(p []byte) (int, error) {
+	for r.buff.Len() == 0 {
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		line := jsonLog{}
+		if err := json.Unmarshal(r.scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		r.buff.WriteString(strings.ReplaceAll(line.Log, "\n", "\r\n"))
+	}
+	return r.buff.Read(p)
+}
+
+func (r *jsonLogReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,190 @@
+package containerd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/fifo"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// processTypeURL is the type of the exec process spec
+const processTypeURL = "types.containerd.io/opencontainers/runtime-spec/1/Process"
+
+// execInjector implement webtty.Slave
+type execInjector struct {
+	tasks       tasksapi.TasksClient
+	namespace   string
+	containerID string
+	execID      string
+	fifoDir     string
+
+	stdin      io.WriteCloser
+	stdout     io.ReadCloser
+	activeChan chan struct{}
+}
+
+func newExecInjector(ctx context.Context, tasks tasksapi.TasksClient,
+	fifoDir, containerID, namespace string, process *specs.Process) (*execInjector, error) {
+	spec, err := json.Marshal(process)
+	if err != nil {
+		return nil, err
+	}
+
+	execID := fmt.Sprintf("web-tty-%d", time.Now().UnixNano())
+	dir := filepath.Join(fifoDir, execID)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	enj := &execInjector{
+		tasks:       tasks,
+		namespace:   namespace,
+		containerID: containerID,
+		execID:      execID,
+		fifoDir:     dir,
+		activeChan:  make(chan struct{}, 5),
+	}
+
+	stdinPath := filepath.Join(dir, "stdin")
+	stdoutPath := filepath.Join(dir, "stdout")
+	enj.stdin, err = fifo.OpenFifo(ctx, stdinPath,
+		syscall.O_WRONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+	if err != nil {
+		enj.cleanup()
+		return nil, err
+	}
+	enj.stdout, err = fifo.OpenFifo(ctx, stdoutPath,
+		syscall.O_RDONLY|syscall.O_CREAT|syscall.O_NONBLOCK, 0700)
+	if err != nil {
+		enj.cleanup()
+		return nil, err
+	}
+
+	nsCtx := withNamespace(ctx, namespace)
+	if _, err := tasks.Exec(nsCtx, &tasksapi.ExecProcessRequest{
+		ContainerID: containerID,
+		ExecID:      execID,
+		Stdin:       stdinPath,
+		Stdout:      stdoutPath,
+		Terminal:    true,
+		Spec: &anypb.Any{
+			TypeUrl: processTypeURL,
+			Value:   spec,
+		},
+	}); err != nil {
+		enj.cleanup()
+		return nil, err
+	}
+	if _, err := tasks.Start(nsCtx, &tasksapi.StartRequest{
+		ContainerID: containerID,
+		ExecID:      execID,
+	}); err != nil {
+		enj.deleteProcess()
+		enj.cleanup()
+		return nil, err
+	}
+
+	return enj, nil
+}
+
+func (enj *execInjector) Read(p []byte) (n int, err error) {
+	go func() {
+		if len(enj.activeChan) != 0 {
+			return
+		}
+		enj.activeChan <- struct{}{}
+	}()
+	return enj.stdout.Read(p)
+}
+
+func (enj *execInjector) Write(p []byte) (n int, err error) {
+	return enj.stdin.Write(p)
+}
+
+func (enj *execInjector) Exit() error {
+	ctx, cancel := context.WithTimeout(
+		withNamespace(context.Background(), enj.namespace), time.Second*3)
+	defer cancel()
+	_, err := enj.tasks.Kill(ctx, &tasksapi.KillRequest{
+		ContainerID: enj.containerID,
+		ExecID:      enj.execID,
+		Signal:      uint32(syscall.SIGKILL),
+	})
+	if err != nil {
+		logrus.Warnf("kill exec %s error: %s", enj.execID, err)
+	} else if _, err := enj.tasks.Wait(ctx, &tasksapi.WaitRequest{
+		ContainerID: enj.containerID,
+		ExecID:      enj.execID,
+	}); err != nil {
+		logrus.Warnf("wait exec %s error: %s", enj.execID, err)
+	}
+	enj.deleteProcess()
+	close(enj.activeChan)
+	return enj.cleanup()
+}
+
+func (enj *execInjector) deleteProcess() {
+	ctx, cancel := context.WithTimeout(
+		withNamespace(context.Background(), enj.namespace), time.Second*3)
+	defer cancel()
+	if _, err := enj.tasks.DeleteProcess(ctx, &tasksapi.DeleteProcessRequest{
+		ContainerID: enj.containerID,
+		ExecID:      enj.execID,
+	}); err != nil {
+		logrus.Warnf("delete exec %s error: %s", enj.execID, err)
+	}
+}
+
+// cleanup closes the fifos and removes them
+func (enj *execInjector) cleanup() error {
+	if enj.stdin != nil {
+		enj.stdin.Close()
+	}
+	if enj.stdout != nil {
+		enj.stdout.Close()
+	}
+	return os.RemoveAll(enj.fifoDir)
+}
+
+func (enj *execInjector) ActiveChan() <-chan struct{} {
+	return enj.activeChan
+}
+
+func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (enj *execInjector) ResizeTerminal(width int, height int) (err error) {
+	// since the process may not up so fast, give it 150ms
+	// retry 3 times
+	for i := 0; i < 3; i++ {
+		if err = enj.resize(width, height); err == nil {
+			return
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	return
+}
+
+func (enj *execInjector) resize(width int, height int) error {
+	ctx, cancel := context.WithTimeout(
+		withNamespace(context.Background(), enj.namespace), time.Second)
+	defer cancel()
+	_, err := enj.tasks.ResizePty(ctx, &tasksapi.ResizePtyRequest{
+		ContainerID: enj.containerID,
+		ExecID:      enj.execID,
+		Width:       uint32(width),
+		Height:      uint32(height),
+	})
+	return err
+}
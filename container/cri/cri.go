@@ -0,0 +1,329 @@
+package cri
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// stopTimeout is the seconds to wait before killing the container
+const stopTimeout = 10
+
+type CriCli struct {
+	conn       *grpc.ClientConn
+	runtime    runtimeapi.RuntimeServiceClient
+	containers *types.Containers
+}
+
+func NewCli(conf config.CRIConfig) (*CriCli, error) {
+	addr := conf.Endpoint
+	if !strings.Contains(addr, "://") {
+		addr = "unix://" + addr
+	}
+	logrus.Infof("CRI connecting to %s", addr)
+
+	conn, err := grpc.NewClient(addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("create CRI client error: %s", err)
+	}
+
+	runtime := runtimeapi.NewRuntimeServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	version, err := runtime.Version(ctx, &runtimeapi.VersionRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	logrus.Infof("New CRI client: runtime [%s %s], API [%s]",
+		version.RuntimeName, version.RuntimeVersion, version.RuntimeApiVersion)
+
+	cli := &CriCli{
+		conn:       conn,
+		runtime:    runtime,
+		containers: &types.Containers{},
+	}
+	cli.List(context.Background())
+
+	return cli, nil
+}
+
+func (cri *CriCli) GetInfo(ctx context.Context, cid string) types.Container {
+	container := cri.containers.Find(cid)
+	if container.ID == "" {
+		// maybe it's a new container
+		logrus.Debugf("container %s not in cache, list again", cid)
+		cri.List(ctx)
+		if container = cri.containers.Find(cid); container.ID == "" {
+			return types.Container{}
+		}
+	}
+
+	if container.Shell == "" {
+		shell := cri.getShell(ctx, container.ID)
+		container.Shell = shell
+		cri.containers.SetShell(container.ID, shell)
+	}
+	logrus.Debugf("found valid container: %s (%s)", container.ID, container.Shell)
+	return container
+}
+
+func (cri *CriCli) List(ctx context.Context) []types.Container {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	sandboxes, err := cri.runtime.ListPodSandbox(ctx, &runtimeapi.ListPodSandboxRequest{})
+	if err != nil {
+		logrus.Errorf("list pod sandboxes error: %s", err)
+		return nil
+	}
+	pods := make(map[string]*runtimeapi.PodSandbox, len(sandboxes.Items))
+	for _, pod := range sandboxes.Items {
+		pods[pod.Id] = pod
+	}
+
+	resp, err := cri.runtime.ListContainers(ctx, &runtimeapi.ListContainersRequest{})
+	if err != nil {
+		logrus.Errorf("list CRI containers error: %s", err)
+		return nil
+	}
+
+	podIPs := make(map[string][]string, len(pods))
+	containers := []types.Container{}
+	for _, c := range resp.Containers {
+		ips, ok := podIPs[c.PodSandboxId]
+		if !ok {
+			ips = cri.sandboxIPs(ctx, pods[c.PodSandboxId])
+			podIPs[c.PodSandboxId] = ips
+		}
+
+		container := convert2Container(c, pods[c.PodSandboxId], ips)
+		if old := cri.containers.Find(c.Id); old.ID == c.Id {
+			container.Shell = old.Shell
+		}
+		containers = append(containers, container)
+	}
+
+	cri.containers.Set(containers)
+	logrus.Debugf("list %d containers", len(containers))
+
+	return containers
+}
+
+// sandboxIPs returns the IPs of a ready pod sandbox
+func (cri *CriCli) sandboxIPs(ctx context.Context, pod *runtimeapi.PodSandbox) []string {
+	ips := []string{}
+	if pod != nil && pod.State == runtimeapi.PodSandboxState_SANDBOX_READY {
+		resp, err := cri.runtime.PodSandboxStatus(ctx,
+			&runtimeapi.PodSandboxStatusRequest{PodSandboxId: pod.Id})
+		if err != nil {
+			logrus.Warnf("get pod sandbox %s status error: %s", pod.Id, err)
+		} else if network := resp.GetStatus().GetNetwork(); network.GetIp() != "" {
+			ips = append(ips, network.Ip)
+			for _, ip := range network.AdditionalIps {
+				ips = append(ips, ip.Ip)
+			}
+		}
+	}
+	if len(ips) == 0 {
+		ips = []string{"null"}
+	}
+	return ips
+}
+
+func convert2Container(c *runtimeapi.Container, pod *runtimeapi.PodSandbox, ips []string) types.Container {
+	name := c.GetMetadata().GetName()
+	container := types.Container{
+		ID:            c.Id,
+		Name:          name,
+		ContainerName: name,
+		Image:         c.GetImage().GetImage(),
+		IPs:           ips,
+		State:         strings.ToLower(strings.TrimPrefix(c.State.String(), "CONTAINER_")),
+		Status: fmt.Sprintf("age: %s; restart %d",
+			time.Since(time.Unix(0, c.CreatedAt)).Round(time.Second),
+			c.GetMetadata().GetAttempt()),
+	}
+	if pod != nil {
+		container.PodName = pod.GetMetadata().GetName()
+		container.Namespace = pod.GetMetadata().GetNamespace()
+	}
+	return container
+}
+
+func (cri *CriCli) exist(ctx context.Context, cid, path string) bool {
+	resp, err := cri.runtime.ExecSync(ctx, &runtimeapi.ExecSyncRequest{
+		ContainerId: cid,
+		Cmd:         []string{"ls", path},
+		Timeout:     1,
+	})
+	if err != nil {
+		logrus.Debugf("exist exec error: [%v]", err)
+		return false
+	}
+	return resp.ExitCode == 0
+}
+
+func (cri *CriCli) getShell(ctx context.Context, cid string) string {
+	for _, sh := range config.SHELL_LIST {
+		if cri.exist(ctx, cid, sh) {
+			logrus.Debugf("container [%s] use [%s]", cid, sh)
+			return sh
+		}
+	}
+	return ""
+}
+
+func (cri *CriCli) find(ctx context.Context, cid string) (types.Container, error) {
+	c := cri.containers.Find(cid)
+	if c.ID == "" {
+		if c = cri.GetInfo(ctx, cid); c.ID == "" {
+			return c, fmt.Errorf("container %s not found", cid)
+		}
+	}
+	return c, nil
+}
+
+// Start starts a created container, the exited ones are
+// recreated by the kubelet instead
+func (cri *CriCli) Start(ctx context.Context, cid string) error {
+	c, err := cri.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	_, err = cri.runtime.StartContainer(ctx,
+		&runtimeapi.StartContainerRequest{ContainerId: c.ID})
+	return err
+}
+
+func (cri *CriCli) Stop(ctx context.Context, cid string) error {
+	c, err := cri.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	_, err = cri.runtime.StopContainer(ctx, &runtimeapi.StopContainerRequest{
+		ContainerId: c.ID,
+		Timeout:     stopTimeout,
+	})
+	return err
+}
+
+func (cri *CriCli) Restart(ctx context.Context, cid string) error {
+	return fmt.Errorf("restart is not supported by the CRI backend, " +
+		"stop the container and let the kubelet recreate it")
+}
+
+func (cri *CriCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	opts := c.Exec
+	if opts.User != "" {
+		return nil, fmt.Errorf("exec as user %s is not supported by the CRI backend", opts.User)
+	}
+	if opts.Privileged {
+		return nil, fmt.Errorf("privileged exec is not supported by the CRI backend")
+	}
+	if opts.Debug {
+		return nil, fmt.Errorf("the debug mode is not supported by the CRI backend")
+	}
+
+	// CRI can't set the environments, wrap the shell with env
+	cmds := []string{"env", "HISTCONTROL=ignoredups", "TERM=xterm"}
+	if opts.Env != "" {
+		cmds = append(cmds, strings.Split(opts.Env, " ")...)
+	}
+	if len(opts.Args) != 0 {
+		cmds = append(cmds, opts.Args...)
+	} else {
+		cmds = append(cmds, c.Shell)
+		if opts.Cmd != "" {
+			cmds = append(cmds, "-c", opts.Cmd)
+		}
+	}
+	logrus.Debugf("exec cmd: %v", cmds)
+
+	resp, err := cri.runtime.Exec(ctx, &runtimeapi.ExecRequest{
+		ContainerId: c.ID,
+		Cmd:         cmds,
+		Tty:         true,
+		Stdin:       true,
+		Stdout:      true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	u, err := url.Parse(resp.Url)
+	if err != nil {
+		return nil, fmt.Errorf("bad streaming URL %s: %s", resp.Url, err)
+	}
+
+	// the streaming server of the runtime uses a self-signed certificate
+	// if the TLS is enabled
+	restConfig := &restclient.Config{
+		TLSClientConfig: restclient.TLSClientConfig{Insecure: true},
+	}
+	logrus.Debugf("POST to %s", u)
+	exec, err := remotecommand.NewSPDYExecutor(restConfig, "POST", u)
+	if err != nil {
+		return nil, err
+	}
+
+	enj := newInjector(ctx)
+	go func() {
+		err := exec.StreamWithContext(context.Background(), remotecommand.StreamOptions{
+			Stdin:             enj.ttyIn,
+			Stdout:            enj.ttyOut,
+			Tty:               true,
+			TerminalSizeQueue: enj.sq,
+		})
+		if err != nil {
+			logrus.Errorf("exec error: [%v]", err)
+		}
+		logrus.Debug("exec done")
+		enj.ttyIn.Close()
+		enj.ttyOut.Close()
+	}()
+
+	return &enj, nil
+}
+
+func (cri *CriCli) Close() error {
+	return cri.conn.Close()
+}
+
+// Logs reads the log file of the container written by the runtime
+func (cri *CriCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	c, err := cri.find(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cri.runtime.ContainerStatus(ctx,
+		&runtimeapi.ContainerStatusRequest{ContainerId: c.ID})
+	if err != nil {
+		return nil, err
+	}
+	logPath := resp.GetStatus().GetLogPath()
+	if !filepath.IsAbs(logPath) {
+		return nil, fmt.Errorf("logs of container %s are not available", c.ID)
+	}
+
+	rc, err := util.TailFile(ctx, logPath, util.ParseTail(opts.Tail), opts.Follow)
+	if err != nil {
+		return nil, err
+	}
+	return newCRILogReader(rc), nil
+}
@@ -0,0 +1,294 @@
+package cri
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/client-go/tools/remotecommand"
+	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+	"k8s.io/kubelet/pkg/cri/streaming"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+const (
+	testContainerID = "0123456789abcdef0123456789abcdef"
+	testSandboxID   = "fedcba9876543210fedcba9876543210"
+)
+
+// fakeCRI is a fake runtime service, the exec sessions are
+// served by the kubelet streaming server
+type fakeCRI struct {
+	runtimeapi.UnimplementedRuntimeServiceServer
+
+	streaming streaming.Server
+	logPath   string
+
+	m       sync.Mutex
+	execCmd []string
+	resized []remotecommand.TerminalSize
+	stopped []string
+}
+
+func (f *fakeCRI) Version(context.Context, *runtimeapi.VersionRequest) (*runtimeapi.VersionResponse, error) {
+	return &runtimeapi.VersionResponse{
+		RuntimeName:       "fake",
+		RuntimeVersion:    "1.0.0",
+		RuntimeApiVersion: "v1",
+	}, nil
+}
+
+func (f *fakeCRI) ListPodSandbox(context.Context, *runtimeapi.ListPodSandboxRequest) (*runtimeapi.ListPodSandboxResponse, error) {
+	return &runtimeapi.ListPodSandboxResponse{
+		Items: []*runtimeapi.PodSandbox{{
+			Id:    testSandboxID,
+			State: runtimeapi.PodSandboxState_SANDBOX_READY,
+			Metadata: &runtimeapi.PodSandboxMetadata{
+				Name:      "nginx-7d9c",
+				Namespace: "web",
+			},
+		}},
+	}, nil
+}
+
+func (f *fakeCRI) PodSandboxStatus(context.Context, *runtimeapi.PodSandboxStatusRequest) (*runtimeapi.PodSandboxStatusResponse, error) {
+	return &runtimeapi.PodSandboxStatusResponse{
+		Status: &runtimeapi.PodSandboxStatus{
+			Id:      testSandboxID,
+			Network: &runtimeapi.PodSandboxNetworkStatus{Ip: "10.0.0.2"},
+		},
+	}, nil
+}
+
+func (f *fakeCRI) ListContainers(context.Context, *runtimeapi.ListContainersRequest) (*runtimeapi.ListContainersResponse, error) {
+	return &runtimeapi.ListContainersResponse{
+		Containers: []*runtimeapi.Container{{
+			Id:           testContainerID,
+			PodSandboxId: testSandboxID,
+			Metadata:     &runtimeapi.ContainerMetadata{Name: "nginx", Attempt: 2},
+			Image:        &runtimeapi.ImageSpec{Image: "nginx:latest"},
+			State:        runtimeapi.ContainerState_CONTAINER_RUNNING,
+			CreatedAt:    time.Now().Add(-time.Minute).UnixNano(),
+		}},
+	}, nil
+}
+
+func (f *fakeCRI) ContainerStatus(context.Context, *runtimeapi.ContainerStatusRequest) (*runtimeapi.ContainerStatusResponse, error) {
+	return &runtimeapi.ContainerStatusResponse{
+		Status: &runtimeapi.ContainerStatus{
+			Id:      testContainerID,
+			LogPath: f.logPath,
+		},
+	}, nil
+}
+
+func (f *fakeCRI) ExecSync(_ context.Context, req *runtimeapi.ExecSyncRequest) (*runtimeapi.ExecSyncResponse, error) {
+	// only /bin/sh exists
+	if strings.Join(req.Cmd, " ") == "ls /bin/sh" {
+		return &runtimeapi.ExecSyncResponse{}, nil
+	}
+	return &runtimeapi.ExecSyncResponse{ExitCode: 1}, nil
+}
+
+func (f *fakeCRI) Exec(_ context.Context, req *runtimeapi.ExecRequest) (*runtimeapi.ExecResponse, error) {
+	return f.streaming.GetExec(req)
+}
+
+func (f *fakeCRI) StopContainer(_ context.Context, req *runtimeapi.StopContainerRequest) (*runtimeapi.StopContainerResponse, error) {
+	f.m.Lock()
+	f.stopped = append(f.stopped, req.ContainerId)
+	f.m.Unlock()
+	return &runtimeapi.StopContainerResponse{}, nil
+}
+
+// fakeStreamRuntime is a shell that echoes the inputs
+type fakeStreamRuntime struct {
+	*fakeCRI
+}
+
+func (f fakeStreamRuntime) Exec(ctx context.Context, containerID string, cmd []string,
+	in io.Reader, out, _ io.WriteCloser, tty bool, resize <-chan remotecommand.TerminalSize) error {
+	f.m.Lock()
+	f.execCmd = cmd
+	f.m.Unlock()
+	go func() {
+		for size := range resize {
+			f.m.Lock()
+			f.resized = append(f.resized, size)
+			f.m.Unlock()
+		}
+	}()
+	io.WriteString(out, "$ ")
+	_, err := io.Copy(out, in)
+	return err
+}
+
+func (f fakeStreamRuntime) Attach(context.Context, string, io.Reader, io.WriteCloser,
+	io.WriteCloser, bool, <-chan remotecommand.TerminalSize) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (f fakeStreamRuntime) PortForward(context.Context, string, int32, io.ReadWriteCloser) error {
+	return fmt.Errorf("not implemented")
+}
+
+func newTestCli(t *testing.T) (*CriCli, *fakeCRI) {
+	dir := t.TempDir()
+	f := &fakeCRI{logPath: filepath.Join(dir, "nginx.log")}
+
+	// the streaming server
+	srv := httptest.NewUnstartedServer(nil)
+	streamConfig := streaming.DefaultConfig
+	streamConfig.BaseURL = &url.URL{Scheme: "http", Host: srv.Listener.Addr().String()}
+	stream, err := streaming.NewServer(streamConfig, fakeStreamRuntime{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.streaming = stream
+	srv.Config.Handler = stream
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	// the runtime service
+	sock := filepath.Join(dir, "cri.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	runtimeapi.RegisterRuntimeServiceServer(s, f)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+
+	cli, err := NewCli(config.CRIConfig{Endpoint: sock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return cli, f
+}
+
+func TestCRI(t *testing.T) {
+	ctx := context.Background()
+	cli, f := newTestCli(t)
+
+	t.Run("list", func(t *testing.T) {
+		containers := cli.List(ctx)
+		if len(containers) != 1 {
+			t.Fatalf("expect 1 container, got %d", len(containers))
+		}
+		c := containers[0]
+		if c.Name != "nginx" || c.PodName != "nginx-7d9c" || c.Namespace != "web" {
+			t.Errorf("unexpected container %+v", c)
+		}
+		if c.State != "running" || c.IPs[0] != "10.0.0.2" {
+			t.Errorf("unexpected container %+v", c)
+		}
+		if !strings.HasSuffix(c.Status, "restart 2") {
+			t.Errorf("unexpected status %q", c.Status)
+		}
+	})
+
+	t.Run("get info", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID[:12])
+		if c.ID != testContainerID {
+			t.Fatalf("container not found: %+v", c)
+		}
+		if c.Shell != "/bin/sh" {
+			t.Errorf("expect shell /bin/sh, got %q", c.Shell)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID)
+		c.Exec = types.ExecOptions{Env: "A=1", Cmd: "top"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Exit()
+
+		if err := tty.ResizeTerminal(80, 24); err != nil {
+			t.Error(err)
+		}
+		if _, err := tty.Write([]byte("ls\n")); err != nil {
+			t.Fatal(err)
+		}
+		line, err := bufio.NewReader(tty).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != "$ ls\r\n" && line != "$ ls\n" {
+			t.Errorf("unexpected output %q", line)
+		}
+
+		f.m.Lock()
+		defer f.m.Unlock()
+		cmd := strings.Join(f.execCmd, " ")
+		if cmd != "env HISTCONTROL=ignoredups TERM=xterm A=1 /bin/sh -c top" {
+			t.Errorf("unexpected cmd %s", cmd)
+		}
+		if len(f.resized) == 0 || f.resized[0].Width != 80 || f.resized[0].Height != 24 {
+			t.Errorf("unexpected resize %v", f.resized)
+		}
+	})
+
+	t.Run("unsupported exec options", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID)
+		c.Exec = types.ExecOptions{User: "nobody"}
+		if _, err := cli.Exec(ctx, c); err == nil {
+			t.Error("expect error of the user option")
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		lines := []string{
+			"2024-01-01T00:00:00.000000000Z stdout F line 1",
+			"2024-01-01T00:00:01.000000000Z stderr P line ",
+			"2024-01-01T00:00:01.000000000Z stderr F 2",
+			"2024-01-01T00:00:02.000000000Z stdout F line 3",
+		}
+		if err := os.WriteFile(f.logPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		rc, err := cli.Logs(ctx, types.LogOptions{ID: testContainerID, Tail: "3"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		bs, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != "line 2\r\nline 3\r\n" {
+			t.Errorf("unexpected logs %q", bs)
+		}
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		if err := cli.Stop(ctx, testContainerID); err != nil {
+			t.Error(err)
+		}
+		f.m.Lock()
+		if len(f.stopped) != 1 || f.stopped[0] != testContainerID {
+			t.Errorf("unexpected stopped containers %v", f.stopped)
+		}
+		f.m.Unlock()
+		if err := cli.Restart(ctx, testContainerID); err == nil {
+			t.Error("expect error of restart")
+		}
+	})
+}
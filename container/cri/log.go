@@ -0,0 +1,50 @@
+package cri
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// criLogReader converts the CRI log lines to terminal outputs, the format is
+// "<RFC3339Nano time> <stdout|stderr> <P|F> <content>", P is a partial line
+type criLogReader struct {
+	rc      io.ReadCloser
+	scanner *bufio.Scanner
+	buff    bytes.Buffer
+}
+
+func newCRILogReader(rc io.ReadCloser) io.ReadCloser {
+	scanner := bufio.NewScanner(rc)
+	scanner.Buffer(make([]byte, 4096), 1<<20)
+	return &criLogReader{
+		rc:      rc,
+		scanner: scanner,
+	}
+}
+
+func (r *criLogReader) Read(p []byte) (int, error) {
+	for r.buff.Len() == 0 {
+		if !r.scanner.Scan() {
+			if err := r.scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		fields := bytes.SplitN(r.scanner.Bytes(), []byte(" "), 4)
+		if len(fields) < 3 {
+			continue
+		}
+		if len(fields) == 4 {
+			r.buff.Write(fields[3])
+		}
+		if string(fields[2]) != "P" {
+			r.buff.WriteString("\r\n")
+		}
+	}
+	return r.buff.Read(p)
+}
+
+func (r *criLogReader) Close() error {
+	return r.rc.Close()
+}
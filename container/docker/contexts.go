@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultContext is the implicit context of the docker CLI, it's the
+// configured docker host
+const defaultContext = "default"
+
+// contextMeta is the meta.json of a context in the store of the docker
+// CLI, ~/.docker/contexts/meta/<digest of the name>/meta.json
+type contextMeta struct {
+	Name      string
+	Endpoints map[string]struct {
+		Host          string
+		SkipTLSVerify bool
+	}
+}
+
+// loadContexts reads the docker endpoints of the contexts from the store
+// of the docker CLI, "all" means all of them, the TLS material of a context
+// is in ~/.docker/contexts/tls/<digest of the name>/docker
+func loadContexts(configDir, defaultHost string, names []string) ([]endpoint, error) {
+	metaDirs, err := filepath.Glob(filepath.Join(configDir, "contexts", "meta", "*"))
+	if err != nil {
+		return nil, err
+	}
+
+	store := map[string]endpoint{}
+	for _, metaDir := range metaDirs {
+		bs, err := os.ReadFile(filepath.Join(metaDir, "meta.json"))
+		if err != nil {
+			continue
+		}
+		var meta contextMeta
+		if err := json.Unmarshal(bs, &meta); err != nil {
+			logrus.Warnf("bad docker context %s: %s", metaDir, err)
+			continue
+		}
+		e := endpoint{name: meta.Name}
+		docker, ok := meta.Endpoints["docker"]
+		if !ok || docker.Host == "" {
+			e.err = fmt.Errorf("no docker endpoint")
+			store[meta.Name] = e
+			continue
+		}
+
+		certs := filepath.Join(configDir, "contexts", "tls", filepath.Base(metaDir), "docker")
+		if _, err := os.Stat(certs); err != nil {
+			certs = ""
+		}
+		if e, err = parseEndpoint(docker.Host, certs); err != nil {
+			e.err = err
+		}
+		e.name = meta.Name
+		e.skipVerify = docker.SkipTLSVerify
+		store[meta.Name] = e
+	}
+
+	if _, exist := store[defaultContext]; !exist {
+		e, err := parseEndpoint(defaultHost, "")
+		if err != nil {
+			e.err = err
+		}
+		e.name = defaultContext
+		store[defaultContext] = e
+	}
+
+	if len(names) == 1 && names[0] == "all" {
+		names = make([]string, 0, len(store))
+		for name := range store {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	endpoints := make([]endpoint, 0, len(names))
+	for _, name := range names {
+		e, exist := store[name]
+		if !exist {
+			e = endpoint{name: name, err: fmt.Errorf("no such context")}
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, nil
+}
@@ -0,0 +1,66 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadContexts(t *testing.T) {
+	dir := t.TempDir()
+	writeContext := func(id, meta string, certs bool) {
+		metaDir := filepath.Join(dir, "contexts", "meta", id)
+		if err := os.MkdirAll(metaDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(metaDir, "meta.json"), []byte(meta), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if certs {
+			tlsDir := filepath.Join(dir, "contexts", "tls", id, "docker")
+			if err := os.MkdirAll(tlsDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	writeContext("1", `{"Name":"prod","Endpoints":{"docker":{"Host":"tcp://10.0.0.2:2376","SkipTLSVerify":true}}}`, true)
+	writeContext("2", `{"Name":"remote","Endpoints":{"docker":{"Host":"ssh://root@10.0.0.3"}}}`, false)
+	writeContext("3", `{"Name":"kube-only","Endpoints":{"kubernetes":{}}}`, false)
+	writeContext("4", `not a json`, false)
+
+	endpoints, err := loadContexts(dir, "/var/run/docker.sock", []string{"all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, e := range endpoints {
+		names = append(names, e.name)
+	}
+	if len(endpoints) != 4 || names[0] != "default" || names[1] != "kube-only" ||
+		names[2] != "prod" || names[3] != "remote" {
+		t.Fatalf("unexpected contexts %v", names)
+	}
+
+	def, kubeOnly, prod, remote := endpoints[0], endpoints[1], endpoints[2], endpoints[3]
+	if def.host != "unix:///var/run/docker.sock" || def.err != nil {
+		t.Errorf("unexpected default context %+v", def)
+	}
+	if kubeOnly.err == nil {
+		t.Error("expect error of the context without docker endpoint")
+	}
+	if prod.host != "tcp://10.0.0.2:2376" || !prod.skipVerify ||
+		prod.certs != filepath.Join(dir, "contexts", "tls", "1", "docker") {
+		t.Errorf("unexpected prod context %+v", prod)
+	}
+	if remote.ssh == nil || remote.certs != "" {
+		t.Errorf("unexpected remote context %+v", remote)
+	}
+
+	endpoints, err = loadContexts(dir, "/var/run/docker.sock", []string{"remote", "gone"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 2 || endpoints[0].err != nil || endpoints[1].err == nil {
+		t.Errorf("unexpected contexts %+v", endpoints)
+	}
+}
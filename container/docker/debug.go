@@ -0,0 +1,141 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// debugLabel marks the helper containers, the value is the target
+const debugLabel = "container-web-tty/debug"
+
+// debugConfig returns the config of the helper container, it joins the
+// pid and network namespaces of the target, so the target's filesystem
+// is reachable under /proc/1/root. The IPC namespace is joined only if
+// it's shareable, the default private one can't be joined
+func debugConfig(c types.Container, image string, ipc container.IpcMode) (*container.Config, *container.HostConfig) {
+	opts := c.Exec
+	cmds := []string{"sh", "-l"}
+	if len(opts.Args) != 0 {
+		cmds = opts.Args
+	} else if opts.Cmd != "" {
+		cmds = []string{"sh", "-c", opts.Cmd}
+	}
+	env := []string{"HISTCONTROL=ignoredups", "TERM=xterm"}
+	if opts.Env != "" {
+		env = append(env, strings.Split(opts.Env, " ")...)
+	}
+
+	config := &container.Config{
+		Image:        image,
+		Cmd:          cmds,
+		Env:          env,
+		User:         opts.User,
+		Labels:       map[string]string{debugLabel: c.ID},
+		Tty:          true,
+		OpenStdin:    true,
+		StdinOnce:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+	target := "container:" + c.ID
+	hostConfig := &container.HostConfig{
+		AutoRemove:  true,
+		PidMode:     container.PidMode(target),
+		NetworkMode: container.NetworkMode(target),
+		CapAdd:      []string{"SYS_PTRACE"},
+		Privileged:  opts.Privileged,
+	}
+	if ipc.IsShareable() || ipc.IsHost() {
+		hostConfig.IpcMode = container.IpcMode(target)
+	}
+	return config, hostConfig
+}
+
+// pull pulls the image if it's not present
+func (d *DockerCli) pull(ctx context.Context, ref string) error {
+	if _, err := d.cli.ImageInspect(ctx, ref); err == nil {
+		return nil
+	}
+	logrus.Infof("pull debug image %s", ref)
+	rc, err := d.cli.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(io.Discard, rc)
+	return err
+}
+
+// debug starts a helper container with the debug image and attaches to
+// it, the helper is removed when the session ends
+func (d *DockerCli) debug(ctx context.Context, c types.Container) (types.TTY, error) {
+	if d.debugImage == "" {
+		return nil, fmt.Errorf("the debug mode is disabled, no debug image configured")
+	}
+	if err := d.pull(ctx, d.debugImage); err != nil {
+		return nil, fmt.Errorf("pull debug image %s error: %s", d.debugImage, err)
+	}
+
+	inspection, err := d.cli.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	var ipc container.IpcMode
+	if inspection.HostConfig != nil {
+		ipc = inspection.HostConfig.IpcMode
+	}
+	config, hostConfig := debugConfig(c, d.debugImage, ipc)
+	created, err := d.cli.ContainerCreate(ctx, config, hostConfig, nil, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("create debug container error: %s", err)
+	}
+	id := created.ID
+	logrus.Infof("debug container %s with %s (%s)", c.ID[:12], d.debugImage, id[:12])
+	remove := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		err := d.cli.ContainerRemove(ctx, id, container.RemoveOptions{Force: true})
+		if err != nil && !strings.Contains(err.Error(), "No such container") {
+			logrus.Warnf("remove debug container %s error: %s", id[:12], err)
+		}
+	}
+
+	resp, err := d.cli.ContainerAttach(ctx, id, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		remove()
+		return nil, err
+	}
+	if err := d.cli.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+		resp.Close()
+		remove()
+		return nil, err
+	}
+
+	resizeFunc := func(width int, height int) error {
+		return d.cli.ContainerResize(ctx, id,
+			container.ResizeOptions{
+				Width:  uint(width),
+				Height: uint(height),
+			})
+	}
+
+	enj := newExecInjector(resp, resizeFunc)
+	enj.execOptions = "debug " + d.debugImage
+	enj.cleanup = remove
+	return enj, nil
+}
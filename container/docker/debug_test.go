@@ -0,0 +1,52 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestDebugConfig(t *testing.T) {
+	c := types.Container{
+		ID:   "0123456789abcdef",
+		Exec: types.ExecOptions{Cmd: "top", Env: "A=1", User: "nobody"},
+	}
+	config, hostConfig := debugConfig(c, "busybox", "shareable")
+	if cmd := strings.Join(config.Cmd, " "); cmd != "sh -c top" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if config.Image != "busybox" || config.User != "nobody" ||
+		config.Labels[debugLabel] != c.ID || config.Env[len(config.Env)-1] != "A=1" {
+		t.Errorf("unexpected config %+v", config)
+	}
+
+	target := "container:" + c.ID
+	if string(hostConfig.PidMode) != target || string(hostConfig.NetworkMode) != target ||
+		string(hostConfig.IpcMode) != target || !hostConfig.AutoRemove {
+		t.Errorf("unexpected host config %+v", hostConfig)
+	}
+}
+
+func TestDebugConfigPrivateIPC(t *testing.T) {
+	c := types.Container{ID: "0123456789abcdef"}
+	target := "container:" + c.ID
+	for ipc, expect := range map[container.IpcMode]string{
+		"":          "",
+		"private":   "",
+		"none":      "",
+		"shareable": target,
+		"host":      target,
+	} {
+		_, hostConfig := debugConfig(c, "busybox", ipc)
+		if string(hostConfig.IpcMode) != expect {
+			t.Errorf("IPC mode %q: expect %q, got %q", ipc, expect, hostConfig.IpcMode)
+		}
+		// the pid and network namespaces are always joined
+		if string(hostConfig.PidMode) != target || string(hostConfig.NetworkMode) != target {
+			t.Errorf("unexpected host config %+v", hostConfig)
+		}
+	}
+}
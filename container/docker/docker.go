@@ -6,10 +6,10 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/moby/moby/client"
 	"github.com/sirupsen/logrus"
@@ -22,36 +22,59 @@ type DockerCli struct {
 	cli         *client.Client
 	containers  *types.Containers
 	listOptions container.ListOptions
-	lastList    time.Time
+	debugImage  string
+	host        string // the daemon, for the logs
+	loc         string // the location of the containers, set if there are several daemons
+
+	// canceled on close, stops watching the events
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// the states of the containers, and the lost connection
+	// to the daemon, the list is stale if disconnected
+	m            sync.RWMutex
+	states       map[string]*container.State
+	disconnected error
+
+	// the changes of the containers
+	hub *types.EventHub
 }
 
 func NewCli(conf config.DockerConfig) (*DockerCli, error) {
-	host := conf.DockerHost
-	if host[:1] == "/" {
-		host = "unix://" + host
-	} else if !strings.HasPrefix(host, "tcp://") {
-		host = "tcp://" + host
+	e, err := parseEndpoint(conf.DockerHost, conf.CertPath)
+	if err != nil {
+		return nil, err
 	}
+	return newCli(conf, e, "", new(types.EventHub))
+}
 
-	logrus.Infof("Docker connecting to %s", host)
+func newCli(conf config.DockerConfig, e endpoint, loc string, hub *types.EventHub) (*DockerCli, error) {
+	logrus.Infof("Docker connecting to %s", e.name)
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	opts, err := e.clientOpts()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		logrus.Errorf("create new docker client error: %s", err)
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	// the ssh hosts take a while to connect
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
 	listOptions, err := buildListOptions(conf.PsOptions)
 	if err != nil {
+		cli.Close()
 		return nil, fmt.Errorf("build ps options error: %s", err)
 	}
 	logrus.Debugf("list options: %+v", listOptions)
 
 	ping, err := cli.Ping(ctx)
 	if err != nil {
+		cli.Close()
 		return nil, err
 	}
 	logrus.Infof("New docker client: API [%s]", ping.APIVersion)
@@ -59,20 +82,18 @@ func NewCli(conf config.DockerConfig) (*DockerCli, error) {
 		cli:         cli,
 		containers:  &types.Containers{},
 		listOptions: listOptions,
+		debugImage:  conf.DebugImage,
+		host:        e.name,
+		loc:         loc,
+		states:      map[string]*container.State{},
+		hub:         hub,
 	}
+	dockerCli.ctx, dockerCli.cancel = context.WithCancel(context.Background())
 	logrus.Infof("Warm up containers info...")
 
-	// when docker restarted, should restart the program as well
-	// since the socket file is gone (as the restart of docker daemon)
-	// see #30
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		dockerCli.listContainers(ctx, true)
-		cancel()
-
-		dockerCli.watchEvents() // will block here
-		logrus.Fatal("lost connection to docker daemon")
-	}()
+	// the containers are listed once the events are subscribed,
+	// and relisted after reconnected
+	go dockerCli.watch()
 
 	return dockerCli, nil
 }
@@ -90,6 +111,9 @@ func getContainerIP(networkSettings interface{}) (ips []string) {
 	switch networkSettings.(type) {
 	case *container.NetworkSettingsSummary:
 		network := networkSettings.(*container.NetworkSettingsSummary)
+		if network == nil {
+			return
+		}
 		for net := range network.Networks {
 			if network.Networks[net] == nil {
 				continue
@@ -98,6 +122,9 @@ func getContainerIP(networkSettings interface{}) (ips []string) {
 		}
 	case *container.NetworkSettings:
 		network := networkSettings.(*container.NetworkSettings)
+		if network == nil {
+			return
+		}
 		for net := range network.Networks {
 			if network.Networks[net] == nil {
 				continue
@@ -109,33 +136,27 @@ func getContainerIP(networkSettings interface{}) (ips []string) {
 	return
 }
 
-func (d *DockerCli) watchEvents() {
-	eventChan, errChan := d.cli.Events(context.Background(), events.ListOptions{})
-
-	go func() {
-		for event := range eventChan {
-			if event.Type != "container" {
-				continue
-			}
-			logrus.Debugf("container event: %+v", event)
-			switch event.Action {
-			case "start", "destroy":
-				ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-				d.listContainers(ctx, true)
-				cancel()
-			}
-		}
-	}()
-
-	logrus.Errorf("docker cli watch events error: %s", <-errChan)
+// setDisconnected sets the connection error, nil when reconnected,
+// and returns the previous one
+func (d *DockerCli) setDisconnected(err error) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	prev := d.disconnected
+	d.disconnected = err
+	return prev
 }
 
-func (d *DockerCli) GetInfo(ctx context.Context, cid string) types.Container {
-	if d.containers.Len() == 0 {
-		logrus.Debugf("zero containers, get cid %s", cid)
-		d.List(ctx)
+// Health reports the lost connection to the daemon
+func (d *DockerCli) Health() error {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.disconnected != nil {
+		return fmt.Errorf("lost connection to docker daemon: %s", d.disconnected)
 	}
+	return nil
+}
 
+func (d *DockerCli) GetInfo(ctx context.Context, cid string) types.Container {
 	// find in containers
 	if container := d.containers.Find(cid); container.ID != "" {
 		if container.Shell == "" {
@@ -162,75 +183,26 @@ func (d *DockerCli) GetInfo(ctx context.Context, cid string) types.Container {
 }
 
 func (d *DockerCli) convert2Container(inspect container.InspectResponse) types.Container {
-	if inspect.Config == nil {
-		// WTF?
-		return types.Container{}
+	c := d.fromInspect(inspect)
+	if c.ID == "" {
+		return c
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
-	shell := d.getShell(ctx, inspect.ID)
-
-	return types.Container{
-		ID:      inspect.ID,
-		Name:    inspect.Name,
-		Image:   inspect.Image,
-		Command: fmt.Sprintf("%s", inspect.Config.Cmd),
-		IPs:     getContainerIP(inspect.NetworkSettings),
-		Status:  inspect.State.Status,
-		State:   inspect.State.Status,
-		Shell:   shell,
-	}
+	c.Shell = d.getShell(ctx, inspect.ID)
+	return c
 }
 
-func (d *DockerCli) listContainers(ctx context.Context, force bool) []types.Container {
-	if time.Now().Sub(d.lastList) < time.Minute && !force {
-		return d.containers.List()
-	}
-
-	start := time.Now()
-	logrus.Debug("list conatiners")
-	cs, err := d.cli.ContainerList(ctx, d.listOptions)
-	if err != nil {
-		logrus.Errorf("list containers eror: %s", err)
-		return nil
-	}
-
-	containers := make([]types.Container, len(cs))
-	var (
-		ips   []string
-		shell string
-	)
-	for i, container := range cs {
-		if old := d.containers.Find(container.ID); old.ID != "" {
-			shell = old.Shell
-			ips = old.IPs
-		} else {
-			ips = getContainerIP(container.NetworkSettings)
-		}
-		containers[i] = types.Container{
-			ID:      container.ID,
-			Name:    container.Names[0][1:],
-			Image:   container.Image,
-			Command: container.Command,
-			IPs:     ips,
-			Status:  container.Status,
-			State:   container.State,
-			Shell:   shell,
-		}
+// List returns the cached containers, the cache is maintained by the events
+func (d *DockerCli) List(ctx context.Context) []types.Container {
+	containers := d.containers.List()
+	for i := range containers {
+		containers[i] = d.render(containers[i])
 	}
-
-	d.containers.Set(containers)
-
-	d.lastList = time.Now()
-	logrus.Debugf("list %d containers, use %s", len(containers), time.Now().Sub(start))
 	return containers
 }
 
-func (d *DockerCli) List(ctx context.Context) []types.Container {
-	return d.listContainers(ctx, false)
-}
-
 func (d *DockerCli) exist(ctx context.Context, cid, path string) bool {
 	_, err := d.cli.ContainerStatPath(ctx, cid, path)
 	if err != nil {
@@ -303,9 +275,13 @@ func buildListOptions(options string) (container.ListOptions, error) {
 func (d *DockerCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
 	cmds := []string{c.Shell}
 	opts := c.Exec
-	if cmd := opts.Cmd; cmd != "" {
-		cmds = append(cmds, "-c")
-		cmds = append(cmds, fmt.Sprintf("\"\"%s\"\"", cmd))
+	if opts.Debug {
+		return d.debug(ctx, c)
+	}
+	if len(opts.Args) != 0 {
+		cmds = opts.Args
+	} else if opts.Cmd != "" {
+		cmds = append(cmds, "-c", opts.Cmd)
 	}
 	logrus.Debugf("exec cmd: %v", cmds)
 
@@ -355,15 +331,74 @@ func (d *DockerCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 }
 
 func (d *DockerCli) Close() error {
+	if d.cancel != nil {
+		d.cancel()
+	}
 	return d.cli.Close()
 }
 
+// Logs reads the logs of the container, which are multiplexed unless
+// the container has a TTY
 func (d *DockerCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
-	rc, err := d.cli.ContainerLogs(ctx, opts.ID, container.LogsOptions{
-		ShowStderr: true,
-		ShowStdout: true,
+	inspection, err := d.cli.ContainerInspect(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	tty := inspection.Config != nil && inspection.Config.Tty
+
+	logsOptions := container.LogsOptions{
+		ShowStderr: opts.Stream != "stdout",
+		ShowStdout: opts.Stream != "stderr",
 		Follow:     opts.Follow,
 		Tail:       opts.Tail,
+		Timestamps: opts.Timestamps,
+	}
+	if !opts.Since.IsZero() {
+		logsOptions.Since = opts.Since.Format(time.RFC3339Nano)
+	}
+	if !opts.Until.IsZero() {
+		logsOptions.Until = opts.Until.Format(time.RFC3339Nano)
+	}
+	rc, err := d.cli.ContainerLogs(ctx, opts.ID, logsOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newLogReader(rc, tty), nil
+}
+
+// Attach attaches to the main process of the container, the session
+// detaches with ^P^Q on exit
+func (d *DockerCli) Attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	inspect, err := d.cli.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	if inspect.Config == nil || !inspect.Config.OpenStdin || !inspect.Config.Tty {
+		return nil, fmt.Errorf("cannot attach to container %s, it's not started "+
+			"with an interactive TTY (-ti)", c.Name)
+	}
+
+	resp, err := d.cli.ContainerAttach(ctx, c.ID, container.AttachOptions{
+		Stream:     true,
+		Stdin:      true,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: "ctrl-p,ctrl-q",
 	})
-	return parseContainerLog(rc), err
+	if err != nil {
+		return nil, err
+	}
+
+	resizeFunc := func(width int, height int) error {
+		return d.cli.ContainerResize(ctx, c.ID,
+			container.ResizeOptions{
+				Width:  uint(width),
+				Height: uint(height),
+			})
+	}
+
+	enj := newExecInjector(resp, resizeFunc)
+	enj.execOptions = "attached"
+	enj.detachKeys = []byte{16, 17} // ^P, ^Q
+	return enj, nil
 }
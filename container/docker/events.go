@@ -0,0 +1,343 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/go-units"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// the backoff of reconnecting to the daemon
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Second * 30
+)
+
+// cacheEvents change the listed containers, the container is inspected
+// again on them, the health_status events are matched by the prefix
+var cacheEvents = map[events.Action]bool{
+	events.ActionCreate:  true,
+	events.ActionStart:   true,
+	events.ActionRestart: true,
+	events.ActionStop:    true,
+	events.ActionDie:     true,
+	events.ActionOOM:     true,
+	events.ActionPause:   true,
+	events.ActionUnPause: true,
+	events.ActionRename:  true,
+	events.ActionUpdate:  true,
+}
+
+// watch watches the events until closed, when the connection is lost,
+// the last known list is served as stale until reconnected
+func (d *DockerCli) watch() {
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := d.watchEvents() // will block here
+		if d.ctx.Err() != nil {
+			return // closed
+		}
+		d.setDisconnected(err)
+
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+		logrus.Errorf("lost connection to docker daemon %s: %s, reconnect in %s",
+			d.host, err, backoff)
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// watchEvents subscribes the events and rebuilds the cache, then keeps
+// the cache updated by the events, it returns when the event stream breaks
+func (d *DockerCli) watchEvents() error {
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+	eventChan, errChan := d.cli.Events(ctx, events.ListOptions{})
+
+	// the containers changed before subscribed are listed here, the events
+	// wait in the stream until the list is set, or they would be overwritten
+	// by the list taken before them
+	refreshCtx, refreshCancel := context.WithTimeout(ctx, time.Second*30)
+	err := d.refresh(refreshCtx)
+	refreshCancel()
+	if err != nil {
+		return fmt.Errorf("list containers error: %s", err)
+	}
+	if d.setDisconnected(nil) != nil {
+		logrus.Infof("reconnected to docker daemon %s", d.host)
+	}
+
+	for {
+		select {
+		case event := <-eventChan:
+			if event.Type != events.ContainerEventType {
+				continue
+			}
+			d.handleEvent(ctx, event)
+		case err := <-errChan:
+			return err
+		}
+	}
+}
+
+func (d *DockerCli) handleEvent(ctx context.Context, event events.Message) {
+	id := event.Actor.ID
+	if event.Action == events.ActionDestroy {
+		logrus.Debugf("container event: %s %s", event.Action, id)
+		d.remove(id)
+		return
+	}
+	if !cacheEvents[event.Action] &&
+		!strings.HasPrefix(string(event.Action), string(events.ActionHealthStatus)) {
+		return
+	}
+	logrus.Debugf("container event: %s %s", event.Action, id)
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*3)
+	defer cancel()
+	inspect, err := d.cli.ContainerInspect(ctx, id)
+	if client.IsErrNotFound(err) {
+		d.remove(id)
+		return
+	} else if err != nil {
+		logrus.Errorf("inspect container %s error: %s", id, err)
+		return
+	}
+
+	listed, err := d.listed(ctx, inspect)
+	if err != nil {
+		logrus.Errorf("filter container %s error: %s", id, err)
+		return
+	}
+	if !listed {
+		d.remove(id)
+		return
+	}
+	d.put(d.fromInspect(inspect), inspect.State)
+}
+
+// listed tells whether the container is listed with the ps options,
+// the -n and -l options are only applied to the whole list
+func (d *DockerCli) listed(ctx context.Context, inspect container.InspectResponse) (bool, error) {
+	if _, ok := inspect.Config.Labels[debugLabel]; ok {
+		// hide the debug helpers
+		return false, nil
+	}
+	if d.listOptions.Filters.Len() == 0 {
+		return d.listOptions.All || inspect.State.Running, nil
+	}
+
+	// let the daemon apply the filters
+	opts := d.listOptions
+	opts.Filters = opts.Filters.Clone()
+	opts.Filters.Add("id", inspect.ID)
+	cs, err := d.cli.ContainerList(ctx, opts)
+	return len(cs) != 0, err
+}
+
+// put caches the container and its state, and publishes the change
+func (d *DockerCli) put(c types.Container, state *container.State) {
+	if old := d.containers.Find(c.ID); old.ID == c.ID {
+		c.Shell = old.Shell
+	}
+	d.m.Lock()
+	d.states[c.ID] = state
+	d.m.Unlock()
+
+	action := types.ContainerCreated
+	if d.containers.Put(c) {
+		action = types.ContainerUpdated
+	}
+	d.hub.Publish(types.ContainerEvent{Action: action, Container: d.render(c)})
+}
+
+// remove removes the container from the cache, and publishes the change
+func (d *DockerCli) remove(id string) {
+	d.m.Lock()
+	delete(d.states, id)
+	d.m.Unlock()
+
+	if d.containers.Remove(id) {
+		d.hub.Publish(types.ContainerEvent{
+			Action:    types.ContainerRemoved,
+			Container: types.Container{ID: id, LocServer: d.loc},
+		})
+	}
+}
+
+// refresh lists and inspects the containers from the daemon, rebuilds the
+// cache and publishes the changes
+func (d *DockerCli) refresh(ctx context.Context) error {
+	start := time.Now()
+	logrus.Debug("list conatiners")
+	cs, err := d.cli.ContainerList(ctx, d.listOptions)
+	if err != nil {
+		return err
+	}
+
+	inspects := make([]*container.InspectResponse, len(cs))
+	wg := sync.WaitGroup{}
+	limit := make(chan struct{}, 8)
+	for i, c := range cs {
+		// hide the debug helpers
+		if _, ok := c.Labels[debugLabel]; ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+			inspect, err := d.cli.ContainerInspect(ctx, id)
+			if err != nil {
+				// removed just now
+				logrus.Debugf("inspect container %s error: %s", id, err)
+				return
+			}
+			inspects[i] = &inspect
+		}(i, c.ID)
+	}
+	wg.Wait()
+
+	containers := make([]types.Container, 0, len(cs))
+	states := make(map[string]*container.State, len(cs))
+	for _, inspect := range inspects {
+		if inspect == nil {
+			continue
+		}
+		c := d.fromInspect(*inspect)
+		if c.ID == "" {
+			continue
+		}
+		if old := d.containers.Find(c.ID); old.ID == c.ID {
+			c.Shell = old.Shell
+		}
+		containers = append(containers, c)
+		states[c.ID] = inspect.State
+	}
+
+	olds := d.containers.List()
+	d.m.Lock()
+	d.states = states
+	d.m.Unlock()
+	d.containers.Set(containers)
+	d.publishChanges(olds, containers)
+
+	logrus.Debugf("list %d containers, use %s", len(containers), time.Now().Sub(start))
+	return nil
+}
+
+// publishChanges publishes the differences of the lists
+func (d *DockerCli) publishChanges(olds, news []types.Container) {
+	oldByID := make(map[string]types.Container, len(olds))
+	for _, c := range olds {
+		oldByID[c.ID] = c
+	}
+	for _, c := range news {
+		old, exist := oldByID[c.ID]
+		delete(oldByID, c.ID)
+		c = d.render(c)
+		switch {
+		case !exist:
+			d.hub.Publish(types.ContainerEvent{Action: types.ContainerCreated, Container: c})
+		case old.Name != c.Name || old.State != c.State || old.Status != c.Status:
+			d.hub.Publish(types.ContainerEvent{Action: types.ContainerUpdated, Container: c})
+		}
+	}
+	for id := range oldByID {
+		d.hub.Publish(types.ContainerEvent{
+			Action:    types.ContainerRemoved,
+			Container: types.Container{ID: id, LocServer: d.loc},
+		})
+	}
+}
+
+// fromInspect converts the inspected container, without the shell
+func (d *DockerCli) fromInspect(inspect container.InspectResponse) types.Container {
+	if inspect.ContainerJSONBase == nil || inspect.State == nil || inspect.Config == nil {
+		// WTF?
+		return types.Container{}
+	}
+	return types.Container{
+		ID:        inspect.ID,
+		Name:      strings.TrimPrefix(inspect.Name, "/"),
+		Image:     inspect.Config.Image,
+		Command:   strings.Join(append([]string{inspect.Path}, inspect.Args...), " "),
+		IPs:       getContainerIP(inspect.NetworkSettings),
+		Status:    string(inspect.State.Status),
+		State:     string(inspect.State.Status),
+		LocServer: d.loc,
+	}
+}
+
+// render describes the status by the state at now, and marks the
+// container as stale if the connection is lost
+func (d *DockerCli) render(c types.Container) types.Container {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if state, ok := d.states[c.ID]; ok {
+		c.Status = statusOf(state, time.Now())
+	}
+	c.Stale = d.disconnected != nil
+	return c
+}
+
+// statusOf describes the state the same way as `docker ps`
+func statusOf(s *container.State, now time.Time) string {
+	since := func(t string) string {
+		at, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return ""
+		}
+		return units.HumanDuration(now.Sub(at))
+	}
+
+	switch {
+	case s.Paused:
+		return fmt.Sprintf("Up %s (Paused)", since(s.StartedAt))
+	case s.Restarting:
+		return fmt.Sprintf("Restarting (%d) %s ago", s.ExitCode, since(s.FinishedAt))
+	case s.Running:
+		status := "Up " + since(s.StartedAt)
+		if s.Health != nil {
+			switch s.Health.Status {
+			case container.Starting:
+				status += " (health: starting)"
+			case container.Healthy, container.Unhealthy:
+				status += " (" + string(s.Health.Status) + ")"
+			}
+		}
+		return status
+	case s.Status == container.StateRemoving:
+		return "Removal In Progress"
+	case s.Dead:
+		return "Dead"
+	case s.Status == container.StateCreated:
+		return "Created"
+	default:
+		return fmt.Sprintf("Exited (%d) %s ago", s.ExitCode, since(s.FinishedAt))
+	}
+}
+
+// Watch returns the changes of the listed containers until the context is done
+func (d *DockerCli) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	return d.hub.Subscribe(ctx)
+}
@@ -0,0 +1,215 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/moby/moby/client"
+	"github.com/sirupsen/logrus"
+)
+
+// endpoint is a docker daemon, the host looks like
+//
+//	/var/run/docker.sock
+//	unix:///var/run/docker.sock
+//	tcp://10.0.0.2:2376?certs=/etc/docker/certs/10.0.0.2
+//	ssh://user@10.0.0.3:22
+type endpoint struct {
+	name       string   // the host as configured or the context, it's the location of the containers
+	host       string   // the docker host with the protocol
+	certs      string   // the directory of ca.pem, cert.pem and key.pem
+	skipVerify bool     // skip verifying the server certs
+	ssh        *url.URL // the ssh transport
+
+	// the endpoint cannot be used, e.g. the context is removed
+	err error
+}
+
+func parseEndpoint(host, certPath string) (endpoint, error) {
+	host = strings.TrimSpace(host)
+	host, query, _ := strings.Cut(host, "?")
+	e := endpoint{name: host, host: host}
+	if host == "" {
+		return e, fmt.Errorf("empty docker host")
+	}
+
+	if query != "" {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return e, fmt.Errorf("bad docker host %s: %s", host, err)
+		}
+		certPath = values.Get("certs")
+	}
+
+	switch {
+	case strings.HasPrefix(host, "/"):
+		e.host = "unix://" + host
+	case strings.HasPrefix(host, "ssh://"):
+		u, err := url.Parse(host)
+		if err != nil {
+			return e, fmt.Errorf("bad docker host %s: %s", host, err)
+		}
+		if u.Hostname() == "" {
+			return e, fmt.Errorf("bad docker host %s: no ssh host", host)
+		}
+		e.ssh = u
+		// the host is not used, the connections are dialed by ssh
+		e.host = "http://docker.example.com"
+	case strings.HasPrefix(host, "unix://"), strings.HasPrefix(host, "npipe://"):
+	case strings.HasPrefix(host, "tcp://"):
+		e.certs = certPath
+	case strings.Contains(host, "://"):
+		return e, fmt.Errorf("bad docker host %s: unknown protocol", host)
+	default:
+		e.host = "tcp://" + host
+		e.certs = certPath
+	}
+
+	return e, nil
+}
+
+func (e endpoint) clientOpts() ([]client.Opt, error) {
+	opts := []client.Opt{}
+	if e.certs != "" || e.skipVerify {
+		tlsConfig, err := tlsconfig.Client(tlsconfig.Options{
+			CAFile:             certFile(e.certs, "ca.pem"),
+			CertFile:           certFile(e.certs, "cert.pem"),
+			KeyFile:            certFile(e.certs, "key.pem"),
+			InsecureSkipVerify: e.skipVerify,
+			ExclusiveRootPools: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("load TLS certs of %s error: %s", e.name, err)
+		}
+		// the same as the default one of the docker client
+		opts = append(opts, client.WithHTTPClient(&http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+				MaxIdleConns:    6,
+				IdleConnTimeout: 30 * time.Second,
+			},
+			CheckRedirect: client.CheckRedirect,
+		}))
+	}
+	opts = append(opts,
+		client.WithHost(e.host),
+		client.WithVersionFromEnv(),
+	)
+	if e.ssh != nil {
+		opts = append(opts, client.WithDialContext(sshDialer(sshArgs(e.ssh))))
+	}
+	return opts, nil
+}
+
+// certFile returns the path of the cert file if it exists
+func certFile(dir, name string) string {
+	if dir == "" {
+		return ""
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+func (e endpoint) equal(o endpoint) bool {
+	sshURL := func(u *url.URL) string {
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+	return e.name == o.name && e.host == o.host && e.certs == o.certs &&
+		e.skipVerify == o.skipVerify && sshURL(e.ssh) == sshURL(o.ssh)
+}
+
+// sshArgs returns the arguments of ssh to reach the remote docker daemon,
+// the authentication is done by the ssh client (keys, agent, ~/.ssh/config)
+func sshArgs(u *url.URL) []string {
+	args := []string{"-o", "BatchMode=yes"}
+	if u.User != nil {
+		args = append(args, "-l", u.User.Username())
+	}
+	if port := u.Port(); port != "" {
+		args = append(args, "-p", port)
+	}
+	return append(args, "--", u.Hostname(), "docker", "system", "dial-stdio")
+}
+
+func sshDialer(args []string) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		// the connection outlives the context of the request
+		cmd := exec.Command("ssh", args...)
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		stderr := logrus.StandardLogger().WriterLevel(logrus.WarnLevel)
+		cmd.Stderr = stderr
+		if err := cmd.Start(); err != nil {
+			stderr.Close()
+			return nil, fmt.Errorf("run ssh error: %s", err)
+		}
+		return &cmdConn{
+			cmd:    cmd,
+			stdin:  stdin,
+			stdout: stdout,
+			stderr: stderr,
+		}, nil
+	}
+}
+
+// cmdConn is a connection over the stdin and stdout of a command
+type cmdConn struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+	stderr *io.PipeWriter
+}
+
+func (c *cmdConn) Read(p []byte) (int, error) {
+	return c.stdout.Read(p)
+}
+
+func (c *cmdConn) Write(p []byte) (int, error) {
+	return c.stdin.Write(p)
+}
+
+func (c *cmdConn) Close() error {
+	c.stdin.Close()
+	c.cmd.Process.Kill()
+	c.cmd.Wait()
+	return c.stderr.Close()
+}
+
+func (c *cmdConn) LocalAddr() net.Addr {
+	return cmdAddr{}
+}
+
+func (c *cmdConn) RemoteAddr() net.Addr {
+	return cmdAddr{}
+}
+
+func (c *cmdConn) SetDeadline(t time.Time) error      { return nil }
+func (c *cmdConn) SetReadDeadline(t time.Time) error  { return nil }
+func (c *cmdConn) SetWriteDeadline(t time.Time) error { return nil }
+
+type cmdAddr struct{}
+
+func (cmdAddr) Network() string { return "cmd" }
+func (cmdAddr) String() string  { return "cmd" }
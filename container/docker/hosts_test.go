@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		host, certPath string
+		name, want     string
+		certs          string
+		ssh            string
+	}{
+		{host: "/var/run/docker.sock", name: "/var/run/docker.sock", want: "unix:///var/run/docker.sock"},
+		{host: "unix:///run/docker.sock", name: "unix:///run/docker.sock", want: "unix:///run/docker.sock"},
+		{host: "10.0.0.2:2375", name: "10.0.0.2:2375", want: "tcp://10.0.0.2:2375"},
+		{host: "tcp://10.0.0.2:2376", certPath: "/certs", name: "tcp://10.0.0.2:2376",
+			want: "tcp://10.0.0.2:2376", certs: "/certs"},
+		{host: " tcp://10.0.0.2:2376?certs=/certs/b", certPath: "/certs", name: "tcp://10.0.0.2:2376",
+			want: "tcp://10.0.0.2:2376", certs: "/certs/b"},
+		{host: "/var/run/docker.sock", certPath: "/certs", name: "/var/run/docker.sock",
+			want: "unix:///var/run/docker.sock"},
+		{host: "ssh://root@10.0.0.3:2222", certPath: "/certs", name: "ssh://root@10.0.0.3:2222",
+			want: "http://docker.example.com",
+			ssh:  "-o BatchMode=yes -l root -p 2222 -- 10.0.0.3 docker system dial-stdio"},
+	} {
+		e, err := parseEndpoint(tc.host, tc.certPath)
+		if err != nil {
+			t.Errorf("parse %s error: %s", tc.host, err)
+			continue
+		}
+		if e.name != tc.name || e.host != tc.want || e.certs != tc.certs {
+			t.Errorf("parse %s, unexpected endpoint %+v", tc.host, e)
+		}
+		if tc.ssh == "" {
+			if e.ssh != nil {
+				t.Errorf("parse %s, unexpected ssh %s", tc.host, e.ssh)
+			}
+			continue
+		}
+		if e.ssh == nil {
+			t.Errorf("parse %s, expect ssh", tc.host)
+		} else if args := strings.Join(sshArgs(e.ssh), " "); args != tc.ssh {
+			t.Errorf("parse %s, unexpected ssh args %q", tc.host, args)
+		}
+	}
+
+	for _, host := range []string{"", "http://10.0.0.2", "ssh://", "tcp://a?%"} {
+		if _, err := parseEndpoint(host, ""); err == nil {
+			t.Errorf("expect error of %q", host)
+		}
+	}
+}
+
+func TestMultiCli(t *testing.T) {
+	newDaemon := func(loc string, ids ...string) *DockerCli {
+		cs := []types.Container{}
+		for _, id := range ids {
+			cs = append(cs, types.Container{ID: id, LocServer: loc})
+		}
+		containers := &types.Containers{}
+		containers.Set(cs)
+		return &DockerCli{containers: containers, loc: loc}
+	}
+	m := &MultiCli{
+		names: []string{"a", "b", "c"},
+		daemons: map[string]*daemon{
+			"a": {cli: newDaemon("a", "aaaaaaaaaaaaaaaa")},
+			"b": {cli: newDaemon("b", "bbbbbbbbbbbbbbbb")},
+			"c": {err: errors.New("no such context")},
+		},
+	}
+
+	ctx := context.Background()
+	for id, host := range map[string]string{
+		"aaaaaaaaaaaa":     "a",
+		"bbbbbbbbbbbbbbbb": "b",
+	} {
+		cli, err := m.find(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cli.loc != host {
+			t.Errorf("container %s is found in %s, expect %s", id, cli.loc, host)
+		}
+	}
+
+	locations := m.Locations()
+	if len(locations) != 3 || !locations[0].Connected || locations[2].Connected ||
+		locations[2].Error != "no such context" {
+		t.Errorf("unexpected locations %+v", locations)
+	}
+}
@@ -0,0 +1,125 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// the streams of the multiplexed logs
+const (
+	stdinStream byte = iota
+	stdoutStream
+	stderrStream
+)
+
+const (
+	// the header is [stream, 0, 0, 0, size of the payload in big endian]
+	frameHeaderLen = 8
+	// the payloads larger than it are returned in several parts
+	maxFrameChunk = 32 << 10
+)
+
+// logDemuxer splits the logs into the outputs of stdout and stderr, the
+// logs of the containers without a TTY are multiplexed with the frame
+// headers, otherwise they are the raw outputs of the TTY
+// https://docs.docker.com/reference/api/engine/version/v1.51/#tag/Container/operation/ContainerAttach
+type logDemuxer struct {
+	r   io.Reader
+	tty bool
+
+	header [frameHeaderLen]byte
+	stream byte
+	left   uint32 // the payload bytes of the frame not read yet
+	buff   []byte
+}
+
+func newLogDemuxer(r io.Reader, tty bool) *logDemuxer {
+	return &logDemuxer{
+		r:    r,
+		tty:  tty,
+		buff: make([]byte, maxFrameChunk),
+	}
+}
+
+// Next returns the next output and its stream, the output is valid until
+// the next call, io.EOF is returned at the end of the logs, the outputs
+// of a TTY are all of stdout
+func (d *logDemuxer) Next() (byte, []byte, error) {
+	if d.tty {
+		n, err := d.r.Read(d.buff)
+		return stdoutStream, d.buff[:n], err
+	}
+
+	for d.left == 0 {
+		// the header may be split across the reads
+		if _, err := io.ReadFull(d.r, d.header[:]); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				err = fmt.Errorf("truncated log frame header")
+			}
+			return 0, nil, err
+		}
+		switch d.header[0] {
+		case stdinStream, stdoutStream, stderrStream:
+		default:
+			return 0, nil, fmt.Errorf("bad log frame header %x", d.header)
+		}
+		d.stream = d.header[0]
+		d.left = binary.BigEndian.Uint32(d.header[4:])
+	}
+
+	size := min(d.left, maxFrameChunk)
+	n, err := io.ReadFull(d.r, d.buff[:size])
+	d.left -= uint32(n)
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		err = fmt.Errorf("truncated log frame, %d bytes left", d.left)
+	}
+	return d.stream, d.buff[:n], err
+}
+
+// logReader converts the logs to terminal outputs, the stderr is colored
+type logReader struct {
+	rc    io.ReadCloser
+	demux *logDemuxer
+	buff  bytes.Buffer
+	err   error
+}
+
+func newLogReader(rc io.ReadCloser, tty bool) io.ReadCloser {
+	return &logReader{
+		rc:    rc,
+		demux: newLogDemuxer(rc, tty),
+	}
+}
+
+func (r *logReader) Read(p []byte) (int, error) {
+	for r.buff.Len() == 0 && r.err == nil {
+		var (
+			stream byte
+			output []byte
+		)
+		stream, output, r.err = r.demux.Next()
+		if len(output) == 0 {
+			continue
+		}
+		// the outputs of a TTY are terminal outputs already
+		if !r.demux.tty {
+			output = bytes.ReplaceAll(output, []byte("\n"), []byte("\r\n"))
+		}
+		if stream == stderrStream {
+			output = util.ColorStderr(output)
+		}
+		r.buff.Write(output)
+	}
+	if r.buff.Len() != 0 {
+		return r.buff.Read(p)
+	}
+	return 0, r.err
+}
+
+func (r *logReader) Close() error {
+	return r.rc.Close()
+}
@@ -0,0 +1,174 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// the logs of `sh -c 'echo hello; echo oops >&2; printf partial'`
+// without a TTY, with an empty frame in between
+const recordedFrames = "\x01\x00\x00\x00\x00\x00\x00\x06hello\n" +
+	"\x02\x00\x00\x00\x00\x00\x00\x05oops\n" +
+	"\x01\x00\x00\x00\x00\x00\x00\x00" +
+	"\x01\x00\x00\x00\x00\x00\x00\x07partial"
+
+// and with a TTY, the outputs are not multiplexed
+const recordedTTY = "hello\r\n\x1b[1moops\x1b[0m\r\npartial"
+
+// chunkReader reads n bytes at most each time, so that the
+// headers are split across the reads
+type chunkReader struct {
+	r io.Reader
+	n int
+}
+
+func (c chunkReader) Read(p []byte) (int, error) {
+	if len(p) > c.n {
+		p = p[:c.n]
+	}
+	return c.r.Read(p)
+}
+
+type frame struct {
+	stream byte
+	output string
+}
+
+// readFrames reads all the outputs, the continuous ones of the
+// same stream are merged
+func readFrames(d *logDemuxer) ([]frame, error) {
+	frames := []frame{}
+	for {
+		stream, output, err := d.Next()
+		if len(output) != 0 {
+			last := len(frames) - 1
+			if last >= 0 && frames[last].stream == stream {
+				frames[last].output += string(output)
+			} else {
+				frames = append(frames, frame{stream, string(output)})
+			}
+		}
+		if err == io.EOF {
+			return frames, nil
+		}
+		if err != nil {
+			return frames, err
+		}
+	}
+}
+
+func readers(s string) map[string]io.Reader {
+	return map[string]io.Reader{
+		"whole":    strings.NewReader(s),
+		"one byte": iotest.OneByteReader(strings.NewReader(s)),
+		"half":     iotest.HalfReader(strings.NewReader(s)),
+		"3 bytes":  chunkReader{strings.NewReader(s), 3},
+		"11 bytes": chunkReader{strings.NewReader(s), 11},
+	}
+}
+
+func TestLogDemuxer(t *testing.T) {
+	expect := []frame{
+		{stdoutStream, "hello\n"},
+		{stderrStream, "oops\n"},
+		{stdoutStream, "partial"},
+	}
+	for name, r := range readers(recordedFrames) {
+		frames, err := readFrames(newLogDemuxer(r, false))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		if !reflect.DeepEqual(frames, expect) {
+			t.Errorf("%s: expect %q, got %q", name, expect, frames)
+		}
+	}
+
+	expect = []frame{{stdoutStream, recordedTTY}}
+	for name, r := range readers(recordedTTY) {
+		frames, err := readFrames(newLogDemuxer(r, true))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		if !reflect.DeepEqual(frames, expect) {
+			t.Errorf("%s: expect %q, got %q", name, expect, frames)
+		}
+	}
+}
+
+func TestLogDemuxerLargeFrame(t *testing.T) {
+	payload := strings.Repeat("x", maxFrameChunk*2+1)
+	header := []byte{stderrStream, 0, 0, 0, 0, 0x01, 0x00, 0x01}
+	frames, err := readFrames(newLogDemuxer(
+		io.MultiReader(bytes.NewReader(header), strings.NewReader(payload)), false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 1 || frames[0].stream != stderrStream || frames[0].output != payload {
+		t.Errorf("unexpected frames of %d bytes", len(frames[0].output))
+	}
+}
+
+func TestLogDemuxerBroken(t *testing.T) {
+	for name, logs := range map[string]string{
+		"truncated header":  recordedFrames[:len("\x01\x00\x00\x00\x00\x00\x00\x06hello\n")+3],
+		"truncated payload": recordedFrames[:len(recordedFrames)-2],
+		"bad header":        "hello world\n",
+	} {
+		if _, err := readFrames(newLogDemuxer(strings.NewReader(logs), false)); err == nil {
+			t.Errorf("%s: expect error", name)
+		}
+	}
+}
+
+func TestLogReader(t *testing.T) {
+	for name, r := range readers(recordedFrames) {
+		bs, err := io.ReadAll(iotest.OneByteReader(newLogReader(io.NopCloser(r), false)))
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+		expect := "hello\r\n\x1b[31moops\x1b[0m\r\npartial"
+		if string(bs) != expect {
+			t.Errorf("%s: expect %q, got %q", name, expect, bs)
+		}
+	}
+
+	// the outputs of a TTY are kept
+	bs, err := io.ReadAll(newLogReader(io.NopCloser(strings.NewReader(recordedTTY)), true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(bs) != recordedTTY {
+		t.Errorf("expect %q, got %q", recordedTTY, bs)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	for _, tty := range []bool{false, true} {
+		daemon := newFakeDaemon()
+		daemon.tty = tty
+		cli := newFakeCli(t, daemon)
+		rc, err := cli.Logs(context.Background(), types.LogOptions{ID: fakeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		bs, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := "hello\r\n\x1b[31moops\x1b[0m\r\npartial"
+		if tty {
+			expect = recordedTTY
+		}
+		if string(bs) != expect {
+			t.Errorf("tty %v: expect %q, got %q", tty, expect, bs)
+		}
+	}
+}
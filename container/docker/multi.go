@@ -0,0 +1,326 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// syncInterval is how often the contexts are reloaded and the
+// disconnected daemons are reconnected
+const syncInterval = time.Second * 30
+
+// MultiCli serves the containers of several docker daemons, the containers
+// are located by the hosts or the contexts of their daemons (LocServer)
+type MultiCli struct {
+	conf config.DockerConfig
+	load func() ([]endpoint, error)
+
+	m       sync.RWMutex
+	names   []string
+	daemons map[string]*daemon
+
+	// the changes of the containers of all the daemons
+	hub *types.EventHub
+
+	done chan struct{}
+}
+
+// daemon is a docker daemon of a location
+type daemon struct {
+	endpoint endpoint
+	cli      *DockerCli // nil if it's disconnected
+	err      error      // why it's disconnected
+}
+
+// NewMultiCli connects to the docker daemons of conf.Hosts, or of the
+// docker contexts if conf.Contexts is set, the disconnected ones and the
+// new contexts are connected in the background
+func NewMultiCli(conf config.DockerConfig) (*MultiCli, error) {
+	m := &MultiCli{
+		conf:    conf,
+		daemons: map[string]*daemon{},
+		hub:     new(types.EventHub),
+		done:    make(chan struct{}),
+	}
+
+	if len(conf.Contexts) != 0 {
+		if len(conf.Hosts) > 1 {
+			return nil, fmt.Errorf("the docker contexts cannot be used with several docker hosts")
+		}
+		logrus.Infof("Docker contexts %v of %s", conf.Contexts, conf.ConfigDir)
+		m.load = func() ([]endpoint, error) {
+			return loadContexts(conf.ConfigDir, conf.DockerHost, conf.Contexts)
+		}
+	} else {
+		endpoints := make([]endpoint, 0, len(conf.Hosts))
+		seen := map[string]bool{}
+		for _, host := range conf.Hosts {
+			e, err := parseEndpoint(host, conf.CertPath)
+			if err != nil {
+				return nil, err
+			}
+			if seen[e.name] {
+				return nil, fmt.Errorf("duplicated docker host %s", e.name)
+			}
+			seen[e.name] = true
+			endpoints = append(endpoints, e)
+		}
+		m.load = func() ([]endpoint, error) {
+			return endpoints, nil
+		}
+	}
+
+	if err := m.sync(); err != nil {
+		return nil, err
+	}
+	if len(m.clis()) == 0 && len(conf.Contexts) == 0 {
+		return nil, fmt.Errorf("none of the docker hosts %v is reachable", conf.Hosts)
+	}
+
+	go m.run()
+	return m, nil
+}
+
+func (m *MultiCli) run() {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.done:
+			return
+		case <-ticker.C:
+			if err := m.sync(); err != nil {
+				logrus.Errorf("load docker contexts error: %s", err)
+			}
+		}
+	}
+}
+
+// sync connects to the new and the disconnected daemons, and closes
+// the removed ones
+func (m *MultiCli) sync() error {
+	endpoints, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	m.m.RLock()
+	olds := m.daemons
+	m.m.RUnlock()
+
+	names := make([]string, 0, len(endpoints))
+	daemons := make(map[string]*daemon, len(endpoints))
+	for _, e := range endpoints {
+		if _, exist := daemons[e.name]; exist {
+			continue
+		}
+		names = append(names, e.name)
+		if old, exist := olds[e.name]; exist && old.cli != nil && old.endpoint.equal(e) {
+			daemons[e.name] = old
+			continue
+		}
+
+		d := &daemon{endpoint: e, err: e.err}
+		if d.err == nil {
+			d.cli, d.err = newCli(m.conf, e, e.name, m.hub)
+		}
+		if d.err != nil {
+			logrus.Warnf("connect to docker %s error: %s", e.name, d.err)
+		}
+		daemons[e.name] = d
+	}
+
+	m.m.Lock()
+	m.names, m.daemons = names, daemons
+	m.m.Unlock()
+
+	for name, old := range olds {
+		if daemons[name] != old && old.cli != nil {
+			logrus.Infof("docker %s is removed or changed, close it", name)
+			old.cli.Close()
+			for _, c := range old.cli.containers.List() {
+				m.hub.Publish(types.ContainerEvent{
+					Action:    types.ContainerRemoved,
+					Container: types.Container{ID: c.ID, LocServer: c.LocServer},
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// clis returns the clients of the connected daemons
+func (m *MultiCli) clis() []*DockerCli {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	clis := make([]*DockerCli, 0, len(m.names))
+	for _, name := range m.names {
+		if cli := m.daemons[name].cli; cli != nil {
+			clis = append(clis, cli)
+		}
+	}
+	return clis
+}
+
+// find returns the client of the daemon that runs the container
+func (m *MultiCli) find(ctx context.Context, cid string) (*DockerCli, error) {
+	clis := m.clis()
+	for _, cli := range clis {
+		if c := cli.containers.Find(cid); c.ID != "" {
+			return cli, nil
+		}
+	}
+	// not listed, e.g. it's stopped
+	for _, cli := range clis {
+		if _, err := cli.cli.ContainerInspect(ctx, cid); err == nil {
+			return cli, nil
+		}
+	}
+	return nil, fmt.Errorf("no such container: %s", cid)
+}
+
+// Locations reports the connection states of the daemons
+func (m *MultiCli) Locations() []types.Location {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	locations := make([]types.Location, 0, len(m.names))
+	for _, name := range m.names {
+		d := m.daemons[name]
+		loc := types.Location{Name: name, Connected: d.cli != nil}
+		if d.err != nil {
+			loc.Error = d.err.Error()
+		} else if err := d.cli.Health(); err != nil {
+			loc.Connected = false
+			loc.Error = err.Error()
+		}
+		locations = append(locations, loc)
+	}
+	return locations
+}
+
+func (m *MultiCli) GetInfo(ctx context.Context, cid string) types.Container {
+	cli, err := m.find(ctx, cid)
+	if err != nil {
+		logrus.Error(err)
+		return types.Container{}
+	}
+	return cli.GetInfo(ctx, cid)
+}
+
+func (m *MultiCli) List(ctx context.Context) []types.Container {
+	clis := m.clis()
+	lists := make([][]types.Container, len(clis))
+
+	wg := sync.WaitGroup{}
+	for i, cli := range clis {
+		wg.Add(1)
+		go func(i int, cli *DockerCli) {
+			defer wg.Done()
+			lists[i] = cli.List(ctx)
+		}(i, cli)
+	}
+	wg.Wait()
+
+	containers := []types.Container{}
+	for _, list := range lists {
+		containers = append(containers, list...)
+	}
+	return containers
+}
+
+func (m *MultiCli) Start(ctx context.Context, cid string) error {
+	cli, err := m.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Start(ctx, cid)
+}
+
+func (m *MultiCli) Stop(ctx context.Context, cid string) error {
+	cli, err := m.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Stop(ctx, cid)
+}
+
+func (m *MultiCli) Restart(ctx context.Context, cid string) error {
+	cli, err := m.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	return cli.Restart(ctx, cid)
+}
+
+func (m *MultiCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	cli, err := m.find(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Exec(ctx, c)
+}
+
+func (m *MultiCli) Attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	cli, err := m.find(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Attach(ctx, c)
+}
+
+func (m *MultiCli) Stats(ctx context.Context, c types.Container) (<-chan types.Stats, error) {
+	cli, err := m.find(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Stats(ctx, c)
+}
+
+func (m *MultiCli) Top(ctx context.Context, c types.Container) ([]types.Process, error) {
+	cli, err := m.find(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Top(ctx, c)
+}
+
+func (m *MultiCli) Signal(ctx context.Context, c types.Container, pid int, signal string) error {
+	cli, err := m.find(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	return cli.Signal(ctx, c, pid, signal)
+}
+
+func (m *MultiCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	cli, err := m.find(ctx, opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	return cli.Logs(ctx, opts)
+}
+
+// Watch returns the changes of the containers of all the daemons
+// until the context is done
+func (m *MultiCli) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	return m.hub.Subscribe(ctx)
+}
+
+func (m *MultiCli) Close() error {
+	close(m.done)
+	var lastErr error
+	for _, cli := range m.clis() {
+		if err := cli.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
@@ -12,6 +12,10 @@ type execInjector struct {
 	hResp      types.HijackedResponse
 	resize     resizeFunction
 	activeChan chan struct{}
+
+	execOptions string // the options took effect
+	cleanup     func() // called after exit
+	detachKeys  []byte // sent on exit instead of ^C^D when attached
 }
 
 type resizeFunction func(width int, height int) error
@@ -41,9 +45,18 @@ func (enj *execInjector) Write(p []byte) (n int, err error) {
 }
 
 func (enj *execInjector) Exit() error {
-	enj.Write([]byte{3, 13, 4, 13}) // ^C, ^D, enter
+	if enj.detachKeys != nil {
+		// detach from the main process, don't kill it
+		enj.Write(enj.detachKeys)
+	} else {
+		enj.Write([]byte{3, 13, 4, 13}) // ^C, ^D, enter
+	}
 	close(enj.activeChan)
-	return enj.hResp.Conn.Close()
+	err := enj.hResp.Conn.Close()
+	if enj.cleanup != nil {
+		enj.cleanup()
+	}
+	return err
 }
 
 func (enj *execInjector) ActiveChan() <-chan struct{} {
@@ -51,7 +64,9 @@ func (enj *execInjector) ActiveChan() <-chan struct{} {
 }
 
 func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
-	return map[string]interface{}{}
+	return map[string]interface{}{
+		"execOptions": enj.execOptions,
+	}
 }
 
 func (enj *execInjector) ResizeTerminal(width int, height int) (err error) {
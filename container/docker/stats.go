@@ -0,0 +1,94 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// Stats streams the resource usage of the container every second,
+// the same as `docker stats`
+func (d *DockerCli) Stats(ctx context.Context, c types.Container) (<-chan types.Stats, error) {
+	resp, err := d.cli.ContainerStats(ctx, c.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(chan types.Stats)
+	go func() {
+		defer close(stats)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var s container.StatsResponse
+			if err := decoder.Decode(&s); err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					logrus.Errorf("decode stats of container %s error: %s", c.ID, err)
+				}
+				return
+			}
+			// the first one of a running container has no
+			// previous CPU usage to compare with
+			if s.PreCPUStats.SystemUsage == 0 && s.CPUStats.SystemUsage != 0 {
+				continue
+			}
+			select {
+			case stats <- convertStats(c.ID, s):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stats, nil
+}
+
+// convertStats calculates the usage the same way as the docker CLI
+func convertStats(id string, s container.StatsResponse) types.Stats {
+	stats := types.Stats{
+		ID:          id,
+		Time:        s.Read,
+		MemoryUsage: s.MemoryStats.Usage,
+		MemoryLimit: s.MemoryStats.Limit,
+	}
+
+	cpuDelta := float64(s.CPUStats.CPUUsage.TotalUsage) - float64(s.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(s.CPUStats.SystemUsage) - float64(s.PreCPUStats.SystemUsage)
+	cpus := float64(s.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(s.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		stats.CPUPercent = cpuDelta / systemDelta * cpus * 100
+	}
+
+	// the page cache is not counted, of cgroup v1 or v2
+	for _, key := range []string{"total_inactive_file", "inactive_file"} {
+		if v, ok := s.MemoryStats.Stats[key]; ok {
+			if v < stats.MemoryUsage {
+				stats.MemoryUsage -= v
+			}
+			break
+		}
+	}
+
+	for _, network := range s.Networks {
+		stats.NetworkRx += network.RxBytes
+		stats.NetworkTx += network.TxBytes
+	}
+	for _, entry := range s.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			stats.BlockRead += entry.Value
+		case "write":
+			stats.BlockWrite += entry.Value
+		}
+	}
+	return stats
+}
@@ -0,0 +1,85 @@
+package docker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+var fakeStats = []container.StatsResponse{
+	{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 1000},
+			SystemUsage: 10000,
+			OnlineCPUs:  2,
+		},
+	},
+	{
+		CPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 1500},
+			SystemUsage: 12000,
+			OnlineCPUs:  2,
+		},
+		PreCPUStats: container.CPUStats{
+			CPUUsage:    container.CPUUsage{TotalUsage: 1000},
+			SystemUsage: 10000,
+		},
+		MemoryStats: container.MemoryStats{
+			Usage: 100 << 20,
+			Limit: 1 << 30,
+			Stats: map[string]uint64{"inactive_file": 20 << 20},
+		},
+		Networks: map[string]container.NetworkStats{
+			"eth0": {RxBytes: 100, TxBytes: 200},
+			"eth1": {RxBytes: 10, TxBytes: 20},
+		},
+		BlkioStats: container.BlkioStats{
+			IoServiceBytesRecursive: []container.BlkioStatEntry{
+				{Op: "Read", Value: 4096},
+				{Op: "write", Value: 1024},
+				{Op: "read", Value: 4096},
+			},
+		},
+	},
+}
+
+func TestConvertStats(t *testing.T) {
+	expect := types.Stats{
+		ID:          fakeID,
+		CPUPercent:  50,
+		MemoryUsage: 80 << 20,
+		MemoryLimit: 1 << 30,
+		NetworkRx:   110,
+		NetworkTx:   220,
+		BlockRead:   8192,
+		BlockWrite:  1024,
+	}
+	if s := convertStats(fakeID, fakeStats[1]); s != expect {
+		t.Errorf("expect %+v, got %+v", expect, s)
+	}
+
+	// stopped
+	if s := convertStats(fakeID, container.StatsResponse{}); s != (types.Stats{ID: fakeID}) {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestStats(t *testing.T) {
+	cli := newFakeCli(t, newFakeDaemon())
+	stats, err := cli.Stats(context.Background(), types.Container{ID: fakeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the first one is skipped
+	all := []types.Stats{}
+	for s := range stats {
+		all = append(all, s)
+	}
+	if len(all) != 1 || all[0].CPUPercent != 50 {
+		t.Errorf("unexpected stats %+v", all)
+	}
+}
@@ -0,0 +1,108 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// topArgs are the `ps` options of the columns we show
+var topArgs = []string{"-o", "pid,user,pcpu,rss,args"}
+
+// Top lists the processes of the container by `docker top`, the PIDs
+// are of the host, see signalable
+func (d *DockerCli) Top(ctx context.Context, c types.Container) ([]types.Process, error) {
+	inspection, err := d.cli.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	top, err := d.cli.ContainerTop(ctx, c.ID, topArgs)
+	if err != nil {
+		// the ps of the daemon host may not support the options
+		logrus.Debugf("top container %s with %v error: %s", c.ID, topArgs, err)
+		top, err = d.cli.ContainerTop(ctx, c.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	processes := util.ParseTop(top.Titles, top.Processes)
+	for i, p := range processes {
+		processes[i].NoSignal = !signalable(inspection, p.PID)
+	}
+	return processes, nil
+}
+
+// signalable tells whether the process can be signaled, the PIDs of
+// `docker top` are of the host, which are the same in the container only
+// if it shares the PID namespace of the host, otherwise only the main
+// process can be signaled, via the container
+func signalable(inspection container.InspectResponse, pid int) bool {
+	if inspection.State != nil && pid == inspection.State.Pid {
+		return true
+	}
+	return inspection.HostConfig != nil && inspection.HostConfig.PidMode.IsHost()
+}
+
+// Signal sends the signal to a process of the container, see signalable
+func (d *DockerCli) Signal(ctx context.Context, c types.Container, pid int, signal string) error {
+	inspection, err := d.cli.ContainerInspect(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+	if inspection.State == nil || !inspection.State.Running {
+		return fmt.Errorf("container %s is not running", c.ID)
+	}
+	if pid == inspection.State.Pid {
+		return d.cli.ContainerKill(ctx, c.ID, signal)
+	}
+	if !signalable(inspection, pid) {
+		return fmt.Errorf("only the main process (%d) can be signaled, "+
+			"the container doesn't share the PID namespace of the host",
+			inspection.State.Pid)
+	}
+	_, err = d.run(ctx, c.ID, "kill", "-s", signal, strconv.Itoa(pid))
+	return err
+}
+
+// run executes the command in the container and returns the stdout,
+// the stderr is the error if it fails
+func (d *DockerCli) run(ctx context.Context, cid string, cmds ...string) (string, error) {
+	response, err := d.cli.ContainerExecCreate(ctx, cid, container.ExecOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmds,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := d.cli.ContainerExecAttach(ctx, response.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Close()
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	if _, err := stdcopy.StdCopy(stdout, stderr, resp.Reader); err != nil {
+		return "", err
+	}
+
+	inspection, err := d.cli.ContainerExecInspect(ctx, response.ID)
+	if err != nil {
+		return "", err
+	}
+	if inspection.ExitCode != 0 {
+		return "", fmt.Errorf("%s exits with %d: %s", cmds[0],
+			inspection.ExitCode, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestTop(t *testing.T) {
+	cli := newFakeCli(t, newFakeDaemon())
+	processes, err := cli.Top(context.Background(), types.Container{ID: fakeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the workers are of the host PIDs, only the main one can be signaled
+	expect := []types.Process{
+		{PID: fakePID, User: "root", Command: "nginx -g daemon off;"},
+		{PID: fakePID + 1, User: "nginx", Command: "nginx: worker process", NoSignal: true},
+	}
+	if !reflect.DeepEqual(processes, expect) {
+		t.Errorf("expect %+v, got %+v", expect, processes)
+	}
+
+	// all of them can be signaled in the PID namespace of the host
+	daemon := newFakeDaemon()
+	daemon.hostPID = true
+	processes, err = newFakeCli(t, daemon).Top(context.Background(), types.Container{ID: fakeID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range processes {
+		if p.NoSignal {
+			t.Errorf("unexpected process %+v", p)
+		}
+	}
+}
+
+func TestSignal(t *testing.T) {
+	daemon := newFakeDaemon()
+	cli := newFakeCli(t, daemon)
+	c := types.Container{ID: fakeID}
+
+	if err := cli.Signal(context.Background(), c, fakePID, "HUP"); err != nil {
+		t.Fatal(err)
+	}
+	if daemon.killed != "HUP" {
+		t.Errorf("expect HUP, got %q", daemon.killed)
+	}
+
+	// not the main process, nor of the host PID namespace
+	if err := cli.Signal(context.Background(), c, fakePID+1, "TERM"); err == nil {
+		t.Error("expect error")
+	}
+	if daemon.killed != "HUP" {
+		t.Errorf("expect HUP, got %q", daemon.killed)
+	}
+}
@@ -0,0 +1,313 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+const (
+	fakeID  = "0123456789abcdef"
+	fakePID = 4242
+)
+
+// fakeDaemon serves the ping, the container list, the inspect, the stats,
+// the top, the kill, the logs and the events, the event stream breaks
+// when it's down
+type fakeDaemon struct {
+	m       sync.Mutex
+	down    bool
+	running bool
+	tty     bool
+	killed  string // the last signal
+	drop    chan struct{}
+	events  chan events.Message
+
+	// the container list waits for the test twice if set, once it's
+	// requested and before it responds
+	listHold chan struct{}
+	// the container shares the PID namespace of the host
+	hostPID bool
+}
+
+func newFakeDaemon() *fakeDaemon {
+	return &fakeDaemon{
+		running: true,
+		drop:    make(chan struct{}),
+		events:  make(chan events.Message),
+	}
+}
+
+func (f *fakeDaemon) setDown(down bool) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.down = down
+	if down {
+		close(f.drop)
+		f.drop = make(chan struct{})
+	}
+}
+
+func (f *fakeDaemon) setRunning(running bool, action events.Action) {
+	f.m.Lock()
+	f.running = running
+	f.m.Unlock()
+	f.events <- events.Message{
+		Type:   events.ContainerEventType,
+		Action: action,
+		Actor:  events.Actor{ID: fakeID},
+	}
+}
+
+func (f *fakeDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.m.Lock()
+	down, drop, running, listHold := f.down, f.drop, f.running, f.listHold
+	f.m.Unlock()
+
+	w.Header().Set("Api-Version", "1.51")
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/_ping"):
+		w.Write([]byte("OK"))
+	case down:
+		http.Error(w, "daemon is down", http.StatusServiceUnavailable)
+	case strings.HasSuffix(r.URL.Path, "/containers/json"):
+		if listHold != nil {
+			listHold <- struct{}{}
+			listHold <- struct{}{}
+		}
+		if !running {
+			w.Write([]byte(`[]`))
+			return
+		}
+		fmt.Fprintf(w, `[{"Id":%q,"Names":["/web"],"State":"running"}]`, fakeID)
+	case strings.HasSuffix(r.URL.Path, "/containers/"+fakeID+"/json"):
+		state := &container.State{
+			Status:     container.StateExited,
+			StartedAt:  time.Now().Add(-time.Hour * 3).Format(time.RFC3339Nano),
+			FinishedAt: time.Now().Add(-time.Minute * 2).Format(time.RFC3339Nano),
+			ExitCode:   137,
+		}
+		if running {
+			state.Status, state.Running, state.Pid = container.StateRunning, true, fakePID
+		}
+		hostConfig := &container.HostConfig{}
+		if f.hostPID {
+			hostConfig.PidMode = "host"
+		}
+		json.NewEncoder(w).Encode(container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{
+				ID:    fakeID,
+				Name:  "/web",
+				Path:  "nginx",
+				Args:  []string{"-g", "daemon off;"},
+				State: state,
+
+				HostConfig: hostConfig,
+			},
+			Config: &container.Config{Image: "nginx", Tty: f.tty},
+		})
+	case strings.HasSuffix(r.URL.Path, "/containers/"+fakeID+"/stats"):
+		// the first one is not primed
+		encoder := json.NewEncoder(w)
+		for _, s := range fakeStats {
+			encoder.Encode(s)
+		}
+	case strings.HasSuffix(r.URL.Path, "/containers/"+fakeID+"/top"):
+		// the ps of the host is busybox
+		if r.URL.Query().Get("ps_args") != "" {
+			http.Error(w, "ps: unrecognized option", http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(container.TopResponse{
+			Titles: []string{"PID", "USER", "TIME", "COMMAND"},
+			Processes: [][]string{
+				{strconv.Itoa(fakePID), "root", "0:00", "nginx -g daemon off;"},
+				{strconv.Itoa(fakePID + 1), "nginx", "0:00", "nginx: worker process"},
+			},
+		})
+	case strings.HasSuffix(r.URL.Path, "/containers/"+fakeID+"/logs"):
+		if f.tty {
+			w.Write([]byte(recordedTTY))
+		} else {
+			w.Write([]byte(recordedFrames))
+		}
+	case strings.HasSuffix(r.URL.Path, "/containers/"+fakeID+"/kill"):
+		f.m.Lock()
+		f.killed = r.URL.Query().Get("signal")
+		f.m.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	case strings.HasSuffix(r.URL.Path, "/events"):
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case event := <-f.events:
+				json.NewEncoder(w).Encode(event)
+				w.(http.Flusher).Flush()
+			case <-drop:
+				return
+			case <-r.Context().Done():
+				return
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeCli(t *testing.T, daemon *fakeDaemon) *DockerCli {
+	srv := httptest.NewServer(daemon)
+	t.Cleanup(srv.Close)
+
+	e, err := parseEndpoint(srv.URL[len("http://"):], "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli, err := newCli(config.DockerConfig{}, e, "", new(types.EventHub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return cli
+}
+
+// waitFor waits for the list to match
+func waitFor(t *testing.T, cli *DockerCli, match func([]types.Container) bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second * 5)
+	for time.Now().Before(deadline) {
+		if match(cli.List(context.Background())) {
+			return
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	t.Fatalf("unexpected list %+v", cli.List(context.Background()))
+}
+
+func TestReconnect(t *testing.T) {
+	daemon := newFakeDaemon()
+	cli := newFakeCli(t, daemon)
+
+	stale := func(stale bool) func([]types.Container) bool {
+		return func(cs []types.Container) bool {
+			return len(cs) == 1 && cs[0].Stale == stale && (cli.Health() != nil) == stale
+		}
+	}
+	waitFor(t, cli, stale(false))
+	daemon.setDown(true)
+	waitFor(t, cli, stale(true))
+	daemon.setDown(false)
+	waitFor(t, cli, stale(false))
+}
+
+func TestEvents(t *testing.T) {
+	daemon := newFakeDaemon()
+	cli := newFakeCli(t, daemon)
+
+	waitFor(t, cli, func(cs []types.Container) bool {
+		return len(cs) == 1
+	})
+	c := cli.List(context.Background())[0]
+	if c.Name != "web" || c.Image != "nginx" || c.Command != "nginx -g daemon off;" ||
+		c.Status != "Up 3 hours" || c.State != "running" {
+		t.Errorf("unexpected container %+v", c)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watch := cli.Watch(ctx)
+	expect := func(action string) {
+		t.Helper()
+		select {
+		case event := <-watch:
+			if event.Action != action || event.Container.ID != fakeID {
+				t.Errorf("unexpected event %+v", event)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("no %s event", action)
+		}
+	}
+
+	// the stopped containers are not listed without -a
+	daemon.setRunning(false, events.ActionDie)
+	expect(types.ContainerRemoved)
+	if len(cli.List(ctx)) != 0 {
+		t.Errorf("unexpected list %+v", cli.List(ctx))
+	}
+
+	daemon.setRunning(true, events.ActionStart)
+	expect(types.ContainerCreated)
+
+	daemon.setRunning(true, events.ActionHealthStatusHealthy)
+	expect(types.ContainerUpdated)
+
+	cli.listOptions.All = true
+	daemon.setRunning(false, events.ActionDie)
+	expect(types.ContainerUpdated)
+	if cs := cli.List(ctx); len(cs) != 1 || cs[0].Status != "Exited (137) 2 minutes ago" {
+		t.Errorf("unexpected list %+v", cs)
+	}
+}
+
+func TestEventsDuringRefresh(t *testing.T) {
+	daemon := newFakeDaemon()
+	hold := make(chan struct{})
+	daemon.listHold = hold
+	cli := newFakeCli(t, daemon)
+
+	// the container is destroyed while it's being listed
+	<-hold
+	daemon.m.Lock()
+	daemon.listHold = nil
+	daemon.m.Unlock()
+	daemon.setRunning(false, events.ActionDestroy)
+	time.Sleep(time.Millisecond * 100)
+	<-hold
+
+	// the list is set before the event is applied
+	time.Sleep(time.Millisecond * 300)
+	if cs := cli.List(context.Background()); len(cs) != 0 {
+		t.Errorf("the destroyed container is listed %+v", cs)
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) string {
+		return now.Add(-d).Format(time.RFC3339Nano)
+	}
+	for _, tc := range []struct {
+		state  container.State
+		status string
+	}{
+		{container.State{Running: true, StartedAt: ago(time.Hour * 3)}, "Up 3 hours"},
+		{container.State{Running: true, Paused: true, StartedAt: ago(time.Minute)}, "Up About a minute (Paused)"},
+		{container.State{Running: true, StartedAt: ago(time.Second * 10),
+			Health: &container.Health{Status: container.Starting}}, "Up 10 seconds (health: starting)"},
+		{container.State{Running: true, StartedAt: ago(time.Hour * 48),
+			Health: &container.Health{Status: container.Unhealthy}}, "Up 2 days (unhealthy)"},
+		{container.State{Running: true, Restarting: true, ExitCode: 1,
+			FinishedAt: ago(time.Second * 5)}, "Restarting (1) 5 seconds ago"},
+		{container.State{Status: container.StateCreated}, "Created"},
+		{container.State{Dead: true}, "Dead"},
+		{container.State{Status: container.StateExited, FinishedAt: ago(time.Minute * 5)}, "Exited (0) 5 minutes ago"},
+	} {
+		if status := statusOf(&tc.state, now); status != tc.status {
+			t.Errorf("expect %q, got %q", tc.status, status)
+		}
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 
 	"github.com/wrfly/container-web-tty/config"
 	pb "github.com/wrfly/container-web-tty/proxy/pb"
@@ -15,6 +19,12 @@ import (
 	"github.com/wrfly/container-web-tty/util"
 )
 
+// the backoff of watching the remote servers again
+const (
+	minBackoff = time.Second
+	maxBackoff = time.Second * 30
+)
+
 type grpcCli struct {
 	addr, auth string
 
@@ -206,7 +216,7 @@ func (gCli GrpcCli) containerAction(ctx context.Context, action, containerID str
 			return err2
 		}
 		if err1 != nil {
-			return fmt.Errorf(err1.Err)
+			return fmt.Errorf("%s", err1.Err)
 		}
 		return nil
 	}
@@ -282,14 +292,12 @@ func (gCli GrpcCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadClo
 		return nil, fmt.Errorf("remote server %s is not ready: %s", info.LocServer, cli.state())
 	}
 
-	logsClient, err := cli.client.Logs(ctx, &pb.LogOpts{
-		Follow: opts.Follow,
-		Tail:   opts.Tail,
-		C: &pb.ContainerID{
-			Id:   info.ID,
-			Auth: gCli.auth,
-		},
-	})
+	logOpts := util.ConvertTpLogOpts(opts)
+	logOpts.C = &pb.ContainerID{
+		Id:   info.ID,
+		Auth: gCli.auth,
+	}
+	logsClient, err := cli.client.Logs(ctx, logOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -316,3 +324,184 @@ func (gCli GrpcCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadClo
 
 	return pr, nil
 }
+
+// Watch returns the changes of the containers of all the remote servers
+// until the context is done, the broken watches are retried
+func (gCli GrpcCli) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	out := make(chan types.ContainerEvent, 64)
+	wg := sync.WaitGroup{}
+	for addr, cli := range gCli.clients {
+		wg.Add(1)
+		go func(addr string, cli grpcCli) {
+			defer wg.Done()
+			gCli.watch(ctx, addr, cli, out)
+		}(addr, cli)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// watch watches the remote server until the context is done
+func (gCli GrpcCli) watch(ctx context.Context, addr string, cli grpcCli,
+	out chan<- types.ContainerEvent) {
+	backoff := minBackoff
+	for {
+		start := time.Now()
+		err := gCli.watchEvents(ctx, addr, cli, out) // will block here
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			logrus.Warnf("remote server %s doesn't report the changes: %s", addr, err)
+			return
+		}
+
+		if time.Since(start) > maxBackoff {
+			backoff = minBackoff
+		}
+		logrus.Errorf("watch remote server %s error: %s, retry in %s", addr, err, backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// watchEvents keeps the containers updated by the events of the remote
+// server, it returns when the stream breaks
+func (gCli GrpcCli) watchEvents(ctx context.Context, addr string, cli grpcCli,
+	out chan<- types.ContainerEvent) error {
+	stream, err := cli.client.Watch(ctx, &pb.Empty{Auth: gCli.auth})
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if event.GetC() == nil {
+			continue
+		}
+		c := util.ConvertPbContainer(event.GetC())
+		c.LocServer = addr
+		if event.GetAction() == types.ContainerRemoved {
+			gCli.containers.Remove(c.ID)
+		} else {
+			if old := gCli.containers.Find(c.ID); old.ID == c.ID {
+				c.Shell = old.Shell
+			}
+			gCli.containers.Put(c)
+		}
+
+		select {
+		case out <- types.ContainerEvent{Action: event.GetAction(), Container: c}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Stats streams the resource usage of the container from its remote server
+func (gCli GrpcCli) Stats(ctx context.Context, c types.Container) (<-chan types.Stats, error) {
+	info := gCli.containers.Find(c.ID)
+	if info.ID == "" {
+		return nil, fmt.Errorf("container not found")
+	}
+
+	cli, exist := gCli.clients[info.LocServer]
+	if !exist {
+		return nil, fmt.Errorf("location server [%s] not found", info.LocServer)
+	}
+
+	statsClient, err := cli.client.Stats(ctx, &pb.ContainerID{
+		Id:   info.ID,
+		Auth: gCli.auth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the errors of the remote backend come with the first one
+	first, err := statsClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(chan types.Stats, 1)
+	stats <- util.ConvertPbStats(first)
+	go func() {
+		defer close(stats)
+		for {
+			s, err := statsClient.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					logrus.Errorf("stats recv error: %s", err)
+				}
+				return
+			}
+			select {
+			case stats <- util.ConvertPbStats(s):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stats, nil
+}
+
+func (gCli GrpcCli) Top(ctx context.Context, c types.Container) ([]types.Process, error) {
+	info := gCli.containers.Find(c.ID)
+	if info.ID == "" {
+		return nil, fmt.Errorf("container not found")
+	}
+
+	cli, exist := gCli.clients[info.LocServer]
+	if !exist {
+		return nil, fmt.Errorf("location server [%s] not found", info.LocServer)
+	}
+
+	processes, err := cli.client.Top(ctx, &pb.ContainerID{
+		Id:   info.ID,
+		Auth: gCli.auth,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return util.ConvertPbProcesses(processes), nil
+}
+
+func (gCli GrpcCli) Signal(ctx context.Context, c types.Container, pid int, signal string) error {
+	info := gCli.containers.Find(c.ID)
+	if info.ID == "" {
+		return fmt.Errorf("container not found")
+	}
+
+	cli, exist := gCli.clients[info.LocServer]
+	if !exist {
+		return fmt.Errorf("location server [%s] not found", info.LocServer)
+	}
+
+	e, err := cli.client.Signal(ctx, &pb.SignalOpts{
+		C: &pb.ContainerID{
+			Id:   info.ID,
+			Auth: gCli.auth,
+		},
+		Pid:    int32(pid),
+		Signal: signal,
+	})
+	if err != nil {
+		return err
+	}
+	if e.Err != "" {
+		return fmt.Errorf("%s", e.Err)
+	}
+	return nil
+}
@@ -0,0 +1,278 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+const (
+	// replicasAnnotation remembers the replicas of a stopped workload
+	replicasAnnotation = "container-web-tty/replicas"
+	// stoppedLabel marks the workloads stopped by us, so they can be listed
+	// and started again after all the pods are gone
+	stoppedLabel = "container-web-tty/stopped"
+	// restartedAtAnnotation is the same as `kubectl rollout restart`
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// workload is the controller owning a pod
+type workload struct {
+	kind, namespace, name string
+}
+
+func (w workload) String() string {
+	return strings.ToLower(w.kind) + "/" + w.name
+}
+
+// parseWorkload parses "kind/name", the Workload of a stopped one
+func parseWorkload(namespace, s string) (workload, bool) {
+	kind, name, ok := strings.Cut(s, "/")
+	switch kind {
+	case "deployment":
+		kind = "Deployment"
+	case "statefulset":
+		kind = "StatefulSet"
+	default:
+		return workload{}, false
+	}
+	return workload{kind: kind, namespace: namespace, name: name}, ok
+}
+
+// owner returns the workload owning the pod of the container, the
+// ReplicaSets are resolved to their Deployments
+func (cl *cluster) owner(ctx context.Context, c types.Container) (workload, error) {
+	pod, err := cl.cli.CoreV1().Pods(c.Namespace).Get(ctx, c.PodName, metav1.GetOptions{})
+	if err != nil {
+		return workload{}, err
+	}
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return workload{}, nil
+	}
+	w := workload{kind: ref.Kind, namespace: c.Namespace, name: ref.Name}
+	if ref.Kind != "ReplicaSet" {
+		return w, nil
+	}
+
+	rs, err := cl.cli.AppsV1().ReplicaSets(c.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return workload{}, err
+	}
+	if ref := metav1.GetControllerOf(rs); ref != nil && ref.Kind == "Deployment" {
+		w.kind, w.name = ref.Kind, ref.Name
+	}
+	return w, nil
+}
+
+// find returns the container, or the stopped workload, and its cluster
+func (kube KubeCli) find(ctx context.Context, cid string) (types.Container, *cluster, error) {
+	c := kube.lookup(cid)
+	if c.ID == "" {
+		return c, nil, fmt.Errorf("container %s not found", cid)
+	}
+	cl, err := kube.clusterOf(c)
+	return c, cl, err
+}
+
+func (cl *cluster) patch(ctx context.Context, w workload, patch map[string]interface{}) error {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("patch %s: %s", w, data)
+
+	switch w.kind {
+	case "Deployment":
+		_, err = cl.cli.AppsV1().Deployments(w.namespace).
+			Patch(ctx, w.name, k8stypes.MergePatchType, data, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = cl.cli.AppsV1().StatefulSets(w.namespace).
+			Patch(ctx, w.name, k8stypes.MergePatchType, data, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = cl.cli.AppsV1().DaemonSets(w.namespace).
+			Patch(ctx, w.name, k8stypes.MergePatchType, data, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("cannot patch %s", w)
+	}
+	return err
+}
+
+// replicas returns the replicas and the metadata of a scalable workload
+func (cl *cluster) replicas(ctx context.Context, w workload) (int32, metav1.ObjectMeta, error) {
+	var (
+		replicas *int32
+		meta     metav1.ObjectMeta
+	)
+	switch w.kind {
+	case "Deployment":
+		d, err := cl.cli.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return 0, meta, err
+		}
+		replicas, meta = d.Spec.Replicas, d.ObjectMeta
+	case "StatefulSet":
+		s, err := cl.cli.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		if err != nil {
+			return 0, meta, err
+		}
+		replicas, meta = s.Spec.Replicas, s.ObjectMeta
+	default:
+		return 0, meta, fmt.Errorf("cannot scale %s, only the Deployments "+
+			"and StatefulSets can be stopped and started", w)
+	}
+	if replicas == nil {
+		return 1, meta, nil
+	}
+	return *replicas, meta, nil
+}
+
+// Start scales the stopped workload back to its previous replicas
+func (kube KubeCli) Start(ctx context.Context, cid string) error {
+	c, cl, err := kube.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+
+	var w workload
+	if c.PodName == "" {
+		// the workload stopped by us
+		var ok bool
+		if w, ok = parseWorkload(c.Namespace, c.Workload); !ok {
+			return fmt.Errorf("unknown workload %s", c.Workload)
+		}
+	} else {
+		if w, err = cl.owner(ctx, c); err != nil {
+			return err
+		}
+		if w.kind == "" {
+			return fmt.Errorf("pod %s has no controller, it cannot be started", c.PodName)
+		}
+	}
+
+	current, meta, err := cl.replicas(ctx, w)
+	if err != nil {
+		return err
+	}
+	if current != 0 {
+		return fmt.Errorf("%s is already running with %d replicas", w, current)
+	}
+	replicas, err := strconv.Atoi(meta.Annotations[replicasAnnotation])
+	if err != nil || replicas <= 0 {
+		return fmt.Errorf("%s was not stopped by container-web-tty, "+
+			"the previous replicas is unknown", w)
+	}
+
+	logrus.Infof("start %s with %d replicas", w, replicas)
+	return cl.patch(ctx, w, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{replicasAnnotation: nil},
+			"labels":      map[string]interface{}{stoppedLabel: nil},
+		},
+		"spec": map[string]interface{}{"replicas": replicas},
+	})
+}
+
+// Stop scales the owning workload to zero and remembers the replicas
+func (kube KubeCli) Stop(ctx context.Context, cid string) error {
+	c, cl, err := kube.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	if c.PodName == "" {
+		return fmt.Errorf("%s is already stopped", c.Workload)
+	}
+
+	w, err := cl.owner(ctx, c)
+	if err != nil {
+		return err
+	}
+	if w.kind == "" {
+		return fmt.Errorf("pod %s has no controller, it cannot be stopped", c.PodName)
+	}
+	replicas, _, err := cl.replicas(ctx, w)
+	if err != nil {
+		return err
+	}
+	if replicas == 0 {
+		return fmt.Errorf("%s is already scaled to 0", w)
+	}
+
+	logrus.Infof("stop %s, scale from %d to 0", w, replicas)
+	return cl.patch(ctx, w, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				replicasAnnotation: strconv.Itoa(int(replicas)),
+			},
+			"labels": map[string]interface{}{stoppedLabel: "true"},
+		},
+		"spec": map[string]interface{}{"replicas": 0},
+	})
+}
+
+// Restart does a rollout restart of the owning workload, or deletes
+// the pod if it's owned by other controllers
+func (kube KubeCli) Restart(ctx context.Context, cid string) error {
+	c, cl, err := kube.find(ctx, cid)
+	if err != nil {
+		return err
+	}
+	if c.PodName == "" {
+		return fmt.Errorf("%s is stopped, start it instead", c.Workload)
+	}
+
+	w, err := cl.owner(ctx, c)
+	if err != nil {
+		return err
+	}
+	switch w.kind {
+	case "":
+		return fmt.Errorf("pod %s has no controller, "+
+			"it won't be recreated after deletion", c.PodName)
+	case "Deployment", "StatefulSet", "DaemonSet":
+		logrus.Infof("rollout restart %s", w)
+		return cl.patch(ctx, w, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							restartedAtAnnotation: time.Now().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		})
+	default:
+		logrus.Infof("delete pod %s/%s of %s", c.Namespace, c.PodName, w)
+		return cl.cli.CoreV1().Pods(c.Namespace).
+			Delete(ctx, c.PodName, metav1.DeleteOptions{})
+	}
+}
+
+func stoppedContainer(kind string, meta metav1.ObjectMeta, template v1.PodTemplateSpec) types.Container {
+	w := workload{kind: kind, namespace: meta.Namespace, name: meta.Name}
+	c := types.Container{
+		ID:        strings.ReplaceAll(string(meta.UID), "-", ""),
+		Name:      meta.Name,
+		Namespace: meta.Namespace,
+		Workload:  w.String(),
+		IPs:       []string{"null"},
+		State:     "stopped",
+		Status:    fmt.Sprintf("scaled to 0 from %s", meta.Annotations[replicasAnnotation]),
+	}
+	if len(template.Spec.Containers) != 0 {
+		c.Image = template.Spec.Containers[0].Image
+		c.Command = strings.Join(template.Spec.Containers[0].Command, " ")
+	}
+	return c
+}
@@ -0,0 +1,186 @@
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func testPod(name, containerID string, owners []metav1.OwnerReference) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			OwnerReferences: owners,
+		},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "nginx"}}},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:        "app",
+				ContainerID: "containerd://" + containerID,
+				Ready:       true,
+			}},
+		},
+	}
+}
+
+// newTestCluster returns the cluster with a synced cache
+func newTestCluster(t *testing.T, name string, filter listFilter, objects ...runtime.Object) (*cluster, *fake.Clientset) {
+	clientset := fake.NewClientset(objects...)
+	cl := newCluster(name, clientset, nil, filter)
+	t.Cleanup(cl.cache.close)
+	if !cl.cache.waitForSync(time.Second * 5) {
+		t.Fatal("cache is not synced")
+	}
+	return cl, clientset
+}
+
+// newTestCli returns the client of a single cluster
+func newTestCli(t *testing.T, filter listFilter, objects ...runtime.Object) (*KubeCli, *fake.Clientset) {
+	cl, clientset := newTestCluster(t, "", filter, objects...)
+	kube := &KubeCli{clusters: []*cluster{cl}, containers: &types.Containers{}}
+	return kube, clientset
+}
+
+// eventually waits for the cache to catch up
+func eventually(t *testing.T, condition func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(time.Millisecond * 20)
+	}
+	t.Fatal("timeout waiting for the condition")
+}
+
+func TestKubeActions(t *testing.T) {
+	replicas := int32(2)
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "1234-5678"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app", Image: "nginx"}}},
+				},
+			},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "web-5d4f",
+				Namespace:       "default",
+				OwnerReferences: controllerRef("Deployment", "web"),
+			},
+		},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}},
+		testPod("web-5d4f-x7k2p", "aaaaaaaaaaaaaaaa", controllerRef("ReplicaSet", "web-5d4f")),
+		testPod("agent-9xz8q", "bbbbbbbbbbbbbbbb", controllerRef("DaemonSet", "agent")),
+		testPod("backup-q2w3e", "cccccccccccccccc", controllerRef("Job", "backup")),
+		testPod("bare", "dddddddddddddddd", nil),
+	}
+	kube, clientset := newTestCli(t, listFilter{}, objects...)
+	ctx := context.Background()
+
+	getDeployment := func() *appsv1.Deployment {
+		d, err := clientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	if err := kube.Health(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(kube.List(ctx)); n != 4 {
+		t.Fatalf("expect 4 containers, got %d", n)
+	}
+
+	t.Run("restart", func(t *testing.T) {
+		if err := kube.Restart(ctx, "aaaaaaaaaaaa"); err != nil {
+			t.Fatal(err)
+		}
+		if getDeployment().Spec.Template.Annotations[restartedAtAnnotation] == "" {
+			t.Error("deployment is not restarted")
+		}
+		if err := kube.Restart(ctx, "cccccccccccc"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := clientset.CoreV1().Pods("default").Get(ctx, "backup-q2w3e", metav1.GetOptions{}); err == nil {
+			t.Error("pod of the job is not deleted")
+		}
+		if err := kube.Restart(ctx, "dddddddddddd"); err == nil {
+			t.Error("expect error of restarting a bare pod")
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		if err := kube.Stop(ctx, "bbbbbbbbbbbb"); err == nil {
+			t.Error("expect error of stopping a daemonset")
+		}
+		if err := kube.Stop(ctx, "aaaaaaaaaaaa"); err != nil {
+			t.Fatal(err)
+		}
+		d := getDeployment()
+		if *d.Spec.Replicas != 0 || d.Annotations[replicasAnnotation] != "2" ||
+			d.Labels[stoppedLabel] != "true" {
+			t.Errorf("unexpected deployment %+v", d.ObjectMeta)
+		}
+		if err := kube.Stop(ctx, "aaaaaaaaaaaa"); err == nil {
+			t.Error("expect error of stopping a stopped deployment")
+		}
+	})
+
+	t.Run("start", func(t *testing.T) {
+		// the pods are gone, the deployment is listed
+		if err := clientset.CoreV1().Pods("default").Delete(ctx, "web-5d4f-x7k2p", metav1.DeleteOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		var stopped types.Container
+		eventually(t, func() bool {
+			containers := kube.List(ctx)
+			stopped = containers[len(containers)-1]
+			return len(containers) == 3 && stopped.State == "stopped"
+		})
+		if stopped.ID != "12345678" || stopped.Workload != "deployment/web" || stopped.Command != "" {
+			t.Fatalf("unexpected stopped container %+v", stopped)
+		}
+		if err := kube.Stop(ctx, stopped.ID); err == nil ||
+			err.Error() != "deployment/web is already stopped" {
+			t.Errorf("unexpected error %v", err)
+		}
+		if c := kube.GetInfo(ctx, stopped.ID); c.Shell != "" {
+			t.Errorf("unexpected shell %q", c.Shell)
+		}
+
+		if err := kube.Start(ctx, stopped.ID); err != nil {
+			t.Fatal(err)
+		}
+		d := getDeployment()
+		if *d.Spec.Replicas != 2 || d.Annotations[replicasAnnotation] != "" ||
+			d.Labels[stoppedLabel] != "" {
+			t.Errorf("unexpected deployment %+v", d.ObjectMeta)
+		}
+		eventually(t, func() bool { return kube.GetInfo(ctx, stopped.ID).ID == "" })
+		if err := kube.Start(ctx, stopped.ID); err == nil {
+			t.Error("expect error of starting a running deployment")
+		}
+	})
+}
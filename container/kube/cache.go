@@ -0,0 +1,337 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// probeTimeout is how long to probe the workloads of a cluster
+const probeTimeout = time.Second * 5
+
+// podCache keeps the containers up to date with the informers, the
+// pods are updated incrementally and the list is rebuilt on demand,
+// the changes are published to the hub
+type podCache struct {
+	cluster    string
+	filter     listFilter
+	containers *types.Containers
+	factories  []informers.SharedInformerFactory
+	synced     []cache.InformerSynced
+	stop       chan struct{}
+	hub        *types.EventHub
+
+	m       sync.Mutex
+	pods    map[string][]types.Container // namespace/name -> containers
+	stopped map[string]types.Container   // the workloads stopped by us
+	dirty   bool
+}
+
+func newPodCache(cluster string, cli kubernetes.Interface, filter listFilter) *podCache {
+	c := &podCache{
+		cluster:    cluster,
+		filter:     filter,
+		containers: &types.Containers{},
+		stop:       make(chan struct{}),
+		hub:        new(types.EventHub),
+		pods:       make(map[string][]types.Container),
+		stopped:    make(map[string]types.Container),
+	}
+
+	// the stopped workloads are optional, the RBAC may not allow to list
+	// them, the informers of the unlistable ones would never sync
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	watchDeployments := probeWorkloads(ctx, "deployments", filter,
+		func(ctx context.Context, ns string, opts metav1.ListOptions) error {
+			_, err := cli.AppsV1().Deployments(ns).List(ctx, opts)
+			return err
+		})
+	watchStatefulSets := probeWorkloads(ctx, "statefulsets", filter,
+		func(ctx context.Context, ns string, opts metav1.ListOptions) error {
+			_, err := cli.AppsV1().StatefulSets(ns).List(ctx, opts)
+			return err
+		})
+	cancel()
+
+	for _, ns := range filter.namespaceList() {
+		podFactory := informers.NewSharedInformerFactoryWithOptions(cli, 0,
+			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				podOpts := filter.podOptions()
+				opts.LabelSelector = podOpts.LabelSelector
+				opts.FieldSelector = podOpts.FieldSelector
+			}))
+		c.watch(podFactory.Core().V1().Pods().Informer(), c.setPod, c.deletePod)
+		c.factories = append(c.factories, podFactory)
+
+		if !watchDeployments && !watchStatefulSets {
+			continue
+		}
+		workloadFactory := informers.NewSharedInformerFactoryWithOptions(cli, 0,
+			informers.WithNamespace(ns),
+			informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+				workloadOpts := filter.workloadOptions(stoppedLabel + "=true")
+				opts.LabelSelector = workloadOpts.LabelSelector
+				opts.FieldSelector = workloadOpts.FieldSelector
+			}))
+		if watchDeployments {
+			c.watch(workloadFactory.Apps().V1().Deployments().Informer(), c.setWorkload, c.deleteWorkload)
+		}
+		if watchStatefulSets {
+			c.watch(workloadFactory.Apps().V1().StatefulSets().Informer(), c.setWorkload, c.deleteWorkload)
+		}
+		c.factories = append(c.factories, workloadFactory)
+	}
+
+	for _, factory := range c.factories {
+		factory.Start(c.stop)
+	}
+	return c
+}
+
+// probeWorkloads tells whether the stopped workloads of the resource can
+// be listed in the namespaces
+func probeWorkloads(ctx context.Context, resource string, filter listFilter,
+	list func(ctx context.Context, ns string, opts metav1.ListOptions) error) bool {
+	for _, ns := range filter.namespaceList() {
+		opts := filter.workloadOptions(stoppedLabel + "=true")
+		opts.Limit = 1
+		if err := list(ctx, ns, opts); err != nil {
+			logrus.Warnf("cannot list the %s, the stopped ones are not listed: %s",
+				resource, err)
+			return false
+		}
+	}
+	return true
+}
+
+func (c *podCache) watch(informer cache.SharedIndexInformer,
+	set func(obj interface{}), del func(obj interface{})) {
+	informer.SetWatchErrorHandler(func(r *cache.Reflector, err error) {
+		logrus.Errorf("kube cache watch error: %s", err)
+	})
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    set,
+		UpdateFunc: func(_, obj interface{}) { set(obj) },
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			del(obj)
+		},
+	})
+	c.synced = append(c.synced, informer.HasSynced)
+}
+
+func (c *podCache) setPod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+	containers := podContainers(pod, c.filter.onlyReady)
+	for i := range containers {
+		containers[i].Cluster = c.cluster
+	}
+	key := pod.Namespace + "/" + pod.Name
+	c.m.Lock()
+	olds := c.pods[key]
+	c.pods[key] = containers
+	c.dirty = true
+	c.m.Unlock()
+	c.publishChanges(olds, containers)
+}
+
+func (c *podCache) deletePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		return
+	}
+	key := pod.Namespace + "/" + pod.Name
+	c.m.Lock()
+	olds := c.pods[key]
+	delete(c.pods, key)
+	c.dirty = true
+	c.m.Unlock()
+	c.publishChanges(olds, nil)
+}
+
+func (c *podCache) setWorkload(obj interface{}) {
+	// the workload is started, not matching the selector anymore
+	if meta, ok := obj.(metav1.Object); ok && meta.GetLabels()[stoppedLabel] != "true" {
+		c.deleteWorkload(obj)
+		return
+	}
+
+	var container types.Container
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		container = stoppedContainer("Deployment", w.ObjectMeta, w.Spec.Template)
+	case *appsv1.StatefulSet:
+		container = stoppedContainer("StatefulSet", w.ObjectMeta, w.Spec.Template)
+	default:
+		return
+	}
+	container.Cluster = c.cluster
+	key := container.Namespace + "/" + container.Workload
+	c.m.Lock()
+	old, exist := c.stopped[key]
+	c.stopped[key] = container
+	c.dirty = true
+	c.m.Unlock()
+	if exist {
+		c.publishChanges([]types.Container{old}, []types.Container{container})
+	} else {
+		c.publishChanges(nil, []types.Container{container})
+	}
+}
+
+func (c *podCache) deleteWorkload(obj interface{}) {
+	var key string
+	switch w := obj.(type) {
+	case *appsv1.Deployment:
+		key = w.Namespace + "/" + workload{kind: "Deployment", name: w.Name}.String()
+	case *appsv1.StatefulSet:
+		key = w.Namespace + "/" + workload{kind: "StatefulSet", name: w.Name}.String()
+	default:
+		return
+	}
+	c.m.Lock()
+	old, exist := c.stopped[key]
+	delete(c.stopped, key)
+	c.dirty = true
+	c.m.Unlock()
+	if exist {
+		c.publishChanges([]types.Container{old}, nil)
+	}
+}
+
+// publishChanges publishes the differences of the containers of a pod
+// or a workload
+func (c *podCache) publishChanges(olds, news []types.Container) {
+	oldByID := make(map[string]types.Container, len(olds))
+	for _, old := range olds {
+		oldByID[old.ID] = old
+	}
+	for _, container := range news {
+		old, exist := oldByID[container.ID]
+		delete(oldByID, container.ID)
+		switch {
+		case !exist:
+			c.hub.Publish(types.ContainerEvent{Action: types.ContainerCreated, Container: container})
+		case old.State != container.State || old.Status != container.Status:
+			c.hub.Publish(types.ContainerEvent{Action: types.ContainerUpdated, Container: container})
+		}
+	}
+	for id := range oldByID {
+		c.hub.Publish(types.ContainerEvent{
+			Action:    types.ContainerRemoved,
+			Container: types.Container{ID: id, Cluster: c.cluster},
+		})
+	}
+}
+
+// list returns the containers, rebuilds the list if anything changed
+func (c *podCache) list() []types.Container {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.dirty {
+		c.rebuild()
+	}
+	return c.containers.List()
+}
+
+// find returns the container of the ID or the prefix of the ID, without
+// rebuilding the list if nothing changed
+func (c *podCache) find(cid string) types.Container {
+	c.m.Lock()
+	if c.dirty {
+		c.rebuild()
+	}
+	c.m.Unlock()
+	return c.containers.Find(cid)
+}
+
+// rebuild sorts the pods and the stopped workloads into the list,
+// it's called with the lock held
+func (c *podCache) rebuild() {
+	containers := []types.Container{}
+	for _, cs := range c.pods {
+		containers = append(containers, cs...)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		a, b := containers[i], containers[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.PodName != b.PodName {
+			return a.PodName < b.PodName
+		}
+		return a.Name < b.Name
+	})
+	stopped := []types.Container{}
+	for _, container := range c.stopped {
+		stopped = append(stopped, container)
+	}
+	sort.Slice(stopped, func(i, j int) bool {
+		return stopped[i].Namespace+"/"+stopped[i].Workload <
+			stopped[j].Namespace+"/"+stopped[j].Workload
+	})
+	containers = append(containers, stopped...)
+
+	c.containers.Set(containers)
+	c.dirty = false
+}
+
+func (c *podCache) hasSynced() bool {
+	for _, synced := range c.synced {
+		if !synced() {
+			return false
+		}
+	}
+	return true
+}
+
+// waitForSync waits for the first list of the informers
+func (c *podCache) waitForSync(timeout time.Duration) bool {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(ctx.Done(), c.synced...) {
+		logrus.Warnf("kube cache is not synced in %s, keep syncing in background", timeout)
+		go func() {
+			if cache.WaitForCacheSync(c.stop, c.synced...) {
+				logrus.Infof("kube cache synced in %s", time.Since(start).Round(time.Millisecond))
+			}
+		}()
+		return false
+	}
+	logrus.Infof("kube cache synced in %s, %d containers",
+		time.Since(start).Round(time.Millisecond), len(c.list()))
+	return true
+}
+
+func (c *podCache) health() error {
+	if !c.hasSynced() {
+		return fmt.Errorf("kube cache is not synced")
+	}
+	return nil
+}
+
+func (c *podCache) close() {
+	close(c.stop)
+	for _, factory := range c.factories {
+		factory.Shutdown()
+	}
+}
@@ -0,0 +1,147 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// debugStartTimeout is how long to wait for the debug image to be
+// pulled and the ephemeral container to be running
+const debugStartTimeout = time.Minute * 2
+
+// debugContainer returns the ephemeral container of the debug image,
+// it shares the process namespace of the target container, so the
+// target's filesystem is reachable under /proc/1/root
+func debugContainer(c types.Container, image string) (v1.EphemeralContainer, error) {
+	opts := c.Exec
+	// the env and the command are applied by the shell of the image
+	cmds, err := execCommand("sh", types.ExecOptions{
+		Env:  opts.Env,
+		Cmd:  opts.Cmd,
+		Args: opts.Args,
+	}, nil)
+	if err != nil {
+		return v1.EphemeralContainer{}, err
+	}
+
+	var sc *v1.SecurityContext
+	if opts.User != "" {
+		uid, err := strconv.ParseInt(opts.User, 10, 64)
+		if err != nil {
+			return v1.EphemeralContainer{}, fmt.Errorf("bad user %q, the debug "+
+				"container only supports a uid", opts.User)
+		}
+		sc = &v1.SecurityContext{RunAsUser: &uid}
+	}
+	if opts.Privileged {
+		if sc == nil {
+			sc = &v1.SecurityContext{}
+		}
+		sc.Privileged = &opts.Privileged
+	}
+
+	return v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:                     "debugger-" + rand.String(5),
+			Image:                    image,
+			ImagePullPolicy:          v1.PullIfNotPresent,
+			Command:                  cmds,
+			SecurityContext:          sc,
+			TerminationMessagePolicy: v1.TerminationMessageReadFile,
+			// the shell exits once the session is detached
+			Stdin:     true,
+			StdinOnce: true,
+			TTY:       true,
+		},
+		TargetContainerName: c.ContainerName,
+	}, nil
+}
+
+// debug injects an ephemeral container into the pod and attaches to it,
+// the ephemeral containers cannot be removed, it's stopped when the
+// session ends
+func (cl *cluster) debug(pod *v1.Pod, c types.Container, image string) (types.TTY, error) {
+	if image == "" {
+		return nil, fmt.Errorf("the debug mode is disabled, no debug image configured")
+	}
+	ec, err := debugContainer(c, image)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), debugStartTimeout)
+	defer cancel()
+
+	pod = pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, ec)
+	logrus.Infof("debug container %s of pod %s/%s with image %s",
+		c.ContainerName, c.Namespace, c.PodName, image)
+	_, err = cl.cli.CoreV1().Pods(c.Namespace).
+		UpdateEphemeralContainers(ctx, c.PodName, pod, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("create debug container error: %s", err)
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, debugStartTimeout, true,
+		func(ctx context.Context) (bool, error) {
+			p, err := cl.cli.CoreV1().Pods(c.Namespace).Get(ctx, c.PodName, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			for _, status := range p.Status.EphemeralContainerStatuses {
+				if status.Name != ec.Name {
+					continue
+				}
+				if t := status.State.Terminated; t != nil {
+					return false, fmt.Errorf("debug container exited: %s %s", t.Reason, t.Message)
+				}
+				if w := status.State.Waiting; w != nil && w.Message != "" {
+					logrus.Debugf("debug container %s: %s %s", ec.Name, w.Reason, w.Message)
+				}
+				return status.State.Running != nil, nil
+			}
+			return false, nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("wait for debug container %s error: %s", ec.Name, err)
+	}
+
+	req := cl.cli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(c.PodName).
+		Namespace(c.Namespace).
+		SubResource("attach").
+		Param("container", ec.Name).
+		Param("stdin", "true").
+		Param("stdout", "true").
+		Param("tty", "true")
+
+	enj := newInjector(ctx)
+	enj.execOptions = debugOptions(c.Exec, image)
+	if err := cl.stream(req, &enj); err != nil {
+		return nil, err
+	}
+	return &enj, nil
+}
+
+// debugOptions describes the options of the debug container
+func debugOptions(opts types.ExecOptions, image string) string {
+	options := "debug " + image
+	if opts.User != "" {
+		options += ", uid " + opts.User
+	}
+	if o := effectiveOptions(opts, nil); o != "" {
+		options += ", " + o
+	}
+	return options
+}
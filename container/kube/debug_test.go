@@ -0,0 +1,52 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestDebugContainer(t *testing.T) {
+	c := types.Container{ContainerName: "app"}
+	ec, err := debugContainer(c, "busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ec.TargetContainerName != "app" || ec.Image != "busybox" ||
+		!strings.HasPrefix(ec.Name, "debugger-") {
+		t.Errorf("unexpected debug container %+v", ec)
+	}
+	if cmd := strings.Join(ec.Command, " "); cmd != "sh -l" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	if !ec.Stdin || !ec.StdinOnce || !ec.TTY || ec.SecurityContext != nil {
+		t.Errorf("unexpected debug container %+v", ec)
+	}
+
+	c.Exec = types.ExecOptions{User: "1000", Env: "A=1", Cmd: "top", Privileged: true}
+	ec, err = debugContainer(c, "busybox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd := strings.Join(ec.Command, " "); cmd != "sh -c export A='1'; top" {
+		t.Errorf("unexpected command %q", cmd)
+	}
+	sc := ec.SecurityContext
+	if sc == nil || *sc.RunAsUser != 1000 || !*sc.Privileged {
+		t.Errorf("unexpected security context %+v", sc)
+	}
+	if o := debugOptions(c.Exec, "busybox"); o != "debug busybox, uid 1000, env A=1, privileged" {
+		t.Errorf("unexpected options %q", o)
+	}
+
+	c.Exec = types.ExecOptions{User: "nobody"}
+	if _, err := debugContainer(c, "busybox"); err == nil {
+		t.Error("expect error of the user name")
+	}
+
+	cl := &cluster{}
+	if _, err := cl.debug(nil, c, ""); err == nil {
+		t.Error("expect error of no debug image")
+	}
+}
@@ -0,0 +1,138 @@
+package kube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/remotecommand"
+
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+var userPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// execUser is the user to switch to, and how
+type execUser struct {
+	name, uid, gid string
+	switcher       string // su or setpriv
+}
+
+// run executes the command in the container and returns the outputs
+func (cl *cluster) run(ctx context.Context, c types.Container, cmds ...string) (string, error) {
+	req := cl.cli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(c.PodName).
+		Namespace(c.Namespace).
+		SubResource("exec").
+		Param("container", c.ContainerName).
+		Param("stdout", "true").
+		Param("stdin", "false").
+		Param("tty", "false")
+	for _, cmd := range cmds {
+		req.Param("command", cmd)
+	}
+
+	logrus.Debugf("POST to %s", req.URL())
+	exec, err := remotecommand.NewSPDYExecutor(cl.config, "POST", req.URL())
+	if err != nil {
+		return "", err
+	}
+	stdout := new(bytes.Buffer)
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: stdout,
+		Tty:    false,
+	})
+	return stdout.String(), err
+}
+
+// lookupUser finds the user in the container and the way to switch to it
+func (cl *cluster) lookupUser(ctx context.Context, c types.Container, user string) (*execUser, error) {
+	if !userPattern.MatchString(user) {
+		return nil, fmt.Errorf("bad user %q, the kube backend only supports "+
+			"a user name or uid", user)
+	}
+
+	output, err := cl.run(ctx, c, c.Shell, "-c",
+		fmt.Sprintf("id -un '%s' && id -u '%s' && id -g '%s'", user, user, user))
+	ids := strings.Fields(output)
+	if err != nil || len(ids) != 3 {
+		return nil, fmt.Errorf("user %s not found in container %s", user, c.Name)
+	}
+	u := &execUser{name: ids[0], uid: ids[1], gid: ids[2]}
+
+	// prefer su, which sets up a login environment
+	output, err = cl.run(ctx, c, c.Shell, "-c", "command -v su || command -v setpriv")
+	if err != nil || strings.TrimSpace(output) == "" {
+		return nil, fmt.Errorf("cannot exec as user %s, neither su nor setpriv "+
+			"is found in container %s", user, c.Name)
+	}
+	u.switcher = path.Base(strings.TrimSpace(output))
+	return u, nil
+}
+
+// execCommand builds the command with the env and the user applied,
+// the env is quoted and exported by the shell, so `env` is not required
+func execCommand(shell string, opts types.ExecOptions, u *execUser) ([]string, error) {
+	if len(opts.Args) != 0 {
+		if opts.Env == "" && u == nil {
+			return opts.Args, nil
+		}
+		// the env and the user need a shell
+		opts.Cmd = "exec " + util.ShellQuote(opts.Args)
+	}
+	script := opts.Cmd
+	if opts.Env != "" {
+		envs, err := util.ShellEnv(opts.Env)
+		if err != nil {
+			return nil, err
+		}
+		if script == "" {
+			script = "exec " + shell + " -l"
+		}
+		script = "export " + envs + "; " + script
+	}
+
+	if u == nil {
+		if script == "" {
+			return []string{shell, "-l"}, nil
+		}
+		return []string{shell, "-c", script}, nil
+	}
+
+	switch u.switcher {
+	case "su":
+		cmds := []string{"su", "-s", shell, "-", u.name}
+		if script != "" {
+			cmds = append(cmds, "-c", script)
+		}
+		return cmds, nil
+	case "setpriv":
+		cmds := []string{"setpriv", "--reuid=" + u.uid, "--regid=" + u.gid, "--init-groups", shell}
+		if script == "" {
+			return append(cmds, "-l"), nil
+		}
+		return append(cmds, "-c", script), nil
+	}
+	return nil, fmt.Errorf("unknown user switcher %s", u.switcher)
+}
+
+// effectiveOptions describes the options took effect
+func effectiveOptions(opts types.ExecOptions, u *execUser) string {
+	options := []string{}
+	if u != nil {
+		options = append(options, fmt.Sprintf("user %s via %s", u.name, u.switcher))
+	}
+	if opts.Env != "" {
+		options = append(options, "env "+strings.Join(strings.Fields(opts.Env), " "))
+	}
+	if opts.Privileged {
+		options = append(options, "privileged")
+	}
+	return strings.Join(options, ", ")
+}
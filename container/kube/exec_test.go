@@ -0,0 +1,60 @@
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestExecCommand(t *testing.T) {
+	su := &execUser{name: "nobody", uid: "65534", gid: "65534", switcher: "su"}
+	setpriv := &execUser{name: "nobody", uid: "65534", gid: "65534", switcher: "setpriv"}
+
+	tests := []struct {
+		opts   types.ExecOptions
+		user   *execUser
+		expect string
+	}{
+		{types.ExecOptions{}, nil, "/bin/sh -l"},
+		{types.ExecOptions{Cmd: "top"}, nil, "/bin/sh -c top"},
+		{types.ExecOptions{Env: "A=1 B=2"}, nil,
+			"/bin/sh -c export A='1' B='2'; exec /bin/sh -l"},
+		{types.ExecOptions{Env: "A=1", Cmd: "top"}, nil,
+			"/bin/sh -c export A='1'; top"},
+		{types.ExecOptions{User: "nobody"}, su,
+			"su -s /bin/sh - nobody"},
+		{types.ExecOptions{User: "nobody", Env: "A=1", Cmd: "top"}, su,
+			"su -s /bin/sh - nobody -c export A='1'; top"},
+		{types.ExecOptions{User: "nobody"}, setpriv,
+			"setpriv --reuid=65534 --regid=65534 --init-groups /bin/sh -l"},
+		{types.ExecOptions{User: "nobody", Cmd: "top"}, setpriv,
+			"setpriv --reuid=65534 --regid=65534 --init-groups /bin/sh -c top"},
+		{types.ExecOptions{Args: []string{"echo", "a b"}}, nil, "echo a b"},
+		{types.ExecOptions{Args: []string{"echo", "a b"}, Env: "A=1"}, nil,
+			"/bin/sh -c export A='1'; exec 'echo' 'a b'"},
+		{types.ExecOptions{Args: []string{"echo"}}, su,
+			"su -s /bin/sh - nobody -c exec 'echo'"},
+		{types.ExecOptions{Env: "A=$(id) B=1;reboot C=a b", Cmd: "top"}, nil,
+			"/bin/sh -c export A='$(id)' B='1;reboot' C='a b'; top"},
+	}
+	for _, tt := range tests {
+		cmds, err := execCommand("/bin/sh", tt.opts, tt.user)
+		if err != nil {
+			t.Errorf("%+v: %s", tt.opts, err)
+			continue
+		}
+		if got := strings.Join(cmds, " "); got != tt.expect {
+			t.Errorf("%+v: expect %q, got %q", tt.opts, tt.expect, got)
+		}
+	}
+
+	if _, err := execCommand("/bin/sh", types.ExecOptions{Env: "$(reboot) A=1"}, nil); err == nil {
+		t.Error("expect error of the bad env")
+	}
+
+	options := effectiveOptions(types.ExecOptions{User: "nobody", Env: "A=1"}, su)
+	if options != "user nobody via su, env A=1" {
+		t.Errorf("unexpected options %q", options)
+	}
+}
@@ -0,0 +1,94 @@
+package kube
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/wrfly/container-web-tty/config"
+)
+
+// listFilter restricts the pods to list, the selectors are applied
+// by the API server, only the readiness of containers is checked here
+type listFilter struct {
+	namespaces    []string // empty means all namespaces
+	exclude       []string
+	labelSelector string
+	fieldSelector string
+	onlyReady     bool
+}
+
+func newListFilter(conf config.KubeConfig) (listFilter, error) {
+	if _, err := labels.Parse(conf.LabelSelector); err != nil {
+		return listFilter{}, fmt.Errorf("bad label selector: %s", err)
+	}
+	if _, err := fields.ParseSelector(conf.FieldSelector); err != nil {
+		return listFilter{}, fmt.Errorf("bad field selector: %s", err)
+	}
+
+	excluded := make(map[string]bool, len(conf.ExcludeNamespaces))
+	for _, ns := range conf.ExcludeNamespaces {
+		excluded[ns] = true
+	}
+	f := listFilter{
+		labelSelector: conf.LabelSelector,
+		fieldSelector: conf.FieldSelector,
+		onlyReady:     conf.OnlyReady,
+	}
+	for _, ns := range conf.Namespaces {
+		if !excluded[ns] {
+			f.namespaces = append(f.namespaces, ns)
+		}
+	}
+	if len(conf.Namespaces) != 0 && len(f.namespaces) == 0 {
+		return listFilter{}, fmt.Errorf("all the namespaces %v are excluded", conf.Namespaces)
+	}
+	// the excluded namespaces only matter when listing all of them
+	if len(f.namespaces) == 0 {
+		f.exclude = conf.ExcludeNamespaces
+	}
+	return f, nil
+}
+
+// namespaceList returns the namespaces to list, "" means all
+func (f listFilter) namespaceList() []string {
+	if len(f.namespaces) == 0 {
+		return []string{""}
+	}
+	return f.namespaces
+}
+
+func (f listFilter) excludeSelectors() []string {
+	selectors := []string{}
+	for _, ns := range f.exclude {
+		selectors = append(selectors, "metadata.namespace!="+ns)
+	}
+	return selectors
+}
+
+// podOptions returns the options to list the pods
+func (f listFilter) podOptions() metav1.ListOptions {
+	selectors := f.excludeSelectors()
+	if f.fieldSelector != "" {
+		selectors = append(selectors, f.fieldSelector)
+	}
+	if f.onlyReady {
+		selectors = append(selectors, "status.phase=Running")
+	}
+	return metav1.ListOptions{
+		LabelSelector: f.labelSelector,
+		FieldSelector: strings.Join(selectors, ","),
+	}
+}
+
+// workloadOptions returns the options to list the workloads, the pod
+// selectors don't apply to them
+func (f listFilter) workloadOptions(labelSelector string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: labelSelector,
+		FieldSelector: strings.Join(f.excludeSelectors(), ","),
+	}
+}
@@ -0,0 +1,87 @@
+package kube
+
+import (
+	"context"
+	"testing"
+
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/wrfly/container-web-tty/config"
+)
+
+func TestListFilter(t *testing.T) {
+	if _, err := newListFilter(config.KubeConfig{LabelSelector: "app in (nginx"}); err == nil {
+		t.Error("expect error of the bad label selector")
+	}
+	if _, err := newListFilter(config.KubeConfig{FieldSelector: "spec.nodeName"}); err == nil {
+		t.Error("expect error of the bad field selector")
+	}
+	if _, err := newListFilter(config.KubeConfig{
+		Namespaces:        []string{"kube-system"},
+		ExcludeNamespaces: []string{"kube-system"},
+	}); err == nil {
+		t.Error("expect error of excluding all the namespaces")
+	}
+
+	t.Run("all namespaces", func(t *testing.T) {
+		f, err := newListFilter(config.KubeConfig{
+			ExcludeNamespaces: []string{"kube-system"},
+			LabelSelector:     "app=nginx",
+			FieldSelector:     "spec.nodeName=node1",
+			OnlyReady:         true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, clientset := newTestCli(t, f, testPod("nginx", "aaaaaaaaaaaaaaaa", nil))
+
+		var list k8stesting.ListAction
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				list = action.(k8stesting.ListAction)
+			}
+		}
+		restrictions := list.GetListRestrictions()
+		if ns := list.GetNamespace(); ns != "" {
+			t.Errorf("unexpected namespace %q", ns)
+		}
+		if l := restrictions.Labels.String(); l != "app=nginx" {
+			t.Errorf("unexpected label selector %q", l)
+		}
+		expect := "metadata.namespace!=kube-system,spec.nodeName=node1,status.phase=Running"
+		if fs := restrictions.Fields.String(); fs != expect {
+			t.Errorf("unexpected field selector %q", fs)
+		}
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		f, err := newListFilter(config.KubeConfig{
+			Namespaces:        []string{"web", "db", "kube-system"},
+			ExcludeNamespaces: []string{"kube-system"},
+			OnlyReady:         true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pod := testPod("nginx", "aaaaaaaaaaaaaaaa", nil)
+		pod.Namespace = "web"
+		notReady := testPod("redis", "bbbbbbbbbbbbbbbb", nil)
+		notReady.Namespace = "db"
+		notReady.Status.ContainerStatuses[0].Ready = false
+		kube, clientset := newTestCli(t, f, pod, notReady)
+
+		containers := kube.List(context.Background())
+		if len(containers) != 1 || containers[0].PodName != "nginx" {
+			t.Errorf("unexpected containers %+v", containers)
+		}
+		namespaces := []string{}
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				namespaces = append(namespaces, action.GetNamespace())
+			}
+		}
+		if len(namespaces) != 2 || namespaces[0] != "web" || namespaces[1] != "db" {
+			t.Errorf("unexpected namespaces %v", namespaces)
+		}
+	})
+}
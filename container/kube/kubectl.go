@@ -3,15 +3,21 @@ package kube
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	api "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
@@ -20,65 +26,189 @@ import (
 
 	"github.com/wrfly/container-web-tty/config"
 	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
 )
 
+// cacheSyncTimeout is how long to wait for the cache at start,
+// it keeps syncing in background after that
+const cacheSyncTimeout = time.Second * 30
+
+// errNoSplitStreams is returned if the stream of the logs is selected, but
+// the kube API would ignore it and return both
+var errNoSplitStreams = errors.New("the kube API cannot select the stream " +
+	"of the logs, the PodLogsQuerySplitStreams feature gate is not enabled")
+
+// cluster is a kube cluster, its containers are tagged with the name
+type cluster struct {
+	name   string // the context name, empty if no context configured
+	cli    kubernetes.Interface
+	config *restclient.Config
+	cache  *podCache
+
+	m            sync.Mutex
+	splitStreams *bool // whether the stream of the logs can be selected
+}
+
+func newCluster(name string, clientset kubernetes.Interface,
+	kubeConfig *restclient.Config, filter listFilter) *cluster {
+	return &cluster{
+		name:   name,
+		cli:    clientset,
+		config: kubeConfig,
+		cache:  newPodCache(name, clientset, filter),
+	}
+}
+
+// KubeCli serves the containers of one or more clusters
 type KubeCli struct {
-	cli        *kubernetes.Clientset
-	config     *restclient.Config
+	clusters   []*cluster
 	containers *types.Containers
+	debugImage string
 }
 
 func NewCli(conf config.KubeConfig) (*KubeCli, error) {
-	// use the current context in kubeconfig
-	kubeConfig, err := clientcmd.BuildConfigFromFlags("", conf.ConfigPath)
+	filter, err := newListFilter(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	// create the clientset
-	clientset, err := kubernetes.NewForConfig(kubeConfig)
+	names, kubeConfigs, err := loadConfigs(conf)
 	if err != nil {
 		return nil, err
 	}
 
-	// get namespaces
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-	namespaceList, err := clientset.CoreV1().Namespaces().List(ctx,
-		metav1.ListOptions{})
+	k := &KubeCli{containers: &types.Containers{}, debugImage: conf.DebugImage}
+	for i, kubeConfig := range kubeConfigs {
+		// create the clientset
+		clientset, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+		clusterFilter, err := checkPermissions(ctx, clientset, filter)
+		cancel()
+		if err != nil {
+			// the other clusters are kept, the cache syncs once it's fixed
+			if len(kubeConfigs) == 1 {
+				return nil, err
+			}
+			logrus.Errorf("check cluster %s error: %s", names[i], err)
+		}
+
+		ns := "all"
+		if len(clusterFilter.namespaces) != 0 {
+			ns = strings.Join(clusterFilter.namespaces, ",")
+		}
+		logrus.Infof("New kube client: cluster [%s], host [%s], namespaces [%s]",
+			names[i], kubeConfig.Host, ns)
+
+		k.clusters = append(k.clusters, newCluster(names[i], clientset, kubeConfig, clusterFilter))
+	}
+	for _, cl := range k.clusters {
+		cl.cache.waitForSync(cacheSyncTimeout)
+	}
+
+	return k, nil
+}
+
+// loadConfigs loads the configs of the contexts, the current context is
+// used if there is no context configured, "all" means all the contexts
+func loadConfigs(conf config.KubeConfig) ([]string, []*restclient.Config, error) {
+	paths := filepath.SplitList(conf.ConfigPath)
+	if len(conf.Contexts) == 0 && len(paths) <= 1 {
+		// running in a pod without kubeconfig, use the service account
+		if _, err := os.Stat(conf.ConfigPath); conf.ConfigPath == "" || os.IsNotExist(err) {
+			kubeConfig, err := restclient.InClusterConfig()
+			if err == nil {
+				logrus.Infof("kube config [%s] not found, use the in-cluster config",
+					conf.ConfigPath)
+				return []string{""}, []*restclient.Config{kubeConfig}, nil
+			}
+			logrus.Debugf("in-cluster config: %s", err)
+		}
+
+		// use the current context in kubeconfig
+		kubeConfig, err := clientcmd.BuildConfigFromFlags("", conf.ConfigPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{""}, []*restclient.Config{kubeConfig}, nil
+	}
+
+	rules := &clientcmd.ClientConfigLoadingRules{Precedence: paths}
+	raw, err := rules.Load()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	ns := []string{}
-	for _, namespace := range namespaceList.Items {
-		ns = append(ns, namespace.Name)
+	contexts := conf.Contexts
+	switch {
+	case len(contexts) == 0:
+		contexts = []string{raw.CurrentContext}
+	case len(contexts) == 1 && contexts[0] == "all":
+		contexts = []string{}
+		for name := range raw.Contexts {
+			contexts = append(contexts, name)
+		}
+		sort.Strings(contexts)
 	}
-	logrus.Infof("New kube client: host [%s], namespaces [%s]",
-		kubeConfig.Host, strings.Join(ns, ","))
 
-	k := &KubeCli{
-		cli:        clientset,
-		containers: &types.Containers{},
-		config:     kubeConfig,
+	names := []string{}
+	kubeConfigs := []*restclient.Config{}
+	for _, name := range contexts {
+		if _, exist := raw.Contexts[name]; !exist {
+			return nil, nil, fmt.Errorf("context %s not found in %s", name, conf.ConfigPath)
+		}
+		kubeConfig, err := clientcmd.NewNonInteractiveClientConfig(*raw, name,
+			&clientcmd.ConfigOverrides{}, rules).ClientConfig()
+		if err != nil {
+			return nil, nil, fmt.Errorf("load context %s error: %s", name, err)
+		}
+		// only tag the containers when the contexts are configured
+		if len(conf.Contexts) == 0 {
+			name = ""
+		}
+		names = append(names, name)
+		kubeConfigs = append(kubeConfigs, kubeConfig)
 	}
-	k.List(context.Background())
+	return names, kubeConfigs, nil
+}
 
-	return k, nil
+// clusterOf returns the cluster of the container
+func (kube KubeCli) clusterOf(c types.Container) (*cluster, error) {
+	for _, cl := range kube.clusters {
+		if cl.name == c.Cluster {
+			return cl, nil
+		}
+	}
+	return nil, fmt.Errorf("cluster %s not found", c.Cluster)
 }
 
-func (kube KubeCli) GetInfo(ctx context.Context, cid string) types.Container {
-	if kube.containers.Len() == 0 {
-		logrus.Debugf("zero containers, get cid %s", cid)
-		kube.List(ctx)
+// lookup finds the container in the caches of the clusters, the whole
+// list is not rebuilt, the detected shell is kept
+func (kube KubeCli) lookup(cid string) types.Container {
+	for _, cl := range kube.clusters {
+		c := cl.cache.find(cid)
+		if c.ID == "" {
+			continue
+		}
+		if old := kube.containers.Find(c.ID); old.ID == c.ID {
+			c.Shell = old.Shell
+		}
+		kube.containers.Put(c)
+		return c
 	}
+	return types.Container{}
+}
 
-	// find in containers
+func (kube KubeCli) GetInfo(ctx context.Context, cid string) types.Container {
 	logrus.Debugf("find cid: %s", cid)
-	container := kube.containers.Find(cid)
+	container := kube.lookup(cid)
 	if container.ID != "" {
-		if container.Shell == "" {
-			shell := kube.getShell(ctx, cid)
-			kube.containers.SetShell(cid, shell)
+		// no shell for the stopped workloads
+		if container.Shell == "" && container.PodName != "" {
+			shell := kube.getShell(ctx, container.ID)
+			kube.containers.SetShell(container.ID, shell)
 			container.Shell = shell
 		}
 		return container
@@ -105,102 +235,122 @@ func containerStartTime(state v1.ContainerState) time.Duration {
 	return time.Since(state.Running.StartedAt.Time).Round(time.Second)
 }
 
-func (kube KubeCli) List(ctx context.Context) []types.Container {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
-	defer cancel()
-	pods, err := kube.cli.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		logrus.Errorf("kubectl list pods error: %s", err)
-		return nil
-	}
-
+// podContainers converts the containers of the pod
+func podContainers(pod *v1.Pod, onlyReady bool) []types.Container {
 	containers := []types.Container{}
+	// map key is name
+	containerMap := make(map[string]types.Container, 0)
 
-	for _, pod := range pods.Items {
-		// map key is name
-		containerMap := make(map[string]types.Container, 0)
-
-		spec := pod.Spec
-		status := pod.Status
+	spec := pod.Spec
+	status := pod.Status
 
-		podIP := pod.Status.PodIP
-		hostIP := pod.Status.HostIP
-		podState := string(status.Phase)
+	podIP := pod.Status.PodIP
+	hostIP := pod.Status.HostIP
+	podState := string(status.Phase)
 
-		// spec
-		for _, container := range spec.Containers {
-			c := types.Container{
-				Command: strings.Join(container.Command, " "),
-				Image:   container.Image,
-			}
-			containerMap[container.Name] = c
+	// spec
+	for _, container := range spec.Containers {
+		c := types.Container{
+			Command: strings.Join(container.Command, " "),
+			Image:   container.Image,
 		}
+		containerMap[container.Name] = c
+	}
 
-		// status
-		for _, container := range status.ContainerStatuses {
-			id := trimContainerIDPrefix(container.ContainerID)
-			if id == "" {
-				continue
-			}
-			c := types.Container{
-				ID:            id,
-				PodName:       pod.GetName(),
-				ContainerName: container.Name,
-				Namespace:     pod.GetNamespace(),
-				Name:          container.Name,
-				State:         fmt.Sprintf("%s / %s", containerReady(container.Ready), podState),
-				Status:        fmt.Sprintf("age: %s; restart %d", containerStartTime(container.State), container.RestartCount),
-				IPs: func() []string {
-					if podIP != hostIP {
-						return []string{podIP, hostIP}
-					}
-					return []string{hostIP}
-				}(),
-				Image:   containerMap[container.Name].Image,
-				Command: containerMap[container.Name].Command,
-			}
-			logrus.Debugf("get container: %+v\n", c)
-			containers = append(containers, c)
+	// status
+	for _, container := range status.ContainerStatuses {
+		id := trimContainerIDPrefix(container.ContainerID)
+		if id == "" || (onlyReady && !container.Ready) {
+			continue
 		}
+		c := types.Container{
+			ID:            id,
+			PodName:       pod.GetName(),
+			ContainerName: container.Name,
+			Namespace:     pod.GetNamespace(),
+			Name:          container.Name,
+			State:         fmt.Sprintf("%s / %s", containerReady(container.Ready), podState),
+			Status:        fmt.Sprintf("age: %s; restart %d", containerStartTime(container.State), container.RestartCount),
+			IPs: func() []string {
+				if podIP != hostIP {
+					return []string{podIP, hostIP}
+				}
+				return []string{hostIP}
+			}(),
+			Image:   containerMap[container.Name].Image,
+			Command: containerMap[container.Name].Command,
+		}
+		containers = append(containers, c)
 	}
+	return containers
+}
 
-	kube.containers.Set(containers)
+// List returns the containers in the caches of all the clusters
+func (kube KubeCli) List(ctx context.Context) []types.Container {
+	containers := []types.Container{}
+	for _, cl := range kube.clusters {
+		containers = append(containers, cl.cache.list()...)
+	}
 
+	// keep the detected shells
+	for i, c := range containers {
+		if old := kube.containers.Find(c.ID); old.ID == c.ID {
+			containers[i].Shell = old.Shell
+		}
+	}
+	kube.containers.Set(containers)
 	return containers
 }
 
+// Health reports whether the caches are synced
+func (kube KubeCli) Health() error {
+	for _, cl := range kube.clusters {
+		if err := cl.cache.health(); err != nil {
+			if cl.name != "" {
+				return fmt.Errorf("cluster %s: %s", cl.name, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Watch returns the changes of the containers of all the clusters
+// until the context is done
+func (kube KubeCli) Watch(ctx context.Context) <-chan types.ContainerEvent {
+	out := make(chan types.ContainerEvent, 64)
+	wg := sync.WaitGroup{}
+	for _, cl := range kube.clusters {
+		wg.Add(1)
+		go func(events <-chan types.ContainerEvent) {
+			defer wg.Done()
+			for event := range events {
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(cl.cache.hub.Subscribe(ctx))
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
 func (kube KubeCli) exist(ctx context.Context, containerID, path string) bool {
 	info := kube.containers.Find(containerID)
 	if info.ID == "" {
 		return false
 	}
-
-	restClient := kube.cli.CoreV1().RESTClient()
-
-	req := restClient.Post().
-		Resource("pods").
-		Name(info.PodName).
-		Namespace(info.Namespace).
-		SubResource("exec").
-		Param("container", info.ContainerName).
-		Param("command", "ls").
-		Param("command", path).
-		Param("stdout", "true").
-		Param("stdin", "false").
-		Param("tty", "false")
-
-	logrus.Debugf("POST to %s", req.URL())
-	exec, err := remotecommand.NewSPDYExecutor(kube.config, "POST", req.URL())
+	cl, err := kube.clusterOf(info)
 	if err != nil {
-		logrus.Errorf("exist exec: setup executor error: [%v]", err)
 		return false
 	}
 
-	err = exec.Stream(remotecommand.StreamOptions{
-		Stdout: new(bytes.Buffer),
-		Tty:    false,
-	})
-	if err != nil {
+	if _, err := cl.run(ctx, info, "ls", path); err != nil {
 		logrus.Debugf("exist exec error: [%v]", err)
 		return false
 	}
@@ -222,16 +372,15 @@ func (kube KubeCli) getShell(ctx context.Context, cid string) string {
 	return ""
 }
 
-func (kube KubeCli) Start(ctx context.Context, cid string) error {
-	return nil
-}
-
-func (kube KubeCli) Stop(ctx context.Context, cid string) error {
-	return nil
-}
-
-func (kube KubeCli) Restart(ctx context.Context, cid string) error {
-	return nil
+// privileged returns whether the container runs in privileged mode
+func privileged(pod *v1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			sc := container.SecurityContext
+			return sc != nil && sc.Privileged != nil && *sc.Privileged
+		}
+	}
+	return false
 }
 
 func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
@@ -239,9 +388,13 @@ func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 	if c.PodName == "" || c.Namespace == "" {
 		return nil, fmt.Errorf("PodName or Namespace is empty")
 	}
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return nil, err
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
-	pod, err := kube.cli.CoreV1().Pods(c.Namespace).
+	pod, err := cl.cli.CoreV1().Pods(c.Namespace).
 		Get(ctx, c.PodName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
@@ -252,13 +405,27 @@ func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 				pod.Status.Phase)
 	}
 
-	cmds := []string{c.Shell, "-l"}
-	if opts := c.Exec; opts.Cmd != "" {
-		cmds = []string{c.Shell, "-l", opts.Cmd}
+	opts := c.Exec
+	if opts.Debug {
+		return cl.debug(pod, c, kube.debugImage)
+	}
+	if opts.Privileged && !privileged(pod, c.ContainerName) {
+		return nil, fmt.Errorf("privileged exec is not supported by the kube backend, " +
+			"set the securityContext of the container instead")
+	}
+	var u *execUser
+	if opts.User != "" {
+		if u, err = cl.lookupUser(ctx, c, opts.User); err != nil {
+			return nil, err
+		}
+	}
+	cmds, err := execCommand(c.Shell, opts, u)
+	if err != nil {
+		return nil, err
 	}
 	logrus.Debugf("exec with cmd: %v", cmds)
 
-	restClient := kube.cli.CoreV1().RESTClient()
+	restClient := cl.cli.CoreV1().RESTClient()
 	req := restClient.Post().
 		Resource("pods").
 		Name(c.PodName).
@@ -268,7 +435,6 @@ func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 		Param("stdin", "true").
 		Param("stdout", "true").
 		Param("tty", "true")
-	// TODO: k8s exec user & env
 
 	// set commands
 	for _, cmd := range cmds {
@@ -276,15 +442,25 @@ func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 	}
 
 	enj := newInjector(ctx)
+	enj.execOptions = effectiveOptions(opts, u)
+	if err := cl.stream(req, &enj); err != nil {
+		return nil, err
+	}
 
+	logrus.Debug("return enj")
+	return &enj, nil
+}
+
+// stream connects the TTY of the request to the injector in background
+func (cl *cluster) stream(req *restclient.Request, enj *execInjector) error {
 	logrus.Debugf("POST to %s", req.URL())
-	exec, err := remotecommand.NewSPDYExecutor(kube.config, "POST", req.URL())
+	exec, err := remotecommand.NewSPDYExecutor(cl.config, "POST", req.URL())
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	go func() {
-		err = exec.Stream(remotecommand.StreamOptions{
+		err := exec.Stream(remotecommand.StreamOptions{
 			Stdin:             enj.ttyIn,
 			Stdout:            enj.ttyOut,
 			Tty:               true,
@@ -298,13 +474,60 @@ func (kube KubeCli) Exec(ctx context.Context, c types.Container) (types.TTY, err
 		enj.ttyIn.Close()
 		enj.ttyOut.Close()
 	}()
+	return nil
+}
 
-	logrus.Debug("return enj")
+// Attach attaches to the main process of the container, the container
+// must have stdin and tty enabled
+func (kube KubeCli) Attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	logrus.Debugf("attach pod: %v", c)
+	if c.PodName == "" || c.Namespace == "" {
+		return nil, fmt.Errorf("PodName or Namespace is empty")
+	}
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	pod, err := cl.cli.CoreV1().Pods(c.Namespace).
+		Get(ctx, c.PodName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != api.PodRunning {
+		return nil, fmt.Errorf("cannot attach to a container in a %s pod", pod.Status.Phase)
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == c.ContainerName && !(container.Stdin && container.TTY) {
+			return nil, fmt.Errorf("cannot attach to container %s, "+
+				"stdin and tty are not enabled in the spec", c.ContainerName)
+		}
+	}
+
+	req := cl.cli.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(c.PodName).
+		Namespace(c.Namespace).
+		SubResource("attach").
+		Param("container", c.ContainerName).
+		Param("stdin", "true").
+		Param("stdout", "true").
+		Param("tty", "true")
+
+	enj := newInjector(ctx)
+	enj.execOptions = "attached"
+	enj.attached = true
+	if err := cl.stream(req, &enj); err != nil {
+		return nil, err
+	}
 	return &enj, nil
 }
 
 func (kube KubeCli) Close() error {
-	// no need to close
+	for _, cl := range kube.clusters {
+		cl.cache.close()
+	}
 	return nil
 }
 
@@ -315,10 +538,14 @@ func (kube KubeCli) Logs(ctx context.Context,
 	if c.PodName == "" || c.Namespace == "" {
 		return nil, fmt.Errorf("PodName or Namespace is empty")
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return nil, err
+	}
+	getCtx, cancel := context.WithTimeout(ctx, time.Second*3)
 	defer cancel()
-	pod, err := kube.cli.CoreV1().Pods(c.Namespace).
-		Get(ctx, c.PodName, metav1.GetOptions{})
+	pod, err := cl.cli.CoreV1().Pods(c.Namespace).
+		Get(getCtx, c.PodName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -328,27 +555,115 @@ func (kube KubeCli) Logs(ctx context.Context,
 				pod.Status.Phase)
 	}
 
-	req := kube.cli.CoreV1().RESTClient().Get().
-		Namespace(c.Namespace).
-		Name(c.PodName).
-		Resource("pods").
-		SubResource("log").
-		Param("follow", strconv.FormatBool(opts.Follow)).
-		Param("container", c.ContainerName).
-		Param("tailLines", opts.Tail)
-
-	// Param("previous", strconv.FormatBool(logOptions.Previous)).
-	// Param("timestamps", strconv.FormatBool(logOptions.Timestamps))
-
-	// if logOptions.SinceSeconds != nil {
-	// 	req.Param("sinceSeconds", strconv.FormatInt(*logOptions.SinceSeconds, 10))
-	// }
-	// if logOptions.SinceTime != nil {
-	// 	req.Param("sinceTime", logOptions.SinceTime.Format(time.RFC3339))
-	// }
-	// if logOptions.LimitBytes != nil {
-	// 	req.Param("limitBytes", strconv.FormatInt(*logOptions.LimitBytes, 10))
-	// }
-
-	return req.Stream(ctx)
+	var stream string
+	switch opts.Stream {
+	case "":
+	case "stdout":
+		stream = api.LogStreamStdout
+	case "stderr":
+		stream = api.LogStreamStderr
+	default:
+		return nil, fmt.Errorf("unknown stream %s", opts.Stream)
+	}
+	if stream != "" {
+		if err := cl.checkStreams(getCtx, c); err != nil {
+			return nil, err
+		}
+	}
+
+	logRequest := func(follow, timestamps bool, tail int, since time.Time) *restclient.Request {
+		req := cl.cli.CoreV1().RESTClient().Get().
+			Namespace(c.Namespace).
+			Name(c.PodName).
+			Resource("pods").
+			SubResource("log").
+			Param("follow", strconv.FormatBool(follow)).
+			Param("container", c.ContainerName).
+			Param("timestamps", strconv.FormatBool(timestamps))
+		if stream != "" {
+			req.Param("stream", stream)
+		}
+		if tail >= 0 {
+			req.Param("tailLines", strconv.Itoa(tail))
+		}
+		if !since.IsZero() {
+			req.Param("sinceTime", since.Format(time.RFC3339))
+		}
+		return req
+	}
+
+	tail := util.ParseTail(opts.Tail)
+	if stream == "" || tail < 0 {
+		rc, err := logRequest(opts.Follow, opts.Timestamps || !opts.Until.IsZero(),
+			tail, opts.Since).Stream(ctx)
+		if err != nil || opts.Until.IsZero() {
+			return rc, err
+		}
+		return newUntilReader(rc, opts.Until, opts.Timestamps), nil
+	}
+
+	// the kube API cannot tail a stream, the stream is tailed here and
+	// followed from the last line, the timestamps tell where it is
+	rc, err := logRequest(false, true, -1, opts.Since).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lines, last, err := tailLines(rc, tail)
+	rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	logs := struct {
+		io.Reader
+		io.Closer
+	}{bytes.NewReader(lines), io.NopCloser(nil)}
+	if opts.Follow {
+		since := opts.Since
+		if !last.IsZero() {
+			// the sinceTime is of seconds, the sent lines are skipped
+			since = last
+		}
+		rc, err := logRequest(true, true, -1, since).Stream(ctx)
+		if err != nil {
+			return nil, err
+		}
+		follow := newUntilReader(rc, time.Time{}, true)
+		follow.after = last
+		logs.Reader, logs.Closer = io.MultiReader(logs.Reader, follow), follow
+	}
+	return newUntilReader(logs, opts.Until, opts.Timestamps), nil
+}
+
+// checkStreams checks whether the kube API can select the stream of the
+// logs, which needs the PodLogsQuerySplitStreams feature gate. The gate
+// can't be read, but only with it the stream and the tailLines are
+// rejected together, without it the stream is ignored silently
+func (cl *cluster) checkStreams(ctx context.Context, c types.Container) error {
+	cl.m.Lock()
+	defer cl.m.Unlock()
+	if cl.splitStreams != nil {
+		if !*cl.splitStreams {
+			return errNoSplitStreams
+		}
+		return nil
+	}
+
+	stream, tail := api.LogStreamStderr, int64(0)
+	err := cl.cli.CoreV1().Pods(c.Namespace).GetLogs(c.PodName, &api.PodLogOptions{
+		Container: c.ContainerName,
+		Stream:    &stream,
+		TailLines: &tail,
+	}).Do(ctx).Error()
+	switch {
+	case apierrors.IsInvalid(err):
+		enabled := true
+		cl.splitStreams = &enabled
+		return nil
+	case err == nil:
+		disabled := false
+		cl.splitStreams = &disabled
+		return errNoSplitStreams
+	default:
+		return err
+	}
 }
@@ -2,11 +2,20 @@ package kube
 
 import (
 	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/wrfly/container-web-tty/config"
 	"github.com/wrfly/container-web-tty/types"
@@ -58,3 +67,211 @@ func TestKubeCli(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestClusters(t *testing.T) {
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	prod, prodClientset := newTestCluster(t, "prod", listFilter{},
+		testPod("nginx", "aaaaaaaaaaaaaaaa", controllerRef("Deployment", "web")), deployment)
+	dev, devClientset := newTestCluster(t, "dev", listFilter{},
+		testPod("redis", "bbbbbbbbbbbbbbbb", controllerRef("Deployment", "web")), deployment)
+	kube := &KubeCli{clusters: []*cluster{prod, dev}, containers: &types.Containers{}}
+	ctx := context.Background()
+
+	containers := kube.List(ctx)
+	if len(containers) != 2 {
+		t.Fatalf("unexpected containers %+v", containers)
+	}
+	if c := containers[0]; c.PodName != "nginx" || c.Cluster != "prod" {
+		t.Errorf("unexpected container %+v", c)
+	}
+	if c := containers[1]; c.PodName != "redis" || c.Cluster != "dev" {
+		t.Errorf("unexpected container %+v", c)
+	}
+
+	// the action goes to the cluster of the container
+	if err := kube.Stop(ctx, "bbbbbbbbbbbb"); err != nil {
+		t.Fatal(err)
+	}
+	d, _ := devClientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if *d.Spec.Replicas != 0 {
+		t.Errorf("dev deployment is not stopped")
+	}
+	p, _ := prodClientset.AppsV1().Deployments("default").Get(ctx, "web", metav1.GetOptions{})
+	if *p.Spec.Replicas != 1 {
+		t.Errorf("prod deployment is stopped")
+	}
+
+	if _, err := kube.clusterOf(types.Container{Cluster: "test"}); err == nil {
+		t.Error("expect error of the unknown cluster")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	kube, clientset := newTestCli(t, listFilter{},
+		testPod("nginx", "aaaaaaaaaaaaaaaa", nil))
+	ctx := context.Background()
+
+	if c := kube.lookup("aaaaaaaaaaaa"); c.PodName != "nginx" {
+		t.Fatalf("unexpected container %+v", c)
+	}
+	kube.containers.SetShell("aaaaaaaaaaaaaaaa", "/bin/sh")
+
+	// the new pod is found without listing
+	_, err := clientset.CoreV1().Pods("default").Create(ctx,
+		testPod("redis", "bbbbbbbbbbbbbbbb", nil), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool { return kube.lookup("bbbbbbbbbbbb").PodName == "redis" })
+	if c := kube.lookup("aaaaaaaaaaaaaaaa"); c.Shell != "/bin/sh" {
+		t.Errorf("the shell is not kept: %+v", c)
+	}
+	if c := kube.lookup("cccccccccccc"); c.ID != "" {
+		t.Errorf("unexpected container %+v", c)
+	}
+}
+
+func TestLogStreams(t *testing.T) {
+	kube, clientset := newTestCli(t, listFilter{},
+		testPod("nginx", "aaaaaaaaaaaaaaaa", nil))
+	ctx := context.Background()
+	kube.List(ctx)
+	kube.containers.SetShell("aaaaaaaaaaaaaaaa", "/bin/sh")
+
+	// the fake API returns the logs with the stream and the tailLines,
+	// like the API without the PodLogsQuerySplitStreams feature gate
+	for i := 0; i < 2; i++ {
+		_, err := kube.Logs(ctx, types.LogOptions{
+			ID: "aaaaaaaaaaaa", Stream: "stderr", Tail: "10"})
+		if err != errNoSplitStreams {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	probes := 0
+	for _, action := range clientset.Actions() {
+		if action.GetSubresource() == "log" {
+			probes++
+		}
+	}
+	if probes != 1 {
+		t.Errorf("expect 1 probe, got %d", probes)
+	}
+
+	if _, err := kube.Logs(ctx, types.LogOptions{ID: "aaaaaaaaaaaa", Stream: "both"}); err == nil {
+		t.Error("expect error of the unknown stream")
+	}
+}
+
+func TestUnlistableWorkloads(t *testing.T) {
+	clientset := fake.NewClientset(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			UID:       "1234-5678",
+			Labels:    map[string]string{stoppedLabel: "true"},
+		},
+	})
+	clientset.PrependReactor("list", "statefulsets",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(
+				schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "", errors.New("denied"))
+		})
+	cl := newCluster("", clientset, nil, listFilter{})
+	defer cl.cache.close()
+
+	// the statefulsets are not watched, the others still sync
+	if !cl.cache.waitForSync(time.Second * 5) {
+		t.Fatal("cache is not synced")
+	}
+	if err := cl.cache.health(); err != nil {
+		t.Error(err)
+	}
+	containers := cl.cache.list()
+	if len(containers) != 1 || containers[0].ID != "12345678" {
+		t.Errorf("unexpected containers %+v", containers)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	kube, clientset := newTestCli(t, listFilter{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := kube.Watch(ctx)
+	expect := func(action string) {
+		t.Helper()
+		select {
+		case event := <-events:
+			if event.Action != action || event.Container.ID != "aaaaaaaaaaaaaaaa" {
+				t.Errorf("unexpected event %+v", event)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("no %s event", action)
+		}
+	}
+
+	pods := clientset.CoreV1().Pods("default")
+	pod, err := pods.Create(ctx, testPod("nginx", "aaaaaaaaaaaaaaaa", nil), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(types.ContainerCreated)
+
+	pod.Status.ContainerStatuses[0].Ready = false
+	if _, err := pods.UpdateStatus(ctx, pod, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expect(types.ContainerUpdated)
+
+	if err := pods.Delete(ctx, "nginx", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expect(types.ContainerRemoved)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestLoadConfigs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, cluster, server string) string {
+		path := filepath.Join(dir, name)
+		data := "apiVersion: v1\nkind: Config\n" +
+			"current-context: " + cluster + "\n" +
+			"clusters:\n- name: " + cluster + "\n  cluster:\n    server: " + server + "\n" +
+			"contexts:\n- name: " + cluster + "\n  context:\n    cluster: " + cluster + "\n    user: " + cluster + "\n" +
+			"users:\n- name: " + cluster + "\n  user:\n    token: abc\n"
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	prod := write("prod", "prod", "https://prod:6443")
+	dev := write("dev", "dev", "https://dev:6443")
+	paths := prod + string(filepath.ListSeparator) + dev
+
+	names, configs, err := loadConfigs(config.KubeConfig{ConfigPath: prod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "" || configs[0].Host != "https://prod:6443" {
+		t.Errorf("unexpected clusters %v", names)
+	}
+
+	names, configs, err = loadConfigs(config.KubeConfig{ConfigPath: paths, Contexts: []string{"all"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "dev" || names[1] != "prod" ||
+		configs[0].Host != "https://dev:6443" {
+		t.Errorf("unexpected clusters %v", names)
+	}
+
+	if _, _, err := loadConfigs(config.KubeConfig{ConfigPath: paths, Contexts: []string{"test"}}); err == nil {
+		t.Error("expect error of the unknown context")
+	}
+}
@@ -0,0 +1,116 @@
+package kube
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"time"
+)
+
+// untilReader ends the logs at the until time, which the kube API doesn't
+// support, the lines are requested with the timestamps to compare. The
+// lines not after the after time are skipped, they are sent already
+type untilReader struct {
+	rc         io.ReadCloser
+	scanner    *bufio.Scanner
+	after      time.Time
+	until      time.Time // zero to read to the end
+	timestamps bool      // keep the timestamps of the lines
+	timer      *time.Timer
+	buff       bytes.Buffer
+}
+
+func newUntilReader(rc io.ReadCloser, until time.Time, timestamps bool) *untilReader {
+	r := &untilReader{
+		rc:         rc,
+		scanner:    newLineScanner(rc),
+		until:      until,
+		timestamps: timestamps,
+	}
+	if !until.IsZero() {
+		// the followed logs end at the until even if no more lines
+		r.timer = time.AfterFunc(time.Until(until), func() { rc.Close() })
+	}
+	return r
+}
+
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), 1<<20)
+	return scanner
+}
+
+// lineTime parses the timestamp of the line, and returns the message
+func lineTime(line []byte) (time.Time, []byte, bool) {
+	ts, msg, ok := bytes.Cut(line, []byte(" "))
+	t, err := time.Parse(time.RFC3339Nano, string(ts))
+	return t, msg, ok && err == nil
+}
+
+func (r *untilReader) Read(p []byte) (int, error) {
+	for r.buff.Len() == 0 {
+		if !r.scanner.Scan() {
+			err := r.scanner.Err()
+			if err != nil && (r.until.IsZero() || time.Now().Before(r.until)) {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		line := r.scanner.Bytes()
+		if t, msg, ok := lineTime(line); ok {
+			if !r.after.IsZero() && !t.After(r.after) {
+				continue
+			}
+			if !r.until.IsZero() && t.After(r.until) {
+				return 0, io.EOF
+			}
+			if !r.timestamps {
+				line = msg
+			}
+		}
+		r.buff.Write(line)
+		r.buff.WriteByte('\n')
+	}
+	return r.buff.Read(p)
+}
+
+func (r *untilReader) Close() error {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+	return r.rc.Close()
+}
+
+// tailLines reads the logs with the timestamps to the end and keeps the
+// last n lines, the kube API cannot tail a single stream, it returns
+// the time of the last line
+func tailLines(r io.Reader, n int) ([]byte, time.Time, error) {
+	var (
+		last  time.Time
+		lines = make([][]byte, 0, n)
+	)
+	scanner := newLineScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if t, _, ok := lineTime(line); ok {
+			last = t
+		}
+		if n == 0 {
+			continue
+		}
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, append([]byte(nil), line...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, last, err
+	}
+
+	buff := bytes.Buffer{}
+	for _, line := range lines {
+		buff.Write(line)
+		buff.WriteByte('\n')
+	}
+	return buff.Bytes(), last, nil
+}
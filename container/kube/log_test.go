@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUntilReader(t *testing.T) {
+	logs := `2024-05-01T10:00:00.000000001Z started
+2024-05-01T10:00:01Z serving
+2024-05-01T10:00:02.5Z stopped
+`
+	until := time.Date(2024, 5, 1, 10, 0, 2, 0, time.UTC)
+
+	for _, tc := range []struct {
+		timestamps bool
+		expect     string
+	}{
+		{false, "started\nserving\n"},
+		{true, "2024-05-01T10:00:00.000000001Z started\n2024-05-01T10:00:01Z serving\n"},
+	} {
+		r := newUntilReader(io.NopCloser(strings.NewReader(logs)), until, tc.timestamps)
+		bs, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != tc.expect {
+			t.Errorf("expect %q, got %q", tc.expect, bs)
+		}
+	}
+}
+
+func TestUntilReaderFollow(t *testing.T) {
+	// no more lines, it ends at the until
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	r := newUntilReader(pr, time.Now().Add(time.Millisecond*100), false)
+	defer r.Close()
+
+	done := make(chan error)
+	go func() {
+		_, err := io.ReadAll(r)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("the logs don't end at the until")
+	}
+}
+
+func TestUntilReaderAfter(t *testing.T) {
+	logs := `2024-05-01T10:00:01Z serving
+2024-05-01T10:00:01.5Z sent
+2024-05-01T10:00:02Z new
+no timestamp
+`
+	r := newUntilReader(io.NopCloser(strings.NewReader(logs)), time.Time{}, false)
+	r.after = time.Date(2024, 5, 1, 10, 0, 1, 500000000, time.UTC)
+	bs, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := "new\nno timestamp\n"; string(bs) != expect {
+		t.Errorf("expect %q, got %q", expect, bs)
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	logs := `2024-05-01T10:00:00Z started
+2024-05-01T10:00:01Z serving
+2024-05-01T10:00:02.5Z stopped
+`
+	last := time.Date(2024, 5, 1, 10, 0, 2, 500000000, time.UTC)
+	for n, expect := range map[int]string{
+		0: "",
+		2: "2024-05-01T10:00:01Z serving\n2024-05-01T10:00:02.5Z stopped\n",
+		5: logs,
+	} {
+		lines, got, err := tailLines(strings.NewReader(logs), n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(lines) != expect || !got.Equal(last) {
+			t.Errorf("tail %d: unexpected %q at %s", n, lines, got)
+		}
+	}
+}
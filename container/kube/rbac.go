@@ -0,0 +1,125 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// namespaceFile is the namespace of the service account, mounted in the pods
+var namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// permission is a RBAC permission we need, the required ones are
+// needed to list the containers, the others are for exec and logs
+type permission struct {
+	verb, resource, subresource string
+	namespace                   string
+	required                    bool
+}
+
+func (p permission) String() string {
+	resource := p.resource
+	if p.subresource != "" {
+		resource += "/" + p.subresource
+	}
+	namespace := "all namespaces"
+	if p.namespace != "" {
+		namespace = "namespace " + p.namespace
+	}
+	return fmt.Sprintf("%s %s in %s", p.verb, resource, namespace)
+}
+
+var permissions = []permission{
+	{verb: "list", resource: "pods", required: true},
+	{verb: "watch", resource: "pods", required: true},
+	{verb: "create", resource: "pods", subresource: "exec"},
+	{verb: "get", resource: "pods", subresource: "log"},
+}
+
+// missingPermissions reviews the permissions in the namespaces, "" means
+// all namespaces, and returns the denied ones
+func missingPermissions(ctx context.Context, cli kubernetes.Interface,
+	namespaces []string) ([]permission, error) {
+	missing := []permission{}
+	for _, ns := range namespaces {
+		for _, p := range permissions {
+			review, err := cli.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx,
+				&authorizationv1.SelfSubjectAccessReview{
+					Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+						ResourceAttributes: &authorizationv1.ResourceAttributes{
+							Namespace:   ns,
+							Verb:        p.verb,
+							Resource:    p.resource,
+							Subresource: p.subresource,
+						},
+					},
+				}, metav1.CreateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			if !review.Status.Allowed {
+				p.namespace = ns
+				missing = append(missing, p)
+			}
+		}
+	}
+	return missing, nil
+}
+
+func listable(missing []permission) bool {
+	for _, p := range missing {
+		if p.required {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPermissions reports the missing permissions, the pods must be
+// listed and watched, exec and logs only fail when they are used. If
+// the service account cannot list all namespaces, only its own namespace
+// is listed
+func checkPermissions(ctx context.Context, cli kubernetes.Interface,
+	filter listFilter) (listFilter, error) {
+	missing, err := missingPermissions(ctx, cli, filter.namespaceList())
+	if err != nil {
+		if apierrors.IsForbidden(err) || apierrors.IsNotFound(err) {
+			logrus.Warnf("cannot review the RBAC permissions: %s", err)
+			return filter, nil
+		}
+		return filter, err
+	}
+
+	if !listable(missing) && len(filter.namespaces) == 0 {
+		if data, err := os.ReadFile(namespaceFile); err == nil {
+			ns := strings.TrimSpace(string(data))
+			own, err := missingPermissions(ctx, cli, []string{ns})
+			if err == nil && listable(own) {
+				logrus.Warnf("cannot list the pods in all namespaces, "+
+					"only list the namespace %s of the service account", ns)
+				filter.namespaces, filter.exclude = []string{ns}, nil
+				missing = own
+			}
+		}
+	}
+
+	denied := []string{}
+	for _, p := range missing {
+		denied = append(denied, p.String())
+	}
+	if !listable(missing) {
+		return filter, fmt.Errorf("missing RBAC permissions: %s", strings.Join(denied, ", "))
+	}
+	if len(denied) != 0 {
+		logrus.Warnf("missing RBAC permissions: %s, exec or logs won't work",
+			strings.Join(denied, ", "))
+	}
+	return filter, nil
+}
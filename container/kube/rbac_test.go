@@ -0,0 +1,71 @@
+package kube
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeRBAC allows the permissions, "" is the namespace for all namespaces
+func fakeRBAC(allowed map[string][]string) *fake.Clientset {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews",
+		func(action k8stesting.Action) (bool, runtime.Object, error) {
+			review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			attr := review.Spec.ResourceAttributes
+			resource := attr.Resource
+			if attr.Subresource != "" {
+				resource += "/" + attr.Subresource
+			}
+			for _, allow := range allowed[attr.Namespace] {
+				if allow == attr.Verb+" "+resource {
+					review.Status.Allowed = true
+				}
+			}
+			return true, review, nil
+		})
+	return clientset
+}
+
+func TestCheckPermissions(t *testing.T) {
+	ctx := context.Background()
+	all := []string{"list pods", "watch pods", "create pods/exec", "get pods/log"}
+
+	f, err := checkPermissions(ctx, fakeRBAC(map[string][]string{"": all}), listFilter{})
+	if err != nil || len(f.namespaces) != 0 {
+		t.Errorf("unexpected filter %+v, error %v", f, err)
+	}
+
+	// exec and logs are optional
+	f, err = checkPermissions(ctx, fakeRBAC(map[string][]string{"": all[:2]}), listFilter{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = checkPermissions(ctx, fakeRBAC(map[string][]string{"web": all}),
+		listFilter{namespaces: []string{"web", "db"}})
+	if err == nil || !strings.Contains(err.Error(), "list pods in namespace db") {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// the service account can only list its own namespace
+	namespaceFile = filepath.Join(t.TempDir(), "namespace")
+	if err := os.WriteFile(namespaceFile, []byte("web\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err = checkPermissions(ctx, fakeRBAC(map[string][]string{"web": all}),
+		listFilter{exclude: []string{"kube-system"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.namespaces) != 1 || f.namespaces[0] != "web" || len(f.exclude) != 0 {
+		t.Errorf("unexpected filter %+v", f)
+	}
+}
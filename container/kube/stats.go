@@ -0,0 +1,129 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// metricsInterval is how often the metrics are polled, the metrics-server
+// scrapes the kubelets every 15s by default
+const metricsInterval = time.Second * 15
+
+// podMetrics is the PodMetrics of metrics.k8s.io, only the fields we need
+type podMetrics struct {
+	Timestamp  metav1.Time `json:"timestamp"`
+	Containers []struct {
+		Name  string          `json:"name"`
+		Usage v1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// Stats polls the usage of the container from the metrics API, which needs
+// the metrics-server, only the CPU and the memory are known
+func (kube KubeCli) Stats(ctx context.Context, c types.Container) (<-chan types.Stats, error) {
+	if c.PodName == "" {
+		return nil, fmt.Errorf("workload %s is stopped", c.Workload)
+	}
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limit is unknown if the pod is not allowed to get
+	var limit uint64
+	pod, err := cl.cli.CoreV1().Pods(c.Namespace).Get(ctx, c.PodName, metav1.GetOptions{})
+	if err != nil {
+		logrus.Debugf("get pod %s/%s error: %s", c.Namespace, c.PodName, err)
+	} else {
+		limit = memoryLimit(pod, c.ContainerName)
+	}
+
+	first, err := cl.containerStats(ctx, c, limit)
+	if err != nil {
+		return nil, fmt.Errorf("metrics API is not available: %s", err)
+	}
+
+	stats := make(chan types.Stats, 1)
+	stats <- first
+	go func() {
+		defer close(stats)
+		ticker := time.NewTicker(metricsInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			s, err := cl.containerStats(ctx, c, limit)
+			if err != nil {
+				if ctx.Err() == nil {
+					logrus.Errorf("get metrics of container %s error: %s", c.ID, err)
+				}
+				return
+			}
+			select {
+			case stats <- s:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return stats, nil
+}
+
+// containerStats gets the metrics of the pod of the container
+func (cl *cluster) containerStats(ctx context.Context, c types.Container, limit uint64) (types.Stats, error) {
+	restClient := cl.cli.Discovery().RESTClient()
+	if restClient == nil {
+		return types.Stats{}, fmt.Errorf("no REST client")
+	}
+	body, err := restClient.Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", c.Namespace, "pods", c.PodName).
+		DoRaw(ctx)
+	if err != nil {
+		return types.Stats{}, err
+	}
+
+	var metrics podMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return types.Stats{}, err
+	}
+	return metricsStats(metrics, c, limit)
+}
+
+// metricsStats picks the usage of the container from the pod metrics
+func metricsStats(metrics podMetrics, c types.Container, limit uint64) (types.Stats, error) {
+	for _, container := range metrics.Containers {
+		if container.Name != c.ContainerName {
+			continue
+		}
+		return types.Stats{
+			ID:          c.ID,
+			Time:        metrics.Timestamp.Time,
+			CPUPercent:  float64(container.Usage.Cpu().MilliValue()) / 10,
+			MemoryUsage: uint64(container.Usage.Memory().Value()),
+			MemoryLimit: limit,
+		}, nil
+	}
+	return types.Stats{}, fmt.Errorf("no metrics of container %s in pod %s",
+		c.ContainerName, c.PodName)
+}
+
+// memoryLimit returns the memory limit of the container, 0 if unlimited
+func memoryLimit(pod *v1.Pod, name string) uint64 {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return uint64(container.Resources.Limits.Memory().Value())
+		}
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestMetricsStats(t *testing.T) {
+	body := `{
+		"kind": "PodMetrics",
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"metadata": {"name": "nginx", "namespace": "default"},
+		"timestamp": "2024-05-01T10:00:00Z",
+		"window": "15s",
+		"containers": [
+			{"name": "sidecar", "usage": {"cpu": "1m", "memory": "1Mi"}},
+			{"name": "app", "usage": {"cpu": "250m", "memory": "64Mi"}}
+		]
+	}`
+	var metrics podMetrics
+	if err := json.Unmarshal([]byte(body), &metrics); err != nil {
+		t.Fatal(err)
+	}
+
+	c := types.Container{ID: "aaaaaaaaaaaaaaaa", PodName: "nginx", ContainerName: "app"}
+	s, err := metricsStats(metrics, c, 128<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID != c.ID || s.CPUPercent != 25 || s.MemoryUsage != 64<<20 ||
+		s.MemoryLimit != 128<<20 || s.Time.IsZero() {
+		t.Errorf("unexpected stats %+v", s)
+	}
+
+	c.ContainerName = "db"
+	if _, err := metricsStats(metrics, c, 0); err == nil {
+		t.Error("expect error of the missing container")
+	}
+}
+
+func TestMemoryLimit(t *testing.T) {
+	pod := testPod("nginx", "aaaaaaaaaaaaaaaa", nil)
+	if limit := memoryLimit(pod, "app"); limit != 0 {
+		t.Errorf("expect unlimited, got %d", limit)
+	}
+	pod.Spec.Containers[0].Resources.Limits = v1.ResourceList{
+		v1.ResourceMemory: resource.MustParse("256Mi"),
+	}
+	if limit := memoryLimit(pod, "app"); limit != 256<<20 {
+		t.Errorf("unexpected limit %d", limit)
+	}
+}
+
+func TestStatsUnavailable(t *testing.T) {
+	kube, _ := newTestCli(t, listFilter{}, testPod("nginx", "aaaaaaaaaaaaaaaa", nil))
+	ctx := context.Background()
+
+	c := kube.List(ctx)[0]
+	if _, err := kube.Stats(ctx, c); err == nil {
+		t.Error("expect error without the metrics API")
+	}
+	if _, err := kube.Stats(ctx, types.Container{Workload: "deployment/web"}); err == nil {
+		t.Error("expect error of the stopped workload")
+	}
+}
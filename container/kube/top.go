@@ -0,0 +1,55 @@
+package kube
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// psCommands are tried in order, busybox doesn't know pcpu, and
+// some builds of it don't support -o at all
+var psCommands = [][]string{
+	{"ps", "-o", "pid,user,pcpu,rss,args"},
+	{"ps", "-o", "pid,user,rss,args"},
+	{"ps"},
+}
+
+// Top lists the processes by executing `ps` in the container, the PIDs
+// are of the container
+func (kube KubeCli) Top(ctx context.Context, c types.Container) ([]types.Process, error) {
+	if c.PodName == "" {
+		return nil, fmt.Errorf("workload %s is stopped", c.Workload)
+	}
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cmds := range psCommands {
+		var output string
+		output, err = cl.run(ctx, c, cmds...)
+		if err == nil {
+			return util.ParsePs(output), nil
+		}
+		logrus.Debugf("run %v in container %s error: %s", cmds, c.ID, err)
+	}
+	return nil, fmt.Errorf("ps is not available in the container: %s", err)
+}
+
+// Signal sends the signal by executing `kill` in the container
+func (kube KubeCli) Signal(ctx context.Context, c types.Container, pid int, signal string) error {
+	if c.PodName == "" {
+		return fmt.Errorf("workload %s is stopped", c.Workload)
+	}
+	cl, err := kube.clusterOf(c)
+	if err != nil {
+		return err
+	}
+	_, err = cl.run(ctx, c, "kill", "-s", signal, strconv.Itoa(pid))
+	return err
+}
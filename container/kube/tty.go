@@ -17,6 +17,9 @@ type execInjector struct {
 
 	sq         *sizeQueue
 	activeChan chan struct{}
+
+	execOptions string // the options took effect
+	attached    bool   // don't signal the main process on exit
 }
 
 func newInjector(ctx context.Context) execInjector {
@@ -55,8 +58,10 @@ func (enj *execInjector) Write(p []byte) (n int, err error) {
 }
 
 func (enj *execInjector) Exit() error {
-	enj.Write([]byte{3}) // ^C
-	enj.Write([]byte{4}) // ^D
+	if !enj.attached {
+		enj.Write([]byte{3}) // ^C
+		enj.Write([]byte{4}) // ^D
+	}
 
 	enj.r.Close()
 	enj.w.Close()
@@ -73,7 +78,9 @@ func (enj *execInjector) ActiveChan() <-chan struct{} {
 }
 
 func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
-	return map[string]interface{}{}
+	return map[string]interface{}{
+		"execOptions": enj.execOptions,
+	}
 }
 
 func (enj *execInjector) ResizeTerminal(width int, height int) (err error) {
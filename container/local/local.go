@@ -0,0 +1,368 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// defaultPath is the PATH in the chroot
+const defaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// LocalCli spawns the shells on the host itself, the host is
+// listed as the only container
+type LocalCli struct {
+	container types.Container
+	chroot    string
+	dir       string
+	logFile   string
+}
+
+func NewCli(conf config.LocalConfig) (*LocalCli, error) {
+	shell := conf.Shell
+	if shell == "" {
+		if shell = os.Getenv("SHELL"); shell == "" {
+			shell = "/bin/sh"
+		}
+	}
+	if conf.Chroot != "" {
+		if _, err := os.Stat(conf.Chroot + shell); err != nil {
+			return nil, fmt.Errorf("shell %s not found in %s: %s", shell, conf.Chroot, err)
+		}
+	} else if _, err := exec.LookPath(shell); err != nil {
+		return nil, fmt.Errorf("shell %s not found: %s", shell, err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(hostname + conf.Chroot + shell))
+
+	command := shell
+	if conf.Chroot != "" {
+		command = "chroot " + conf.Chroot + " " + shell
+	}
+	logrus.Infof("New local client: %s", command)
+
+	return &LocalCli{
+		container: types.Container{
+			ID:      hex.EncodeToString(sum[:]),
+			Name:    hostname,
+			Image:   "local",
+			Command: command,
+			IPs:     hostIPs(),
+			State:   "running",
+			Status:  "local host",
+			Shell:   shell,
+		},
+		chroot:  conf.Chroot,
+		dir:     conf.Dir,
+		logFile: conf.LogFile,
+	}, nil
+}
+
+func hostIPs() []string {
+	ips := []string{}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		logrus.Warnf("get interface addresses error: %s", err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+			ips = append(ips, ipNet.IP.String())
+		}
+	}
+	if len(ips) == 0 {
+		ips = []string{"null"}
+	}
+	return ips
+}
+
+func (l *LocalCli) GetInfo(ctx context.Context, cid string) types.Container {
+	if strings.HasPrefix(l.container.ID, cid) && cid != "" {
+		return l.container
+	}
+	return types.Container{}
+}
+
+func (l *LocalCli) List(ctx context.Context) []types.Container {
+	return []types.Container{l.container}
+}
+
+func (l *LocalCli) Start(ctx context.Context, cid string) error {
+	return fmt.Errorf("start is not supported by the local backend")
+}
+
+func (l *LocalCli) Stop(ctx context.Context, cid string) error {
+	return fmt.Errorf("stop is not supported by the local backend")
+}
+
+func (l *LocalCli) Restart(ctx context.Context, cid string) error {
+	return fmt.Errorf("restart is not supported by the local backend")
+}
+
+// account is the user the shell runs as
+type account struct {
+	name, home string
+	uid, gid   uint32
+}
+
+// lookupAccount finds the "user[:group]" by the names or the IDs, in the
+// passwd and group files of the chroot if set, instead of the host's
+func lookupAccount(root, name string) (*account, error) {
+	name, group, _ := strings.Cut(name, ":")
+	var (
+		a   *account
+		err error
+	)
+	if root == "" {
+		a, err = hostAccount(name)
+	} else {
+		a, err = fileAccount(filepath.Join(root, "/etc/passwd"), name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup user %s error: %s", name, err)
+	}
+
+	if group != "" {
+		var gid string
+		if root == "" {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				if g, err = user.LookupGroupId(group); err != nil {
+					return nil, fmt.Errorf("lookup group %s error: %s", group, err)
+				}
+			}
+			gid = g.Gid
+		} else {
+			fields, err := lookupEntry(filepath.Join(root, "/etc/group"), group, 3)
+			if err != nil {
+				return nil, fmt.Errorf("lookup group %s error: %s", group, err)
+			}
+			gid = fields[2]
+		}
+		gidN, err := strconv.ParseUint(gid, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		a.gid = uint32(gidN)
+	}
+	return a, nil
+}
+
+func hostAccount(name string) (*account, error) {
+	u, err := user.Lookup(name)
+	if err != nil {
+		if u, err = user.LookupId(name); err != nil {
+			return nil, err
+		}
+	}
+	return newAccount(u.Username, u.Uid, u.Gid, u.HomeDir)
+}
+
+// fileAccount finds the user in the passwd file,
+// name:password:uid:gid:gecos:home:shell
+func fileAccount(path, name string) (*account, error) {
+	fields, err := lookupEntry(path, name, 6)
+	if err != nil {
+		return nil, err
+	}
+	return newAccount(fields[0], fields[2], fields[3], fields[5])
+}
+
+func newAccount(name, uid, gid, home string) (*account, error) {
+	uidN, err := strconv.ParseUint(uid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	gidN, err := strconv.ParseUint(gid, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	if home == "" {
+		home = "/"
+	}
+	return &account{name: name, home: home, uid: uint32(uidN), gid: uint32(gidN)}, nil
+}
+
+// lookupEntry finds the line of the passwd or group file whose name,
+// or else the ID, matches, it has n fields at least
+func lookupEntry(path, name string, n int) ([]string, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var byID []string
+	for _, line := range strings.Split(string(bs), "\n") {
+		fields := strings.Split(line, ":")
+		if len(fields) < n || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if fields[0] == name {
+			return fields, nil
+		}
+		if fields[2] == name && byID == nil {
+			byID = fields
+		}
+	}
+	if byID == nil {
+		return nil, fmt.Errorf("%s not found in %s", name, path)
+	}
+	return byID, nil
+}
+
+// environ is the environments of the shell, the ones of the server are
+// not inherited, they may have the secrets of the other backends
+func (l *LocalCli) environ(a *account, extra string) []string {
+	path := defaultPath
+	if l.chroot == "" {
+		if p := os.Getenv("PATH"); p != "" {
+			path = p
+		}
+	}
+	env := []string{
+		"PATH=" + path,
+		"TERM=xterm",
+		"HISTCONTROL=ignoredups",
+		"SHELL=" + l.container.Shell,
+		"HOME=" + a.home,
+	}
+	if a.name != "" {
+		env = append(env, "USER="+a.name, "LOGNAME="+a.name)
+	}
+	if extra != "" {
+		env = append(env, strings.Split(extra, " ")...)
+	}
+	return env
+}
+
+// lookPath finds the executable of the argv[0] without a slash, in
+// the chroot if set, the returned path is of the chroot
+func (l *LocalCli) lookPath(name string) (string, error) {
+	if strings.Contains(name, "/") {
+		return name, nil
+	}
+	if l.chroot == "" {
+		return exec.LookPath(name)
+	}
+	for _, dir := range filepath.SplitList(defaultPath) {
+		path := filepath.Join(dir, name)
+		// the links are resolved in the chroot by the kernel
+		fi, err := os.Lstat(filepath.Join(l.chroot, path))
+		if err != nil {
+			continue
+		}
+		if fi.Mode()&os.ModeSymlink != 0 || (fi.Mode().IsRegular() && fi.Mode()&0111 != 0) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("executable file %s not found in %s", name, l.chroot)
+}
+
+func (l *LocalCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	opts := c.Exec
+	if opts.Privileged {
+		return nil, fmt.Errorf("privileged exec is not supported by the local backend")
+	}
+	if opts.Debug {
+		return nil, fmt.Errorf("the debug mode is not supported by the local backend")
+	}
+
+	args := []string{l.container.Shell, "-l"}
+	if len(opts.Args) != 0 {
+		args = opts.Args
+	} else if opts.Cmd != "" {
+		args = []string{l.container.Shell, "-c", opts.Cmd}
+	}
+	path, err := l.lookPath(args[0])
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Args[0] = args[0]
+	cmd.Dir = l.dir
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	if l.chroot != "" {
+		cmd.SysProcAttr.Chroot = l.chroot
+		if cmd.Dir == "" {
+			cmd.Dir = "/"
+		}
+	}
+
+	var a *account
+	if opts.User != "" {
+		if a, err = lookupAccount(l.chroot, opts.User); err != nil {
+			return nil, err
+		}
+		cmd.SysProcAttr.Credential = &syscall.Credential{Uid: a.uid, Gid: a.gid}
+	} else if a, err = lookupAccount(l.chroot, strconv.Itoa(os.Getuid())); err != nil {
+		// the user of the server may not be in the chroot
+		logrus.Debugf("lookup the current user error: %s", err)
+		a = &account{home: "/"}
+	}
+	cmd.Env = l.environ(a, opts.Env)
+	logrus.Debugf("exec cmd: %v", cmd.Args)
+
+	f, err := pty.StartWithSize(cmd, &pty.Winsize{Rows: 24, Cols: 80})
+	if err != nil {
+		return nil, err
+	}
+	return newExecInjector(cmd, f), nil
+}
+
+func (l *LocalCli) Close() error {
+	return nil
+}
+
+// Logs tails the configured log file
+func (l *LocalCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	if l.logFile == "" {
+		return nil, fmt.Errorf("logs are not available, no log file configured")
+	}
+	rc, err := util.TailFile(ctx, l.logFile, util.ParseTail(opts.Tail), opts.Follow)
+	if err != nil {
+		return nil, err
+	}
+	return &logReader{rc: rc}, nil
+}
+
+// logReader converts the lines to terminal outputs
+type logReader struct {
+	rc   io.ReadCloser
+	buff bytes.Buffer
+}
+
+func (r *logReader) Read(p []byte) (int, error) {
+	if r.buff.Len() == 0 {
+		bs := make([]byte, len(p))
+		n, err := r.rc.Read(bs)
+		if n == 0 {
+			return 0, err
+		}
+		r.buff.Write(bytes.ReplaceAll(bs[:n], []byte("\n"), []byte("\r\n")))
+	}
+	return r.buff.Read(p)
+}
+
+func (r *logReader) Close() error {
+	return r.rc.Close()
+}
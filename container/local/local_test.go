@@ -0,0 +1,213 @@
+package local
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(logFile, []byte("line 1\nline 2\nline 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cli, err := NewCli(config.LocalConfig{Shell: "/bin/sh", LogFile: logFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		containers := cli.List(ctx)
+		if len(containers) != 1 {
+			t.Fatalf("expect 1 container, got %d", len(containers))
+		}
+		c := cli.GetInfo(ctx, containers[0].ID[:12])
+		if c.ID != containers[0].ID || c.Shell != "/bin/sh" {
+			t.Errorf("unexpected container %+v", c)
+		}
+		if c := cli.GetInfo(ctx, "not-exist"); c.ID != "" {
+			t.Errorf("unexpected container %+v", c)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c := cli.List(ctx)[0]
+		c.Exec = types.ExecOptions{Env: "GREETING=hello", Cmd: "read x; echo $GREETING $x"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Exit()
+
+		if err := tty.ResizeTerminal(100, 30); err != nil {
+			t.Fatal(err)
+		}
+		rows, cols, err := pty.Getsize(tty.(*execInjector).pty)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows != 30 || cols != 100 {
+			t.Errorf("unexpected size %dx%d", cols, rows)
+		}
+
+		if _, err := tty.Write([]byte("world\n")); err != nil {
+			t.Fatal(err)
+		}
+		r := bufio.NewReader(tty)
+		output := make(chan string)
+		go func() {
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || strings.HasPrefix(line, "hello") {
+					output <- line
+					return
+				}
+			}
+		}()
+		select {
+		case line := <-output:
+			if strings.TrimSpace(line) != "hello world" {
+				t.Errorf("unexpected output %q", line)
+			}
+		case <-time.After(time.Second * 3):
+			t.Error("timeout waiting for the output")
+		}
+	})
+
+	t.Run("environ", func(t *testing.T) {
+		t.Setenv("CWT_SSH_PASSWORD", "secret")
+		c := cli.List(ctx)[0]
+		c.Exec = types.ExecOptions{Cmd: "read x; echo env=${CWT_SSH_PASSWORD:-none}:$TERM"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Exit()
+
+		if _, err := tty.Write([]byte("\n")); err != nil {
+			t.Fatal(err)
+		}
+		r := bufio.NewReader(tty)
+		output := make(chan string)
+		go func() {
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil || strings.HasPrefix(line, "env=") {
+					output <- line
+					return
+				}
+			}
+		}()
+		select {
+		case line := <-output:
+			if strings.TrimSpace(line) != "env=none:xterm" {
+				t.Errorf("unexpected output %q", line)
+			}
+		case <-time.After(time.Second * 3):
+			t.Error("timeout waiting for the output")
+		}
+	})
+
+	t.Run("privileged", func(t *testing.T) {
+		c := cli.List(ctx)[0]
+		c.Exec = types.ExecOptions{Privileged: true}
+		if _, err := cli.Exec(ctx, c); err == nil {
+			t.Error("expect error of privileged exec")
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		rc, err := cli.Logs(ctx, types.LogOptions{Tail: "2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		bs, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != "line 2\r\nline 3\r\n" {
+			t.Errorf("unexpected logs %q", bs)
+		}
+	})
+}
+
+func TestChroot(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"etc/passwd":  "root:x:0:0:root:/root:/bin/sh\n# comment\nalice:x:1000:1000::/home/alice:/bin/sh\n",
+		"etc/group":   "root:x:0:\nstaff:x:50:alice\n",
+		"bin/sh":      "#!/bin/true\n",
+		"usr/bin/top": "#!/bin/true\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink("/bin/sh", filepath.Join(root, "bin/ash")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "bin/readme"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("account", func(t *testing.T) {
+		for name, expect := range map[string]account{
+			"alice":       {name: "alice", home: "/home/alice", uid: 1000, gid: 1000},
+			"1000":        {name: "alice", home: "/home/alice", uid: 1000, gid: 1000},
+			"alice:staff": {name: "alice", home: "/home/alice", uid: 1000, gid: 50},
+			"0:50":        {name: "root", home: "/root", uid: 0, gid: 50},
+		} {
+			a, err := lookupAccount(root, name)
+			if err != nil {
+				t.Errorf("lookup %s error: %s", name, err)
+				continue
+			}
+			if *a != expect {
+				t.Errorf("lookup %s: unexpected account %+v", name, *a)
+			}
+		}
+		for _, name := range []string{"bob", "alice:wheel"} {
+			if _, err := lookupAccount(root, name); err == nil {
+				t.Errorf("expect error of looking up %s", name)
+			}
+		}
+	})
+
+	t.Run("path", func(t *testing.T) {
+		cli := &LocalCli{chroot: root}
+		for name, expect := range map[string]string{
+			"sh":        "/bin/sh",
+			"ash":       "/bin/ash",
+			"top":       "/usr/bin/top",
+			"/bin/bash": "/bin/bash",
+			"./run.sh":  "./run.sh",
+		} {
+			path, err := cli.lookPath(name)
+			if err != nil || path != expect {
+				t.Errorf("look path %s: expect %s, got %s, %v", name, expect, path, err)
+			}
+		}
+		for _, name := range []string{"readme", "bash"} {
+			if path, err := cli.lookPath(name); err == nil {
+				t.Errorf("expect error of looking path %s, got %s", name, path)
+			}
+		}
+	})
+}
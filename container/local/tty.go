@@ -0,0 +1,75 @@
+package local
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+
+	"github.com/creack/pty"
+	"github.com/sirupsen/logrus"
+)
+
+// execInjector implement webtty.Slave
+type execInjector struct {
+	cmd        *exec.Cmd
+	pty        *os.File
+	activeChan chan struct{}
+
+	// the reads may happen after exit
+	m      sync.Mutex
+	exited bool
+}
+
+func newExecInjector(cmd *exec.Cmd, f *os.File) *execInjector {
+	return &execInjector{
+		cmd:        cmd,
+		pty:        f,
+		activeChan: make(chan struct{}, 5),
+	}
+}
+
+func (enj *execInjector) Read(p []byte) (n int, err error) {
+	enj.m.Lock()
+	if !enj.exited && len(enj.activeChan) == 0 {
+		enj.activeChan <- struct{}{}
+	}
+	enj.m.Unlock()
+	return enj.pty.Read(p)
+}
+
+func (enj *execInjector) Write(p []byte) (n int, err error) {
+	return enj.pty.Write(p)
+}
+
+func (enj *execInjector) Exit() error {
+	enj.m.Lock()
+	defer enj.m.Unlock()
+	if enj.exited {
+		return nil
+	}
+	enj.exited = true
+	close(enj.activeChan)
+
+	// hang up the shell and reap it
+	if err := enj.cmd.Process.Signal(syscall.SIGHUP); err != nil {
+		logrus.Debugf("hang up process %d error: %s", enj.cmd.Process.Pid, err)
+	}
+	go enj.cmd.Wait()
+	return enj.pty.Close()
+}
+
+func (enj *execInjector) ActiveChan() <-chan struct{} {
+	return enj.activeChan
+}
+
+func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (enj *execInjector) ResizeTerminal(width int, height int) error {
+	return pty.Setsize(enj.pty, &pty.Winsize{
+		Rows: uint16(height),
+		Cols: uint16(width),
+	})
+}
@@ -0,0 +1,232 @@
+package podman
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiPrefix is the libpod API version this client speaks
+const apiPrefix = "/v4.0.0/libpod"
+
+// apiClient is a tiny libpod REST client
+type apiClient struct {
+	network, addr string
+	http          *http.Client
+}
+
+func newAPIClient(host string) (*apiClient, error) {
+	c := &apiClient{}
+	switch {
+	case strings.HasPrefix(host, "/"):
+		c.network, c.addr = "unix", host
+	case strings.HasPrefix(host, "unix://"):
+		c.network, c.addr = "unix", strings.TrimPrefix(host, "unix://")
+	case strings.HasPrefix(host, "tcp://"):
+		c.network, c.addr = "tcp", strings.TrimPrefix(host, "tcp://")
+	default:
+		return nil, fmt.Errorf("unsupported podman host %s", host)
+	}
+	c.http = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return c.dial(ctx)
+			},
+		},
+	}
+	return c, nil
+}
+
+func (c *apiClient) dial(ctx context.Context) (net.Conn, error) {
+	dialer := net.Dialer{}
+	return dialer.DialContext(ctx, c.network, c.addr)
+}
+
+// apiError is the error body of the libpod API
+type apiError struct {
+	Cause    string `json:"cause"`
+	Message  string `json:"message"`
+	Response int    `json:"response"`
+}
+
+func newRequest(ctx context.Context, method, path string,
+	query url.Values, body interface{}) (*http.Request, error) {
+	u := "http://podman" + apiPrefix + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// checkResponse converts the error responses to errors, the caller
+// should close the body if there is no error
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	e := apiError{}
+	bs, _ := io.ReadAll(resp.Body)
+	if err := json.Unmarshal(bs, &e); err != nil || e.Message == "" {
+		return fmt.Errorf("podman API error: %s %s", resp.Status, bytes.TrimSpace(bs))
+	}
+	return fmt.Errorf("podman API error: %s", e.Message)
+}
+
+func (c *apiClient) do(ctx context.Context, method, path string,
+	query url.Values, body interface{}) (*http.Response, error) {
+	req, err := newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// call does the request and decodes the response into out if it's not nil
+func (c *apiClient) call(ctx context.Context, method, path string,
+	query url.Values, body, out interface{}) error {
+	resp, err := c.do(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// hijack does the request and takes over the connection, it's
+// used for the interactive exec sessions
+func (c *apiClient) hijack(ctx context.Context, path string,
+	body interface{}) (net.Conn, *bufio.Reader, error) {
+	req, err := newRequest(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols &&
+		resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, nil, fmt.Errorf("unexpected hijack response: %s", resp.Status)
+	}
+	return conn, br, nil
+}
+
+// listContainer is an entry of /containers/json
+type listContainer struct {
+	ID       string   `json:"Id"`
+	Names    []string `json:"Names"`
+	Image    string   `json:"Image"`
+	Command  []string `json:"Command"`
+	State    string   `json:"State"`
+	Status   string   `json:"Status"`
+	ExitCode int32    `json:"ExitCode"`
+	Pod      string   `json:"Pod"`
+	PodName  string   `json:"PodName"`
+	IsInfra  bool     `json:"IsInfra"`
+}
+
+// inspectContainer is the response of /containers/{id}/json
+type inspectContainer struct {
+	ID        string `json:"Id"`
+	Name      string `json:"Name"`
+	ImageName string `json:"ImageName"`
+	Pod       string `json:"Pod"`
+	Config    struct {
+		Cmd []string `json:"Cmd"`
+		Tty bool     `json:"Tty"`
+	} `json:"Config"`
+	State struct {
+		Status   string `json:"Status"`
+		ExitCode int32  `json:"ExitCode"`
+	} `json:"State"`
+	NetworkSettings struct {
+		IPAddress string `json:"IPAddress"`
+		Networks  map[string]struct {
+			IPAddress string `json:"IPAddress"`
+		} `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// inspectPod is the response of /pods/{id}/json
+type inspectPod struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+// execConfig is the body of /containers/{id}/exec
+type execConfig struct {
+	AttachStdin  bool     `json:"AttachStdin"`
+	AttachStdout bool     `json:"AttachStdout"`
+	AttachStderr bool     `json:"AttachStderr"`
+	Tty          bool     `json:"Tty"`
+	Privileged   bool     `json:"Privileged"`
+	User         string   `json:"User,omitempty"`
+	Env          []string `json:"Env,omitempty"`
+	Cmd          []string `json:"Cmd"`
+}
+
+// execStart is the body of /exec/{id}/start
+type execStart struct {
+	Detach bool `json:"Detach"`
+	Tty    bool `json:"Tty"`
+}
+
+type idResponse struct {
+	ID string `json:"Id"`
+}
@@ -0,0 +1,59 @@
+package podman
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/docker/docker/pkg/stdcopy"
+
+	"github.com/wrfly/container-web-tty/util"
+)
+
+// crlfWriter converts the outputs to terminal lines
+type crlfWriter struct {
+	w io.Writer
+}
+
+func (w crlfWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(bytes.ReplaceAll(p, []byte("\n"), []byte("\r\n"))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// stderrWriter colors the stderr outputs
+type stderrWriter struct {
+	w io.Writer
+}
+
+func (w stderrWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(util.ColorStderr(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+type logReader struct {
+	*io.PipeReader
+	rc io.ReadCloser
+}
+
+// newLogReader demultiplexes the log stream of libpod, it always
+// has the stream headers no matter the container has a TTY or not
+func newLogReader(rc io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		w := crlfWriter{w: pw}
+		_, err := stdcopy.StdCopy(w, stderrWriter{w}, rc)
+		pw.CloseWithError(err)
+	}()
+	return &logReader{
+		PipeReader: pr,
+		rc:         rc,
+	}
+}
+
+func (r *logReader) Close() error {
+	r.PipeReader.Close()
+	return r.rc.Close()
+}
@@ -0,0 +1,304 @@
+package podman
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+type PodmanCli struct {
+	api        *apiClient
+	containers *types.Containers
+}
+
+func NewCli(conf config.PodmanConfig) (*PodmanCli, error) {
+	logrus.Infof("Podman connecting to %s", conf.Host)
+
+	api, err := newAPIClient(conf.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	resp, err := api.do(ctx, http.MethodGet, "/_ping", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	logrus.Infof("New podman client: API [%s]", resp.Header.Get("Libpod-API-Version"))
+
+	cli := &PodmanCli{
+		api:        api,
+		containers: &types.Containers{},
+	}
+	cli.List(context.Background())
+
+	return cli, nil
+}
+
+func (p *PodmanCli) GetInfo(ctx context.Context, cid string) types.Container {
+	if p.containers.Len() == 0 {
+		logrus.Debugf("zero containers, get cid %s", cid)
+		p.List(ctx)
+	}
+
+	// find in containers
+	if container := p.containers.Find(cid); container.ID != "" {
+		if container.Shell == "" {
+			shell := p.getShell(ctx, container.ID)
+			container.Shell = shell
+			p.containers.SetShell(container.ID, shell)
+		}
+		logrus.Debugf("found valid container: %s (%s)", container.ID, container.Shell)
+		return container
+	}
+
+	// not in the cache, maybe it's a new container
+	inspect := inspectContainer{}
+	path := fmt.Sprintf("/containers/%s/json", url.PathEscape(cid))
+	if err := p.api.call(ctx, http.MethodGet, path, nil, nil, &inspect); err != nil {
+		logrus.Errorf("inspect container %s error: %s", cid, err)
+		return types.Container{}
+	}
+
+	c := p.convert2Container(ctx, inspect)
+	if c.ID != "" {
+		p.containers.Append(c)
+	}
+	return c
+}
+
+func (p *PodmanCli) convert2Container(ctx context.Context, inspect inspectContainer) types.Container {
+	ips := []string{}
+	if ip := inspect.NetworkSettings.IPAddress; ip != "" {
+		ips = append(ips, ip)
+	}
+	for _, network := range inspect.NetworkSettings.Networks {
+		if network.IPAddress != "" && network.IPAddress != inspect.NetworkSettings.IPAddress {
+			ips = append(ips, network.IPAddress)
+		}
+	}
+	if len(ips) == 0 {
+		ips = []string{"null"}
+	}
+
+	name := strings.TrimPrefix(inspect.Name, "/")
+	c := types.Container{
+		ID:            inspect.ID,
+		Name:          name,
+		ContainerName: name,
+		Image:         inspect.ImageName,
+		Command:       strings.Join(inspect.Config.Cmd, " "),
+		IPs:           ips,
+		State:         inspect.State.Status,
+		Status:        containerStatus(inspect.State.Status, "", inspect.State.ExitCode),
+		Shell:         p.getShell(ctx, inspect.ID),
+	}
+
+	if inspect.Pod != "" {
+		pod := inspectPod{}
+		path := fmt.Sprintf("/pods/%s/json", url.PathEscape(inspect.Pod))
+		if err := p.api.call(ctx, http.MethodGet, path, nil, nil, &pod); err != nil {
+			logrus.Warnf("inspect pod %s error: %s", inspect.Pod, err)
+		}
+		c.PodName = pod.Name
+	}
+	return c
+}
+
+// containerStatus fills the status when the API doesn't give one
+func containerStatus(state, status string, exitCode int32) string {
+	switch {
+	case status != "":
+		return status
+	case state == "exited" || state == "stopped":
+		return fmt.Sprintf("exited (%d)", exitCode)
+	}
+	return state
+}
+
+func (p *PodmanCli) List(ctx context.Context) []types.Container {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	start := time.Now()
+	list := []listContainer{}
+	query := url.Values{"all": []string{"true"}}
+	if err := p.api.call(ctx, http.MethodGet, "/containers/json", query, nil, &list); err != nil {
+		logrus.Errorf("list podman containers error: %s", err)
+		return nil
+	}
+
+	containers := make([]types.Container, 0, len(list))
+	for _, c := range list {
+		// the infra container only holds the namespaces of the pod
+		if c.IsInfra {
+			continue
+		}
+		name := c.ID
+		if len(c.Names) != 0 {
+			name = c.Names[0]
+		}
+		container := types.Container{
+			ID:            c.ID,
+			Name:          name,
+			ContainerName: name,
+			PodName:       c.PodName,
+			Image:         c.Image,
+			Command:       strings.Join(c.Command, " "),
+			IPs:           []string{"null"},
+			State:         c.State,
+			Status:        containerStatus(c.State, c.Status, c.ExitCode),
+		}
+		// the list API doesn't have the IPs and the shell
+		if old := p.containers.Find(c.ID); old.ID == c.ID {
+			container.IPs = old.IPs
+			container.Shell = old.Shell
+		}
+		containers = append(containers, container)
+	}
+
+	// keep the containers of the same pod together
+	sort.SliceStable(containers, func(i, j int) bool {
+		return containers[i].PodName < containers[j].PodName
+	})
+
+	p.containers.Set(containers)
+	logrus.Debugf("list %d containers, use %s", len(containers), time.Since(start))
+
+	return containers
+}
+
+func (p *PodmanCli) exist(ctx context.Context, cid, path string) bool {
+	req := fmt.Sprintf("/containers/%s/archive", url.PathEscape(cid))
+	resp, err := p.api.do(ctx, http.MethodHead, req, url.Values{"path": []string{path}}, nil)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+func (p *PodmanCli) getShell(ctx context.Context, cid string) string {
+	for _, sh := range config.SHELL_LIST {
+		if p.exist(ctx, cid, sh) {
+			logrus.Debugf("container [%s] use [%s]", cid, sh)
+			return sh
+		}
+	}
+	return ""
+}
+
+func (p *PodmanCli) action(ctx context.Context, cid, action string) error {
+	path := fmt.Sprintf("/containers/%s/%s", url.PathEscape(cid), action)
+	return p.api.call(ctx, http.MethodPost, path, nil, nil, nil)
+}
+
+func (p *PodmanCli) Start(ctx context.Context, cid string) error {
+	return p.action(ctx, cid, "start")
+}
+
+func (p *PodmanCli) Stop(ctx context.Context, cid string) error {
+	return p.action(ctx, cid, "stop")
+}
+
+func (p *PodmanCli) Restart(ctx context.Context, cid string) error {
+	return p.action(ctx, cid, "restart")
+}
+
+func (p *PodmanCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	cmds := []string{c.Shell}
+	opts := c.Exec
+	if opts.Debug {
+		return nil, fmt.Errorf("the debug mode is not supported by the podman backend")
+	}
+	if len(opts.Args) != 0 {
+		cmds = opts.Args
+	} else if opts.Cmd != "" {
+		cmds = append(cmds, "-c", opts.Cmd)
+	}
+	logrus.Debugf("exec cmd: %v", cmds)
+
+	conf := execConfig{
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Tty:          true,
+		Privileged:   opts.Privileged,
+		User:         opts.User,
+		Cmd:          cmds,
+		Env:          []string{"HISTCONTROL=ignoredups", "TERM=xterm"},
+	}
+	if opts.Env != "" {
+		conf.Env = append(conf.Env, strings.Split(opts.Env, " ")...)
+	}
+
+	created := idResponse{}
+	path := fmt.Sprintf("/containers/%s/exec", url.PathEscape(c.ID))
+	if err := p.api.call(ctx, http.MethodPost, path, nil, conf, &created); err != nil {
+		return nil, err
+	}
+	execID := created.ID
+	if execID == "" {
+		return nil, fmt.Errorf("exec ID empty")
+	}
+
+	path = fmt.Sprintf("/exec/%s/start", url.PathEscape(execID))
+	conn, reader, err := p.api.hijack(ctx, path, execStart{Tty: true})
+	if err != nil {
+		return nil, err
+	}
+
+	resizeFunc := func(width int, height int) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		path := fmt.Sprintf("/exec/%s/resize", url.PathEscape(execID))
+		return p.api.call(ctx, http.MethodPost, path, url.Values{
+			"h": []string{strconv.Itoa(height)},
+			"w": []string{strconv.Itoa(width)},
+		}, nil, nil)
+	}
+
+	return newExecInjector(conn, reader, resizeFunc), nil
+}
+
+func (p *PodmanCli) Close() error {
+	p.api.http.CloseIdleConnections()
+	return nil
+}
+
+func (p *PodmanCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	query := url.Values{
+		"stdout":     []string{strconv.FormatBool(opts.Stream != "stderr")},
+		"stderr":     []string{strconv.FormatBool(opts.Stream != "stdout")},
+		"follow":     []string{strconv.FormatBool(opts.Follow)},
+		"timestamps": []string{strconv.FormatBool(opts.Timestamps)},
+	}
+	if opts.Tail != "" {
+		query.Set("tail", opts.Tail)
+	}
+	if !opts.Since.IsZero() {
+		query.Set("since", opts.Since.Format(time.RFC3339Nano))
+	}
+	if !opts.Until.IsZero() {
+		query.Set("until", opts.Until.Format(time.RFC3339Nano))
+	}
+	path := fmt.Sprintf("/containers/%s/logs", url.PathEscape(opts.ID))
+	resp, err := p.api.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newLogReader(resp.Body), nil
+}
@@ -0,0 +1,300 @@
+package podman
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+const (
+	testContainerID = "0123456789abcdef0123456789abcdef"
+	testExecID      = "fedcba9876543210"
+)
+
+// fakePodman records the requests to the fake libpod API
+type fakePodman struct {
+	m       sync.Mutex
+	actions []string
+	exec    execConfig
+	resize  string
+	logs    string
+}
+
+func (f *fakePodman) handler() http.Handler {
+	mux := http.NewServeMux()
+	prefix := apiPrefix
+	mux.HandleFunc("GET "+prefix+"/_ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Libpod-API-Version", "4.9.3")
+		io.WriteString(w, "OK")
+	})
+	mux.HandleFunc("GET "+prefix+"/containers/json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]listContainer{
+			{
+				ID:      "infra" + testContainerID[5:],
+				Names:   []string{"web-infra"},
+				Pod:     "pod1",
+				PodName: "web",
+				IsInfra: true,
+			},
+			{
+				ID:      testContainerID,
+				Names:   []string{"nginx"},
+				Image:   "docker.io/library/nginx:latest",
+				Command: []string{"nginx", "-g", "daemon off;"},
+				State:   "running",
+				Status:  "Up 3 minutes",
+				Pod:     "pod1",
+				PodName: "web",
+			},
+			{
+				ID:       "abcdef0123456789abcdef0123456789",
+				Names:    []string{"job"},
+				Image:    "docker.io/library/alpine:latest",
+				State:    "exited",
+				ExitCode: 1,
+			},
+		})
+	})
+	mux.HandleFunc("HEAD "+prefix+"/containers/{id}/archive", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("path") != "/bin/sh" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("POST "+prefix+"/containers/{id}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != testContainerID {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(apiError{Message: "no such container", Response: 404})
+			return
+		}
+		if r.PathValue("action") == "exec" {
+			f.m.Lock()
+			json.NewDecoder(r.Body).Decode(&f.exec)
+			f.m.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(idResponse{ID: testExecID})
+			return
+		}
+		f.m.Lock()
+		f.actions = append(f.actions, r.PathValue("action"))
+		f.m.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+prefix+"/exec/{id}/start", func(w http.ResponseWriter, r *http.Request) {
+		start := execStart{}
+		if err := json.NewDecoder(r.Body).Decode(&start); err != nil || !start.Tty {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 101 UPGRADED\r\n"+
+			"Content-Type: application/vnd.docker.raw-stream\r\n"+
+			"Connection: Upgrade\r\nUpgrade: tcp\r\n\r\n")
+		// a shell echoes the inputs
+		io.WriteString(conn, "$ ")
+		io.Copy(conn, buf)
+	})
+	mux.HandleFunc("POST "+prefix+"/exec/{id}/resize", func(w http.ResponseWriter, r *http.Request) {
+		f.m.Lock()
+		f.resize = r.URL.RawQuery
+		f.m.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET "+prefix+"/containers/{id}/logs", func(w http.ResponseWriter, r *http.Request) {
+		f.m.Lock()
+		f.logs = r.URL.RawQuery
+		f.m.Unlock()
+		writeFrame(w, 1, "line 1\n")
+		writeFrame(w, 2, "line 2\n")
+	})
+	return mux
+}
+
+// writeFrame writes a multiplexed log frame
+func writeFrame(w io.Writer, stream byte, msg string) {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(msg)))
+	w.Write(header)
+	io.WriteString(w, msg)
+}
+
+func newTestCli(t *testing.T) (*PodmanCli, *fakePodman) {
+	sock := filepath.Join(t.TempDir(), "podman.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakePodman{}
+	srv := httptest.NewUnstartedServer(f.handler())
+	srv.Listener = l
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	cli, err := NewCli(config.PodmanConfig{Host: "unix://" + sock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return cli, f
+}
+
+func TestPodman(t *testing.T) {
+	ctx := context.Background()
+	cli, f := newTestCli(t)
+
+	t.Run("list", func(t *testing.T) {
+		containers := cli.List(ctx)
+		if len(containers) != 2 {
+			t.Fatalf("expect 2 containers, got %d", len(containers))
+		}
+		// containers without pods come first
+		job, nginx := containers[0], containers[1]
+		if job.Status != "exited (1)" {
+			t.Errorf("unexpected status %q", job.Status)
+		}
+		if nginx.Name != "nginx" || nginx.PodName != "web" || nginx.State != "running" {
+			t.Errorf("unexpected container %+v", nginx)
+		}
+		if nginx.Command != "nginx -g daemon off;" {
+			t.Errorf("unexpected command %q", nginx.Command)
+		}
+	})
+
+	t.Run("get info", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID[:12])
+		if c.ID != testContainerID {
+			t.Fatalf("container not found: %+v", c)
+		}
+		if c.Shell != "/bin/sh" {
+			t.Errorf("expect shell /bin/sh, got %q", c.Shell)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c := cli.GetInfo(ctx, testContainerID)
+		c.Exec = types.ExecOptions{User: "nobody", Env: "A=1 B=2", Cmd: "top"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Exit()
+
+		f.m.Lock()
+		exec := f.exec
+		f.m.Unlock()
+		if strings.Join(exec.Cmd, " ") != "/bin/sh -c top" {
+			t.Errorf("unexpected cmd %v", exec.Cmd)
+		}
+		if exec.User != "nobody" || !exec.Tty || !exec.AttachStdin {
+			t.Errorf("unexpected exec config %+v", exec)
+		}
+		if env := strings.Join(exec.Env, " "); !strings.HasSuffix(env, "A=1 B=2") {
+			t.Errorf("unexpected env %s", env)
+		}
+
+		if err := tty.ResizeTerminal(80, 24); err != nil {
+			t.Error(err)
+		}
+		f.m.Lock()
+		if f.resize != "h=24&w=80" {
+			t.Errorf("unexpected resize %s", f.resize)
+		}
+		f.m.Unlock()
+
+		if _, err := tty.Write([]byte("ls\n")); err != nil {
+			t.Fatal(err)
+		}
+		r := bufio.NewReader(tty)
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != "$ ls\n" {
+			t.Errorf("unexpected output %q", line)
+		}
+		select {
+		case <-tty.ActiveChan():
+		case <-time.After(time.Second):
+			t.Error("tty is not active")
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		rc, err := cli.Logs(ctx, types.LogOptions{ID: testContainerID, Tail: "2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		bs, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the stderr is red
+		if string(bs) != "line 1\r\n\x1b[31mline 2\x1b[0m\r\n" {
+			t.Errorf("unexpected logs %q", bs)
+		}
+		f.m.Lock()
+		if !strings.Contains(f.logs, "tail=2") || !strings.Contains(f.logs, "follow=false") {
+			t.Errorf("unexpected logs query %s", f.logs)
+		}
+		f.m.Unlock()
+
+		since := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		rc, err = cli.Logs(ctx, types.LogOptions{
+			ID:         testContainerID,
+			Since:      since,
+			Timestamps: true,
+			Stream:     "stderr",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.ReadAll(rc)
+		rc.Close()
+		f.m.Lock()
+		for _, q := range []string{"since=2024-05-01T10%3A00%3A00Z", "timestamps=true",
+			"stdout=false", "stderr=true"} {
+			if !strings.Contains(f.logs, q) {
+				t.Errorf("expect %s in the logs query %s", q, f.logs)
+			}
+		}
+		f.m.Unlock()
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		for _, action := range []func(context.Context, string) error{
+			cli.Start, cli.Stop, cli.Restart,
+		} {
+			if err := action(ctx, testContainerID); err != nil {
+				t.Error(err)
+			}
+		}
+		f.m.Lock()
+		if got := strings.Join(f.actions, ","); got != "start,stop,restart" {
+			t.Errorf("unexpected actions %s", got)
+		}
+		f.m.Unlock()
+
+		err := cli.Start(ctx, "not-exist")
+		if err == nil || !strings.Contains(err.Error(), "no such container") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
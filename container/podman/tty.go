@@ -0,0 +1,66 @@
+package podman
+
+import (
+	"bufio"
+	"net"
+	"time"
+)
+
+// execInjector implement webtty.Slave
+type execInjector struct {
+	conn       net.Conn
+	reader     *bufio.Reader
+	resize     resizeFunction
+	activeChan chan struct{}
+}
+
+type resizeFunction func(width int, height int) error
+
+func newExecInjector(conn net.Conn, reader *bufio.Reader, resize resizeFunction) *execInjector {
+	return &execInjector{
+		conn:       conn,
+		reader:     reader,
+		resize:     resize,
+		activeChan: make(chan struct{}, 5),
+	}
+}
+
+func (enj *execInjector) Read(p []byte) (n int, err error) {
+	go func() {
+		if len(enj.activeChan) != 0 {
+			return
+		}
+		enj.activeChan <- struct{}{}
+	}()
+	return enj.reader.Read(p)
+}
+
+func (enj *execInjector) Write(p []byte) (n int, err error) {
+	return enj.conn.Write(p)
+}
+
+func (enj *execInjector) Exit() error {
+	enj.Write([]byte{3, 13, 4, 13}) // ^C, ^D, enter
+	close(enj.activeChan)
+	return enj.conn.Close()
+}
+
+func (enj *execInjector) ActiveChan() <-chan struct{} {
+	return enj.activeChan
+}
+
+func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (enj *execInjector) ResizeTerminal(width int, height int) (err error) {
+	// since the process may not up so fast, give it 150ms
+	// retry 3 times
+	for i := 0; i < 3; i++ {
+		if err = enj.resize(width, height); err == nil {
+			return
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	return
+}
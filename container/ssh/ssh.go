@@ -0,0 +1,444 @@
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
+)
+
+const dialTimeout = time.Second * 3
+
+// defaultKeys are tried when there is no key configured
+var defaultKeys = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// host is a configured ssh host
+type host struct {
+	id   string
+	name string // as configured
+	user string
+	addr string // host:port
+
+	m      sync.Mutex
+	client *ssh.Client
+}
+
+// SshCli treats every configured host as a container
+type SshCli struct {
+	hosts      []*host
+	config     ssh.ClientConfig
+	logFile    string
+	containers *types.Containers
+}
+
+func NewCli(conf config.SSHConfig) (*SshCli, error) {
+	if len(conf.Hosts) == 0 {
+		return nil, fmt.Errorf("no ssh hosts configured")
+	}
+
+	defaultUser := conf.User
+	if defaultUser == "" {
+		u, err := user.Current()
+		if err != nil {
+			return nil, fmt.Errorf("get current user error: %s", err)
+		}
+		defaultUser = u.Username
+	}
+
+	auth, err := authMethods(conf)
+	if err != nil {
+		return nil, err
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	if conf.Insecure {
+		logrus.Warn("ssh host keys are not verified")
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		hostKeyCallback, err = knownhosts.New(conf.KnownHosts)
+		if err != nil {
+			return nil, fmt.Errorf("load known hosts error: %s", err)
+		}
+	}
+
+	cli := &SshCli{
+		config: ssh.ClientConfig{
+			Auth:            auth,
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         dialTimeout,
+		},
+		logFile:    conf.LogFile,
+		containers: &types.Containers{},
+	}
+	for _, h := range conf.Hosts {
+		cli.hosts = append(cli.hosts, parseHost(h, defaultUser))
+	}
+	logrus.Infof("New ssh client: hosts %v", conf.Hosts)
+	cli.List(context.Background())
+
+	return cli, nil
+}
+
+// parseHost parses "[user@]host[:port]"
+func parseHost(h, defaultUser string) *host {
+	sum := sha256.Sum256([]byte(h))
+	parsed := &host{
+		id:   hex.EncodeToString(sum[:]),
+		name: h,
+		user: defaultUser,
+		addr: h,
+	}
+	if i := strings.LastIndex(h, "@"); i != -1 {
+		parsed.user, parsed.addr = h[:i], h[i+1:]
+	}
+	if _, _, err := net.SplitHostPort(parsed.addr); err != nil {
+		parsed.addr = net.JoinHostPort(strings.Trim(parsed.addr, "[]"), "22")
+	}
+	return parsed
+}
+
+func authMethods(conf config.SSHConfig) ([]ssh.AuthMethod, error) {
+	methods := []ssh.AuthMethod{}
+
+	keys := []string{conf.KeyPath}
+	if conf.KeyPath == "" {
+		keys = keys[:0]
+		for _, k := range defaultKeys {
+			keys = append(keys, filepath.Join(util.HomeDIR(), ".ssh", k))
+		}
+	}
+	signers := []ssh.Signer{}
+	for _, k := range keys {
+		bs, err := os.ReadFile(k)
+		if err != nil {
+			if conf.KeyPath != "" {
+				return nil, fmt.Errorf("read ssh key error: %s", err)
+			}
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(bs)
+		if err != nil {
+			return nil, fmt.Errorf("parse ssh key %s error: %s", k, err)
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) != 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	if conf.Password != "" {
+		methods = append(methods, ssh.Password(conf.Password))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no ssh auth method available, set the key or the password")
+	}
+	return methods, nil
+}
+
+// connect returns the connected client of the host, reconnects if
+// the connection is broken
+func (cli *SshCli) connect(h *host) (*ssh.Client, error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.client != nil {
+		if _, _, err := h.client.SendRequest("keepalive@openssh.com", true, nil); err == nil {
+			return h.client, nil
+		}
+		h.client.Close()
+		h.client = nil
+	}
+
+	conf := cli.config
+	conf.User = h.user
+	client, err := ssh.Dial("tcp", h.addr, &conf)
+	if err != nil {
+		return nil, err
+	}
+	h.client = client
+	return client, nil
+}
+
+func (cli *SshCli) convert2Container(h *host, client *ssh.Client, err error) types.Container {
+	c := types.Container{
+		ID:      h.id,
+		Name:    h.name,
+		Image:   "ssh",
+		Command: h.user + "@" + h.addr,
+		IPs:     []string{"null"},
+		State:   "running",
+		Status:  "connected",
+	}
+	if err != nil {
+		c.State = "unreachable"
+		c.Status = err.Error()
+		return c
+	}
+	c.Image = string(client.ServerVersion())
+	if addr, ok := client.RemoteAddr().(*net.TCPAddr); ok {
+		c.IPs = []string{addr.IP.String()}
+	}
+	return c
+}
+
+func (cli *SshCli) List(ctx context.Context) []types.Container {
+	containers := make([]types.Container, len(cli.hosts))
+
+	wg := sync.WaitGroup{}
+	for i, h := range cli.hosts {
+		wg.Add(1)
+		go func(i int, h *host) {
+			defer wg.Done()
+			client, err := cli.connect(h)
+			if err != nil {
+				logrus.Errorf("connect to %s error: %s", h.name, err)
+			}
+			containers[i] = cli.convert2Container(h, client, err)
+			if old := cli.containers.Find(h.id); old.ID == h.id {
+				containers[i].Shell = old.Shell
+			}
+		}(i, h)
+	}
+	wg.Wait()
+
+	cli.containers.Set(containers)
+	return containers
+}
+
+func (cli *SshCli) find(cid string) (*host, error) {
+	c := cli.containers.Find(cid)
+	for _, h := range cli.hosts {
+		if h.id == c.ID {
+			return h, nil
+		}
+	}
+	return nil, fmt.Errorf("host %s not found", cid)
+}
+
+func (cli *SshCli) GetInfo(ctx context.Context, cid string) types.Container {
+	if cli.containers.Len() == 0 {
+		cli.List(ctx)
+	}
+
+	container := cli.containers.Find(cid)
+	if container.ID == "" {
+		return types.Container{}
+	}
+	if container.Shell == "" {
+		shell := cli.getShell(container.ID)
+		container.Shell = shell
+		cli.containers.SetShell(container.ID, shell)
+	}
+	return container
+}
+
+// getShell returns the login shell of the user
+func (cli *SshCli) getShell(cid string) string {
+	h, err := cli.find(cid)
+	if err != nil {
+		return ""
+	}
+	client, err := cli.connect(h)
+	if err != nil {
+		logrus.Errorf("connect to %s error: %s", h.name, err)
+		return ""
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		return ""
+	}
+	defer session.Close()
+
+	output, err := session.Output("echo $SHELL")
+	if shell := strings.TrimSpace(string(output)); err == nil && shell != "" {
+		return shell
+	}
+	return "/bin/sh"
+}
+
+func (cli *SshCli) Start(ctx context.Context, cid string) error {
+	return fmt.Errorf("start is not supported by the ssh backend")
+}
+
+func (cli *SshCli) Stop(ctx context.Context, cid string) error {
+	return fmt.Errorf("stop is not supported by the ssh backend")
+}
+
+func (cli *SshCli) Restart(ctx context.Context, cid string) error {
+	return fmt.Errorf("restart is not supported by the ssh backend")
+}
+
+// newSession opens a session with a PTY
+func (cli *SshCli) newSession(cid string) (*ssh.Session, error) {
+	h, err := cli.find(cid)
+	if err != nil {
+		return nil, err
+	}
+	client, err := cli.connect(h)
+	if err != nil {
+		return nil, err
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.RequestPty("xterm", 40, 80, ssh.TerminalModes{
+		ssh.ECHO: 1,
+	}); err != nil {
+		session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+func (cli *SshCli) Exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	opts := c.Exec
+	if opts.User != "" {
+		return nil, fmt.Errorf("exec as user %s is not supported by the ssh backend, "+
+			"set the user in the host address", opts.User)
+	}
+	if opts.Privileged {
+		return nil, fmt.Errorf("privileged exec is not supported by the ssh backend")
+	}
+	if opts.Debug {
+		return nil, fmt.Errorf("the debug mode is not supported by the ssh backend")
+	}
+
+	session, err := cli.newSession(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+
+	// most servers refuse the environments, export them in the command
+	cmd := opts.Cmd
+	if len(opts.Args) != 0 {
+		// the remote command is always run by the login shell
+		cmd = "exec " + util.ShellQuote(opts.Args)
+	}
+	if opts.Env != "" {
+		envs, err := util.ShellEnv(opts.Env)
+		if err != nil {
+			session.Close()
+			return nil, err
+		}
+		if cmd == "" {
+			cmd = "exec " + c.Shell + " -l"
+		}
+		cmd = "export " + envs + "; " + cmd
+	}
+	logrus.Debugf("exec cmd: %q", cmd)
+
+	if cmd == "" {
+		err = session.Shell()
+	} else {
+		err = session.Start(cmd)
+	}
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+
+	return newExecInjector(session, stdin, stdout), nil
+}
+
+func (cli *SshCli) Close() error {
+	for _, h := range cli.hosts {
+		h.m.Lock()
+		if h.client != nil {
+			h.client.Close()
+		}
+		h.m.Unlock()
+	}
+	return nil
+}
+
+// logsCommand tails the configured log file, or the journal
+func (cli *SshCli) logsCommand(opts types.LogOptions) string {
+	tail := util.ParseTail(opts.Tail)
+	if cli.logFile != "" {
+		cmd := "tail -n +1"
+		if tail >= 0 {
+			cmd = fmt.Sprintf("tail -n %d", tail)
+		}
+		if opts.Follow {
+			cmd += " -F"
+		}
+		return cmd + " " + cli.logFile
+	}
+
+	cmd := "journalctl --no-pager"
+	if tail >= 0 {
+		cmd += fmt.Sprintf(" -n %d", tail)
+	}
+	if opts.Follow {
+		cmd += " -f"
+	}
+	return cmd
+}
+
+func (cli *SshCli) Logs(ctx context.Context, opts types.LogOptions) (io.ReadCloser, error) {
+	// with the PTY, the command is killed once the session closed
+	session, err := cli.newSession(opts.ID)
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	cmd := cli.logsCommand(opts)
+	logrus.Debugf("logs cmd: %q", cmd)
+	if err := session.Start(cmd); err != nil {
+		session.Close()
+		return nil, err
+	}
+
+	return &logReader{
+		Reader:  stdout,
+		session: session,
+	}, nil
+}
+
+type logReader struct {
+	io.Reader
+	session *ssh.Session
+}
+
+func (r *logReader) Close() error {
+	return r.session.Close()
+}
@@ -0,0 +1,252 @@
+package ssh
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/wrfly/container-web-tty/config"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// fakeServer is an ssh server whose shell echoes the inputs
+type fakeServer struct {
+	l      net.Listener
+	config *ssh.ServerConfig
+
+	m        sync.Mutex
+	commands []string
+	resized  [][2]uint32
+}
+
+func newFakeServer(t *testing.T) (*fakeServer, ssh.PublicKey) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeServer{
+		config: &ssh.ServerConfig{
+			PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+				if c.User() == "tester" && string(pass) == "secret" {
+					return nil, nil
+				}
+				return nil, io.EOF
+			},
+		},
+	}
+	s.config.AddHostKey(signer)
+
+	s.l, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.l.Close() })
+	go s.serve()
+
+	return s, signer.PublicKey()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			go ssh.DiscardRequests(reqs)
+			for newChan := range chans {
+				if newChan.ChannelType() != "session" {
+					newChan.Reject(ssh.UnknownChannelType, "unknown channel type")
+					continue
+				}
+				ch, reqs, err := newChan.Accept()
+				if err != nil {
+					continue
+				}
+				go s.handleSession(ch, reqs)
+			}
+		}()
+	}
+}
+
+func (s *fakeServer) handleSession(ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+	for req := range reqs {
+		switch req.Type {
+		case "pty-req":
+			req.Reply(true, nil)
+		case "window-change":
+			s.m.Lock()
+			s.resized = append(s.resized, [2]uint32{
+				binary.BigEndian.Uint32(req.Payload),
+				binary.BigEndian.Uint32(req.Payload[4:]),
+			})
+			s.m.Unlock()
+		case "shell":
+			req.Reply(true, nil)
+			go s.run(ch, "")
+		case "exec":
+			cmd := struct{ Command string }{}
+			ssh.Unmarshal(req.Payload, &cmd)
+			req.Reply(true, nil)
+			go s.run(ch, cmd.Command)
+		default:
+			req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *fakeServer) run(ch ssh.Channel, cmd string) {
+	s.m.Lock()
+	s.commands = append(s.commands, cmd)
+	s.m.Unlock()
+
+	switch {
+	case cmd == "echo $SHELL":
+		io.WriteString(ch, "/bin/bash\n")
+	case strings.HasPrefix(cmd, "tail"), strings.HasPrefix(cmd, "journalctl"):
+		io.WriteString(ch, "line 1\r\nline 2\r\n")
+	default:
+		io.WriteString(ch, "$ ")
+		io.Copy(ch, ch)
+	}
+	ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+	ch.Close()
+}
+
+func (s *fakeServer) lastCommand() string {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.commands[len(s.commands)-1]
+}
+
+func TestSSH(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	s, hostKey := newFakeServer(t)
+	addr := s.l.Addr().String()
+	knownHosts := filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(addr)}, hostKey)
+	if err := os.WriteFile(knownHosts, []byte(line+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cli, err := NewCli(config.SSHConfig{
+		Hosts:      []string{"tester@" + addr, "tester@127.0.0.1:1"},
+		Password:   "secret",
+		KnownHosts: knownHosts,
+		LogFile:    "/var/log/syslog",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cli.Close()
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		containers := cli.List(ctx)
+		if len(containers) != 2 {
+			t.Fatalf("expect 2 hosts, got %d", len(containers))
+		}
+		if c := containers[0]; c.State != "running" || c.IPs[0] != "127.0.0.1" {
+			t.Errorf("unexpected host %+v", c)
+		}
+		if c := containers[1]; c.State != "unreachable" {
+			t.Errorf("unexpected host %+v", c)
+		}
+	})
+
+	id := cli.List(ctx)[0].ID
+
+	t.Run("get info", func(t *testing.T) {
+		c := cli.GetInfo(ctx, id[:12])
+		if c.ID != id {
+			t.Fatalf("host not found: %+v", c)
+		}
+		if c.Shell != "/bin/bash" {
+			t.Errorf("expect shell /bin/bash, got %q", c.Shell)
+		}
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		c := cli.GetInfo(ctx, id)
+		c.Exec = types.ExecOptions{Env: "A=1 B=$(id);reboot"}
+		tty, err := cli.Exec(ctx, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tty.Exit()
+
+		if cmd := s.lastCommand(); cmd != "export A='1' B='$(id);reboot'; exec /bin/bash -l" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+		if err := tty.ResizeTerminal(80, 24); err != nil {
+			t.Error(err)
+		}
+		if _, err := tty.Write([]byte("ls\n")); err != nil {
+			t.Fatal(err)
+		}
+		line, err := bufio.NewReader(tty).ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line != "$ ls\n" {
+			t.Errorf("unexpected output %q", line)
+		}
+		s.m.Lock()
+		if len(s.resized) == 0 || s.resized[0] != [2]uint32{80, 24} {
+			t.Errorf("unexpected resize %v", s.resized)
+		}
+		s.m.Unlock()
+	})
+
+	t.Run("unsupported exec options", func(t *testing.T) {
+		c := cli.GetInfo(ctx, id)
+		c.Exec = types.ExecOptions{User: "root"}
+		if _, err := cli.Exec(ctx, c); err == nil {
+			t.Error("expect error of the user option")
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		rc, err := cli.Logs(ctx, types.LogOptions{ID: id, Tail: "2", Follow: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rc.Close()
+		bs, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bs) != "line 1\r\nline 2\r\n" {
+			t.Errorf("unexpected logs %q", bs)
+		}
+		if cmd := s.lastCommand(); cmd != "tail -n 2 -F /var/log/syslog" {
+			t.Errorf("unexpected command %q", cmd)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package ssh
+
+import (
+	"io"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// execInjector implement webtty.Slave
+type execInjector struct {
+	session    *ssh.Session
+	stdin      io.WriteCloser
+	stdout     io.Reader
+	activeChan chan struct{}
+}
+
+func newExecInjector(session *ssh.Session, stdin io.WriteCloser, stdout io.Reader) *execInjector {
+	return &execInjector{
+		session:    session,
+		stdin:      stdin,
+		stdout:     stdout,
+		activeChan: make(chan struct{}, 5),
+	}
+}
+
+func (enj *execInjector) Read(p []byte) (n int, err error) {
+	go func() {
+		if len(enj.activeChan) != 0 {
+			return
+		}
+		enj.activeChan <- struct{}{}
+	}()
+	return enj.stdout.Read(p)
+}
+
+func (enj *execInjector) Write(p []byte) (n int, err error) {
+	return enj.stdin.Write(p)
+}
+
+func (enj *execInjector) Exit() error {
+	// the server hangs up the processes once the session closed
+	enj.stdin.Close()
+	close(enj.activeChan)
+	return enj.session.Close()
+}
+
+func (enj *execInjector) ActiveChan() <-chan struct{} {
+	return enj.activeChan
+}
+
+func (enj *execInjector) WindowTitleVariables() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (enj *execInjector) ResizeTerminal(width int, height int) (err error) {
+	// since the process may not up so fast, give it 150ms
+	// retry 3 times
+	for i := 0; i < 3; i++ {
+		if err = enj.session.WindowChange(height, width); err == nil {
+			return
+		}
+		time.Sleep(time.Millisecond * 50)
+	}
+	return
+}
@@ -53,16 +53,40 @@ func main() {
 			Aliases:     []string{"b"},
 			EnvVars:     util.EnvVars("backend"),
 			Value:       "docker",
-			Usage:       "backend type, 'docker' or 'kube' or 'grpc'(remote)",
+			Usage:       "backend type, 'docker' or 'kube' or 'containerd' or 'podman' or 'cri' or 'ssh' or 'local' or 'grpc'(remote), several backends are separated by commas, e.g. 'docker,kube'",
 			Destination: &conf.Backend.Type,
 		},
+		&cli.StringFlag{
+			Name:        "docker-debug-image",
+			EnvVars:     util.EnvVars("docker-debug-image"),
+			Usage:       "tools image of the helper container for '?debug=1' exec, e.g. 'busybox', disabled if empty",
+			Destination: &conf.Backend.Docker.DebugImage,
+		},
 		&cli.StringFlag{
 			Name:        "docker-host",
 			EnvVars:     append(util.EnvVars("docker-host"), "DOCKER_HOST"),
 			Value:       "/var/run/docker.sock",
-			Usage:       "docker host path",
+			Usage:       "docker host path, or several hosts separated by commas, e.g. '/var/run/docker.sock,tcp://10.0.0.2:2376,ssh://user@10.0.0.3'",
 			Destination: &conf.Backend.Docker.DockerHost,
 		},
+		&cli.StringFlag{
+			Name:        "docker-cert-path",
+			EnvVars:     append(util.EnvVars("docker-cert-path"), "DOCKER_CERT_PATH"),
+			Usage:       "directory of the TLS client certs (ca.pem, cert.pem and key.pem) of the tcp docker hosts, override it per host with 'tcp://host:2376?certs=/path'",
+			Destination: &conf.Backend.Docker.CertPath,
+		},
+		&cli.StringFlag{
+			Name:        "docker-config",
+			EnvVars:     append(util.EnvVars("docker-config"), "DOCKER_CONFIG"),
+			Value:       util.DockerConfigDir(),
+			Usage:       "docker CLI config directory, the store of the docker contexts",
+			Destination: &conf.Backend.Docker.ConfigDir,
+		},
+		&cli.StringFlag{
+			Name:    "docker-contexts",
+			EnvVars: util.EnvVars("docker-contexts"),
+			Usage:   "docker contexts to serve, use comma for split, 'all' for all contexts, new contexts are picked up without restart",
+		},
 		&cli.StringFlag{
 			Name:        "docker-ps",
 			EnvVars:     util.EnvVars("docker-ps"),
@@ -73,9 +97,148 @@ func main() {
 			Name:        "kube-config",
 			EnvVars:     util.EnvVars("kube-config"),
 			Value:       util.KubeConfigPath(),
-			Usage:       "kube config path",
+			Usage:       "kube config path, several paths are separated like $KUBECONFIG",
 			Destination: &conf.Backend.Kube.ConfigPath,
 		},
+		&cli.StringFlag{
+			Name:    "kube-contexts",
+			EnvVars: util.EnvVars("kube-contexts"),
+			Usage:   "kube contexts to serve, use comma for split, 'all' for all contexts, default is the current context",
+		},
+		&cli.StringFlag{
+			Name:        "kube-debug-image",
+			EnvVars:     util.EnvVars("kube-debug-image"),
+			Value:       "busybox",
+			Usage:       "image of the ephemeral container for '?debug=1' exec, empty to disable",
+			Destination: &conf.Backend.Kube.DebugImage,
+		},
+		&cli.StringFlag{
+			Name:    "kube-namespaces",
+			EnvVars: util.EnvVars("kube-namespaces"),
+			Usage:   "kube namespaces to list, use comma for split, default is all",
+		},
+		&cli.StringFlag{
+			Name:    "kube-exclude-namespaces",
+			EnvVars: util.EnvVars("kube-exclude-namespaces"),
+			Usage:   "kube namespaces not to list, use comma for split",
+		},
+		&cli.StringFlag{
+			Name:        "kube-selector",
+			EnvVars:     util.EnvVars("kube-selector"),
+			Usage:       "kube label selector of the pods, e.g. 'app=nginx,tier!=cache'",
+			Destination: &conf.Backend.Kube.LabelSelector,
+		},
+		&cli.StringFlag{
+			Name:        "kube-field-selector",
+			EnvVars:     util.EnvVars("kube-field-selector"),
+			Usage:       "kube field selector of the pods, e.g. 'spec.nodeName=node1,status.phase=Running'",
+			Destination: &conf.Backend.Kube.FieldSelector,
+		},
+		&cli.BoolFlag{
+			Name:        "kube-only-ready",
+			EnvVars:     util.EnvVars("kube-only-ready"),
+			Usage:       "only list the ready containers of the running pods",
+			Destination: &conf.Backend.Kube.OnlyReady,
+		},
+		&cli.StringFlag{
+			Name:        "containerd-address",
+			EnvVars:     util.EnvVars("containerd-address"),
+			Value:       "/run/containerd/containerd.sock",
+			Usage:       "containerd socket path",
+			Destination: &conf.Backend.Containerd.Address,
+		},
+		&cli.StringFlag{
+			Name:    "containerd-namespaces",
+			EnvVars: util.EnvVars("containerd-namespaces"),
+			Usage:   "containerd namespaces to list, use comma for split, default is all",
+		},
+		&cli.StringFlag{
+			Name:        "containerd-fifo-dir",
+			EnvVars:     util.EnvVars("containerd-fifo-dir"),
+			Value:       "/run/container-web-tty/fifo",
+			Usage:       "directory of the exec FIFOs, must be reachable by containerd",
+			Destination: &conf.Backend.Containerd.FifoDir,
+		},
+		&cli.StringFlag{
+			Name:        "cri-endpoint",
+			EnvVars:     append(util.EnvVars("cri-endpoint"), "CONTAINER_RUNTIME_ENDPOINT"),
+			Value:       "/var/run/crio/crio.sock",
+			Usage:       "CRI runtime endpoint, CRI-O or containerd socket",
+			Destination: &conf.Backend.CRI.Endpoint,
+		},
+		&cli.StringFlag{
+			Name:        "podman-host",
+			EnvVars:     append(util.EnvVars("podman-host"), "CONTAINER_HOST"),
+			Value:       "/run/podman/podman.sock",
+			Usage:       "podman API socket path, or unix:// and tcp:// address",
+			Destination: &conf.Backend.Podman.Host,
+		},
+		&cli.StringFlag{
+			Name:    "ssh-hosts",
+			EnvVars: util.EnvVars("ssh-hosts"),
+			Usage:   "ssh hosts in the format of [user@]host[:port], use comma for split",
+		},
+		&cli.StringFlag{
+			Name:        "ssh-user",
+			EnvVars:     util.EnvVars("ssh-user"),
+			Usage:       "ssh user of the hosts without a user, default is the current user",
+			Destination: &conf.Backend.SSH.User,
+		},
+		&cli.StringFlag{
+			Name:        "ssh-key",
+			EnvVars:     util.EnvVars("ssh-key"),
+			Usage:       "ssh private key path, default is ~/.ssh/id_{ed25519,ecdsa,rsa}",
+			Destination: &conf.Backend.SSH.KeyPath,
+		},
+		&cli.StringFlag{
+			Name:        "ssh-password",
+			EnvVars:     util.EnvVars("ssh-password"),
+			Usage:       "ssh password",
+			Destination: &conf.Backend.SSH.Password,
+		},
+		&cli.StringFlag{
+			Name:        "ssh-known-hosts",
+			EnvVars:     util.EnvVars("ssh-known-hosts"),
+			Value:       util.KnownHostsPath(),
+			Usage:       "ssh known hosts path",
+			Destination: &conf.Backend.SSH.KnownHosts,
+		},
+		&cli.BoolFlag{
+			Name:        "ssh-insecure",
+			EnvVars:     util.EnvVars("ssh-insecure"),
+			Usage:       "skip the ssh host key checking",
+			Destination: &conf.Backend.SSH.Insecure,
+		},
+		&cli.StringFlag{
+			Name:        "ssh-log-file",
+			EnvVars:     util.EnvVars("ssh-log-file"),
+			Usage:       "log file of the ssh hosts, use journalctl if empty",
+			Destination: &conf.Backend.SSH.LogFile,
+		},
+		&cli.StringFlag{
+			Name:        "local-shell",
+			EnvVars:     util.EnvVars("local-shell"),
+			Usage:       "shell of the local backend, default is $SHELL",
+			Destination: &conf.Backend.Local.Shell,
+		},
+		&cli.StringFlag{
+			Name:        "local-chroot",
+			EnvVars:     util.EnvVars("local-chroot"),
+			Usage:       "run the local shell in this root directory",
+			Destination: &conf.Backend.Local.Chroot,
+		},
+		&cli.StringFlag{
+			Name:        "local-dir",
+			EnvVars:     util.EnvVars("local-dir"),
+			Usage:       "working directory of the local shell",
+			Destination: &conf.Backend.Local.Dir,
+		},
+		&cli.StringFlag{
+			Name:        "local-log-file",
+			EnvVars:     util.EnvVars("local-log-file"),
+			Usage:       "log file of the local backend",
+			Destination: &conf.Backend.Local.LogFile,
+		},
 		&cli.IntFlag{
 			Name:        "grpc-port",
 			EnvVars:     util.EnvVars("grpc-port"),
@@ -198,6 +361,34 @@ func main() {
 			if servers[0] != "" {
 				conf.Backend.GRPC.Servers = servers
 			}
+			dockerHosts := strings.Split(c.String("docker-host"), ",")
+			if len(dockerHosts) > 1 {
+				conf.Backend.Docker.Hosts = dockerHosts
+			}
+			dockerContexts := strings.Split(c.String("docker-contexts"), ",")
+			if dockerContexts[0] != "" {
+				conf.Backend.Docker.Contexts = dockerContexts
+			}
+			kubeContexts := strings.Split(c.String("kube-contexts"), ",")
+			if kubeContexts[0] != "" {
+				conf.Backend.Kube.Contexts = kubeContexts
+			}
+			kubeNamespaces := strings.Split(c.String("kube-namespaces"), ",")
+			if kubeNamespaces[0] != "" {
+				conf.Backend.Kube.Namespaces = kubeNamespaces
+			}
+			excludeNamespaces := strings.Split(c.String("kube-exclude-namespaces"), ",")
+			if excludeNamespaces[0] != "" {
+				conf.Backend.Kube.ExcludeNamespaces = excludeNamespaces
+			}
+			namespaces := strings.Split(c.String("containerd-namespaces"), ",")
+			if namespaces[0] != "" {
+				conf.Backend.Containerd.Namespaces = namespaces
+			}
+			hosts := strings.Split(c.String("ssh-hosts"), ",")
+			if hosts[0] != "" {
+				conf.Backend.SSH.Hosts = hosts
+			}
 			if conf.Debug {
 				logrus.SetLevel(logrus.DebugLevel)
 			} else {
@@ -15,6 +15,11 @@ It has these top-level messages:
 	LogOpts
 	Container
 	Containers
+	Event
+	Stats
+	Process
+	Processes
+	SignalOpts
 	Io
 	WindowSize
 	ExecOptions
@@ -114,9 +119,13 @@ func (m *ContainerID) GetAuth() string {
 }
 
 type LogOpts struct {
-	C      *ContainerID `protobuf:"bytes,1,opt,name=c" json:"c,omitempty"`
-	Follow bool         `protobuf:"varint,2,opt,name=follow" json:"follow,omitempty"`
-	Tail   string       `protobuf:"bytes,3,opt,name=tail" json:"tail,omitempty"`
+	C          *ContainerID `protobuf:"bytes,1,opt,name=c" json:"c,omitempty"`
+	Follow     bool         `protobuf:"varint,2,opt,name=follow" json:"follow,omitempty"`
+	Tail       string       `protobuf:"bytes,3,opt,name=tail" json:"tail,omitempty"`
+	Since      int64        `protobuf:"varint,4,opt,name=since" json:"since,omitempty"`
+	Until      int64        `protobuf:"varint,5,opt,name=until" json:"until,omitempty"`
+	Timestamps bool         `protobuf:"varint,6,opt,name=timestamps" json:"timestamps,omitempty"`
+	Stream     string       `protobuf:"bytes,7,opt,name=stream" json:"stream,omitempty"`
 }
 
 func (m *LogOpts) Reset()                    { *m = LogOpts{} }
@@ -145,6 +154,34 @@ func (m *LogOpts) GetTail() string {
 	return ""
 }
 
+func (m *LogOpts) GetSince() int64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
+}
+
+func (m *LogOpts) GetUntil() int64 {
+	if m != nil {
+		return m.Until
+	}
+	return 0
+}
+
+func (m *LogOpts) GetTimestamps() bool {
+	if m != nil {
+		return m.Timestamps
+	}
+	return false
+}
+
+func (m *LogOpts) GetStream() string {
+	if m != nil {
+		return m.Stream
+	}
+	return ""
+}
+
 // Container instance
 type Container struct {
 	Id            string   `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
@@ -163,6 +200,11 @@ type Container struct {
 	ExecCmd       string   `protobuf:"bytes,14,opt,name=execCmd" json:"execCmd,omitempty"`
 	ExecUser      string   `protobuf:"bytes,15,opt,name=execUser" json:"execUser,omitempty"`
 	ExecEnv       string   `protobuf:"bytes,16,opt,name=execEnv" json:"execEnv,omitempty"`
+	Cluster       string   `protobuf:"bytes,17,opt,name=cluster" json:"cluster,omitempty"`
+	ExecDebug     bool     `protobuf:"varint,18,opt,name=execDebug" json:"execDebug,omitempty"`
+	ExecArgs      []string `protobuf:"bytes,19,rep,name=execArgs" json:"execArgs,omitempty"`
+	Stale         bool     `protobuf:"varint,20,opt,name=stale" json:"stale,omitempty"`
+	Workload      string   `protobuf:"bytes,21,opt,name=workload" json:"workload,omitempty"`
 }
 
 func (m *Container) Reset()                    { *m = Container{} }
@@ -282,6 +324,41 @@ func (m *Container) GetExecEnv() string {
 	return ""
 }
 
+func (m *Container) GetCluster() string {
+	if m != nil {
+		return m.Cluster
+	}
+	return ""
+}
+
+func (m *Container) GetExecDebug() bool {
+	if m != nil {
+		return m.ExecDebug
+	}
+	return false
+}
+
+func (m *Container) GetExecArgs() []string {
+	if m != nil {
+		return m.ExecArgs
+	}
+	return nil
+}
+
+func (m *Container) GetStale() bool {
+	if m != nil {
+		return m.Stale
+	}
+	return false
+}
+
+func (m *Container) GetWorkload() string {
+	if m != nil {
+		return m.Workload
+	}
+	return ""
+}
+
 type Containers struct {
 	Cs []*Container `protobuf:"bytes,1,rep,name=cs" json:"cs,omitempty"`
 }
@@ -298,6 +375,217 @@ func (m *Containers) GetCs() []*Container {
 	return nil
 }
 
+// event is a change of the listed containers,
+// only the id is set when it's removed
+type Event struct {
+	Action string     `protobuf:"bytes,1,opt,name=action" json:"action,omitempty"`
+	C      *Container `protobuf:"bytes,2,opt,name=c" json:"c,omitempty"`
+}
+
+func (m *Event) Reset()                    { *m = Event{} }
+func (m *Event) String() string            { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()               {}
+func (*Event) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Event) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *Event) GetC() *Container {
+	if m != nil {
+		return m.C
+	}
+	return nil
+}
+
+// stats is the resource usage of a container at a moment
+type Stats struct {
+	Id          string  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Time        int64   `protobuf:"varint,2,opt,name=time" json:"time,omitempty"`
+	CpuPercent  float64 `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent" json:"cpu_percent,omitempty"`
+	MemoryUsage uint64  `protobuf:"varint,4,opt,name=memory_usage,json=memoryUsage" json:"memory_usage,omitempty"`
+	MemoryLimit uint64  `protobuf:"varint,5,opt,name=memory_limit,json=memoryLimit" json:"memory_limit,omitempty"`
+	NetworkRx   uint64  `protobuf:"varint,6,opt,name=network_rx,json=networkRx" json:"network_rx,omitempty"`
+	NetworkTx   uint64  `protobuf:"varint,7,opt,name=network_tx,json=networkTx" json:"network_tx,omitempty"`
+	BlockRead   uint64  `protobuf:"varint,8,opt,name=block_read,json=blockRead" json:"block_read,omitempty"`
+	BlockWrite  uint64  `protobuf:"varint,9,opt,name=block_write,json=blockWrite" json:"block_write,omitempty"`
+}
+
+func (m *Stats) Reset()                    { *m = Stats{} }
+func (m *Stats) String() string            { return proto.CompactTextString(m) }
+func (*Stats) ProtoMessage()               {}
+func (*Stats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *Stats) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Stats) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Stats) GetCpuPercent() float64 {
+	if m != nil {
+		return m.CpuPercent
+	}
+	return 0
+}
+
+func (m *Stats) GetMemoryUsage() uint64 {
+	if m != nil {
+		return m.MemoryUsage
+	}
+	return 0
+}
+
+func (m *Stats) GetMemoryLimit() uint64 {
+	if m != nil {
+		return m.MemoryLimit
+	}
+	return 0
+}
+
+func (m *Stats) GetNetworkRx() uint64 {
+	if m != nil {
+		return m.NetworkRx
+	}
+	return 0
+}
+
+func (m *Stats) GetNetworkTx() uint64 {
+	if m != nil {
+		return m.NetworkTx
+	}
+	return 0
+}
+
+func (m *Stats) GetBlockRead() uint64 {
+	if m != nil {
+		return m.BlockRead
+	}
+	return 0
+}
+
+func (m *Stats) GetBlockWrite() uint64 {
+	if m != nil {
+		return m.BlockWrite
+	}
+	return 0
+}
+
+type Process struct {
+	Pid        int32   `protobuf:"varint,1,opt,name=pid" json:"pid,omitempty"`
+	User       string  `protobuf:"bytes,2,opt,name=user" json:"user,omitempty"`
+	CpuPercent float64 `protobuf:"fixed64,3,opt,name=cpu_percent,json=cpuPercent" json:"cpu_percent,omitempty"`
+	Memory     uint64  `protobuf:"varint,4,opt,name=memory" json:"memory,omitempty"`
+	Command    string  `protobuf:"bytes,5,opt,name=command" json:"command,omitempty"`
+	NoSignal   bool    `protobuf:"varint,6,opt,name=no_signal,json=noSignal" json:"no_signal,omitempty"`
+}
+
+func (m *Process) Reset()                    { *m = Process{} }
+func (m *Process) String() string            { return proto.CompactTextString(m) }
+func (*Process) ProtoMessage()               {}
+func (*Process) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *Process) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *Process) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Process) GetCpuPercent() float64 {
+	if m != nil {
+		return m.CpuPercent
+	}
+	return 0
+}
+
+func (m *Process) GetMemory() uint64 {
+	if m != nil {
+		return m.Memory
+	}
+	return 0
+}
+
+func (m *Process) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *Process) GetNoSignal() bool {
+	if m != nil {
+		return m.NoSignal
+	}
+	return false
+}
+
+type Processes struct {
+	Ps []*Process `protobuf:"bytes,1,rep,name=ps" json:"ps,omitempty"`
+}
+
+func (m *Processes) Reset()                    { *m = Processes{} }
+func (m *Processes) String() string            { return proto.CompactTextString(m) }
+func (*Processes) ProtoMessage()               {}
+func (*Processes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *Processes) GetPs() []*Process {
+	if m != nil {
+		return m.Ps
+	}
+	return nil
+}
+
+type SignalOpts struct {
+	C      *ContainerID `protobuf:"bytes,1,opt,name=c" json:"c,omitempty"`
+	Pid    int32        `protobuf:"varint,2,opt,name=pid" json:"pid,omitempty"`
+	Signal string       `protobuf:"bytes,3,opt,name=signal" json:"signal,omitempty"`
+}
+
+func (m *SignalOpts) Reset()                    { *m = SignalOpts{} }
+func (m *SignalOpts) String() string            { return proto.CompactTextString(m) }
+func (*SignalOpts) ProtoMessage()               {}
+func (*SignalOpts) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *SignalOpts) GetC() *ContainerID {
+	if m != nil {
+		return m.C
+	}
+	return nil
+}
+
+func (m *SignalOpts) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *SignalOpts) GetSignal() string {
+	if m != nil {
+		return m.Signal
+	}
+	return ""
+}
+
 type Io struct {
 	In  []byte `protobuf:"bytes,1,opt,name=in,proto3" json:"in,omitempty"`
 	Out []byte `protobuf:"bytes,2,opt,name=out,proto3" json:"out,omitempty"`
@@ -306,7 +594,7 @@ type Io struct {
 func (m *Io) Reset()                    { *m = Io{} }
 func (m *Io) String() string            { return proto.CompactTextString(m) }
 func (*Io) ProtoMessage()               {}
-func (*Io) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Io) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *Io) GetIn() []byte {
 	if m != nil {
@@ -330,7 +618,7 @@ type WindowSize struct {
 func (m *WindowSize) Reset()                    { *m = WindowSize{} }
 func (m *WindowSize) String() string            { return proto.CompactTextString(m) }
 func (*WindowSize) ProtoMessage()               {}
-func (*WindowSize) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*WindowSize) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *WindowSize) GetHeight() int32 {
 	if m != nil {
@@ -357,7 +645,7 @@ type ExecOptions struct {
 func (m *ExecOptions) Reset()                    { *m = ExecOptions{} }
 func (m *ExecOptions) String() string            { return proto.CompactTextString(m) }
 func (*ExecOptions) ProtoMessage()               {}
-func (*ExecOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*ExecOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ExecOptions) GetCmd() *Io {
 	if m != nil {
@@ -402,6 +690,11 @@ func init() {
 	proto.RegisterType((*LogOpts)(nil), "pbrpc.logOpts")
 	proto.RegisterType((*Container)(nil), "pbrpc.Container")
 	proto.RegisterType((*Containers)(nil), "pbrpc.Containers")
+	proto.RegisterType((*Event)(nil), "pbrpc.event")
+	proto.RegisterType((*Stats)(nil), "pbrpc.stats")
+	proto.RegisterType((*Process)(nil), "pbrpc.process")
+	proto.RegisterType((*Processes)(nil), "pbrpc.processes")
+	proto.RegisterType((*SignalOpts)(nil), "pbrpc.signalOpts")
 	proto.RegisterType((*Io)(nil), "pbrpc.io")
 	proto.RegisterType((*WindowSize)(nil), "pbrpc.windowSize")
 	proto.RegisterType((*ExecOptions)(nil), "pbrpc.execOptions")
@@ -426,6 +719,10 @@ type ContainerServerClient interface {
 	Exec(ctx context.Context, opts ...grpc.CallOption) (ContainerServer_ExecClient, error)
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Pong, error)
 	Logs(ctx context.Context, in *LogOpts, opts ...grpc.CallOption) (ContainerServer_LogsClient, error)
+	Watch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ContainerServer_WatchClient, error)
+	Stats(ctx context.Context, in *ContainerID, opts ...grpc.CallOption) (ContainerServer_StatsClient, error)
+	Top(ctx context.Context, in *ContainerID, opts ...grpc.CallOption) (*Processes, error)
+	Signal(ctx context.Context, in *SignalOpts, opts ...grpc.CallOption) (*Err, error)
 }
 
 type containerServerClient struct {
@@ -553,6 +850,88 @@ func (x *containerServerLogsClient) Recv() (*Io, error) {
 	return m, nil
 }
 
+func (c *containerServerClient) Watch(ctx context.Context, in *Empty, opts ...grpc.CallOption) (ContainerServer_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ContainerServer_serviceDesc.Streams[2], c.cc, "/pbrpc.containerServer/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &containerServerWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ContainerServer_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type containerServerWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServerWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *containerServerClient) Stats(ctx context.Context, in *ContainerID, opts ...grpc.CallOption) (ContainerServer_StatsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ContainerServer_serviceDesc.Streams[3], c.cc, "/pbrpc.containerServer/Stats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &containerServerStatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ContainerServer_StatsClient interface {
+	Recv() (*Stats, error)
+	grpc.ClientStream
+}
+
+type containerServerStatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *containerServerStatsClient) Recv() (*Stats, error) {
+	m := new(Stats)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *containerServerClient) Top(ctx context.Context, in *ContainerID, opts ...grpc.CallOption) (*Processes, error) {
+	out := new(Processes)
+	err := grpc.Invoke(ctx, "/pbrpc.containerServer/Top", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *containerServerClient) Signal(ctx context.Context, in *SignalOpts, opts ...grpc.CallOption) (*Err, error) {
+	out := new(Err)
+	err := grpc.Invoke(ctx, "/pbrpc.containerServer/Signal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ContainerServer service
 
 type ContainerServerServer interface {
@@ -564,6 +943,10 @@ type ContainerServerServer interface {
 	Exec(ContainerServer_ExecServer) error
 	Ping(context.Context, *Empty) (*Pong, error)
 	Logs(*LogOpts, ContainerServer_LogsServer) error
+	Watch(*Empty, ContainerServer_WatchServer) error
+	Stats(*ContainerID, ContainerServer_StatsServer) error
+	Top(context.Context, *ContainerID) (*Processes, error)
+	Signal(context.Context, *SignalOpts) (*Err, error)
 }
 
 func RegisterContainerServerServer(s *grpc.Server, srv ContainerServerServer) {
@@ -725,6 +1108,84 @@ func (x *containerServerLogsServer) Send(m *Io) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ContainerServer_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContainerServerServer).Watch(m, &containerServerWatchServer{stream})
+}
+
+type ContainerServer_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type containerServerWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *containerServerWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ContainerServer_Stats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContainerID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ContainerServerServer).Stats(m, &containerServerStatsServer{stream})
+}
+
+type ContainerServer_StatsServer interface {
+	Send(*Stats) error
+	grpc.ServerStream
+}
+
+type containerServerStatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *containerServerStatsServer) Send(m *Stats) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ContainerServer_Top_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainerID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServerServer).Top(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbrpc.containerServer/Top",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServerServer).Top(ctx, req.(*ContainerID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ContainerServer_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContainerServerServer).Signal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pbrpc.containerServer/Signal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContainerServerServer).Signal(ctx, req.(*SignalOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ContainerServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pbrpc.containerServer",
 	HandlerType: (*ContainerServerServer)(nil),
@@ -753,6 +1214,14 @@ var _ContainerServer_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _ContainerServer_Ping_Handler,
 		},
+		{
+			MethodName: "Top",
+			Handler:    _ContainerServer_Top_Handler,
+		},
+		{
+			MethodName: "Signal",
+			Handler:    _ContainerServer_Signal_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -766,6 +1235,16 @@ var _ContainerServer_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ContainerServer_Logs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _ContainerServer_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Stats",
+			Handler:       _ContainerServer_Stats_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api.proto",
 }
@@ -773,47 +1252,72 @@ var _ContainerServer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 664 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xdb, 0x38,
-	0x10, 0xb6, 0x7e, 0x1c, 0xdb, 0x23, 0xc7, 0x49, 0x88, 0xc5, 0x2e, 0xd7, 0xd9, 0x2d, 0x1c, 0x15,
-	0x29, 0x5c, 0x14, 0x30, 0x12, 0xb7, 0xa7, 0x5e, 0xd3, 0xa0, 0x08, 0x10, 0x24, 0x85, 0x8c, 0xa2,
-	0xc7, 0x40, 0x91, 0x18, 0x99, 0x80, 0x44, 0x12, 0x24, 0x1d, 0xa7, 0x7d, 0x8d, 0x3e, 0x44, 0x1f,
-	0xb0, 0x2f, 0x50, 0x90, 0xa2, 0xe4, 0x20, 0xf1, 0x21, 0xb7, 0xf9, 0x66, 0xbe, 0x19, 0x7d, 0xe2,
-	0x37, 0x24, 0x0c, 0x52, 0x41, 0x67, 0x42, 0x72, 0xcd, 0x51, 0x57, 0xdc, 0x4a, 0x91, 0xc5, 0x87,
-	0xd0, 0x25, 0x95, 0xd0, 0xdf, 0x11, 0x82, 0x30, 0x5d, 0xe9, 0x25, 0xf6, 0x26, 0xde, 0x74, 0x90,
-	0xd8, 0x38, 0xc6, 0x10, 0x0a, 0xce, 0x0a, 0xb4, 0x0f, 0x41, 0xa5, 0x0a, 0x57, 0x32, 0x61, 0xfc,
-	0x0f, 0x04, 0x44, 0x4a, 0x53, 0x20, 0x52, 0x36, 0x05, 0x22, 0x65, 0x7c, 0x0a, 0xd1, 0x19, 0x67,
-	0x3a, 0xa5, 0x8c, 0xc8, 0x8b, 0x4f, 0x68, 0x04, 0x3e, 0xcd, 0x5d, 0xdd, 0xa7, 0x79, 0xfb, 0x15,
-	0xff, 0xd1, 0x57, 0xbe, 0x41, 0xaf, 0xe4, 0xc5, 0xb5, 0xd0, 0x0a, 0x4d, 0xc0, 0xcb, 0x2c, 0x3b,
-	0x9a, 0xa3, 0x99, 0x15, 0x38, 0x7b, 0x34, 0x2d, 0xf1, 0x32, 0xf4, 0x37, 0xec, 0xdc, 0xf1, 0xb2,
-	0xe4, 0x6b, 0x3b, 0xa2, 0x9f, 0x38, 0x64, 0x06, 0xeb, 0x94, 0x96, 0x38, 0xa8, 0x07, 0x9b, 0x38,
-	0xfe, 0x15, 0xc0, 0xa0, 0x6d, 0xdf, 0x26, 0x85, 0xa5, 0x15, 0x69, 0xa4, 0x98, 0x18, 0xfd, 0x05,
-	0x5d, 0x5a, 0xa5, 0x05, 0x71, 0x63, 0x6a, 0x80, 0x30, 0xf4, 0x32, 0x5e, 0x55, 0x29, 0xcb, 0x71,
-	0x68, 0xf3, 0x0d, 0x34, 0x7c, 0xa5, 0x53, 0x4d, 0x70, 0xb7, 0xe6, 0x5b, 0x60, 0x34, 0x9a, 0x60,
-	0xa5, 0xf0, 0x8e, 0x4d, 0x3b, 0x64, 0x4e, 0x8b, 0x0a, 0x85, 0x7b, 0x93, 0xc0, 0x9c, 0x16, 0x15,
-	0xca, 0xf6, 0x2f, 0x49, 0x59, 0xe2, 0xbe, 0xeb, 0x37, 0x00, 0xfd, 0x0b, 0x7d, 0xc1, 0xf3, 0x1b,
-	0xab, 0x6e, 0x50, 0x7f, 0x50, 0xf0, 0xfc, 0xca, 0x08, 0x3c, 0x86, 0x51, 0xd6, 0xfc, 0x51, 0x4d,
-	0x00, 0x4b, 0xd8, 0x6d, 0xb3, 0x96, 0xf6, 0x1f, 0x0c, 0x4c, 0x51, 0x89, 0x34, 0x23, 0x38, 0xb2,
-	0x8c, 0x4d, 0x02, 0x1d, 0xc1, 0x50, 0xae, 0x18, 0xa3, 0xac, 0xb8, 0x61, 0x3c, 0x27, 0x78, 0x68,
-	0x09, 0x91, 0xcb, 0x5d, 0xf1, 0x9c, 0xa0, 0xff, 0x01, 0x4a, 0x9e, 0xdd, 0x28, 0x22, 0xef, 0x89,
-	0xc4, 0xbb, 0xf5, 0x84, 0x92, 0x67, 0x0b, 0x9b, 0x30, 0x27, 0x42, 0x1e, 0x48, 0x76, 0x56, 0xe5,
-	0x78, 0x54, 0x0b, 0x74, 0x10, 0x8d, 0xa1, 0x6f, 0xc2, 0xaf, 0x8a, 0x48, 0xbc, 0x67, 0x4b, 0x2d,
-	0x6e, 0xba, 0xce, 0xd9, 0x3d, 0xde, 0xdf, 0x74, 0x9d, 0xb3, 0xfb, 0x78, 0x06, 0xd0, 0x1a, 0x65,
-	0xb6, 0xc0, 0xcf, 0x14, 0xf6, 0x26, 0xc1, 0x34, 0x9a, 0xef, 0x3f, 0x5d, 0x83, 0xc4, 0xcf, 0x54,
-	0xfc, 0x06, 0x7c, 0xca, 0xad, 0xa3, 0xcc, 0x3a, 0x3a, 0x4c, 0x7c, 0xca, 0xcc, 0xf9, 0xf2, 0x95,
-	0xb6, 0x86, 0x0e, 0x13, 0x13, 0xc6, 0x1f, 0x01, 0xd6, 0x94, 0xe5, 0x7c, 0xbd, 0xa0, 0x3f, 0xac,
-	0x2f, 0x4b, 0x42, 0x8b, 0xa5, 0xb6, 0x3d, 0xdd, 0xc4, 0x21, 0xe3, 0xc2, 0x9a, 0xe6, 0x6e, 0x2b,
-	0xbb, 0x49, 0x0d, 0xe2, 0x9f, 0x1e, 0x44, 0x46, 0xdf, 0xb5, 0xd0, 0x94, 0x33, 0x85, 0x0e, 0x21,
-	0xc8, 0xaa, 0xdc, 0x6d, 0xe7, 0xc0, 0xc9, 0xa2, 0x3c, 0x31, 0x59, 0xf4, 0xca, 0x2c, 0xae, 0x6f,
-	0x4b, 0xcf, 0x15, 0x7b, 0x59, 0x73, 0x51, 0x82, 0xf6, 0xa2, 0xb4, 0x37, 0x21, 0xdc, 0xdc, 0x04,
-	0x74, 0x04, 0xfe, 0x5a, 0xd9, 0x5d, 0x8a, 0xe6, 0x07, 0x6e, 0xcc, 0x46, 0x7f, 0xe2, 0xaf, 0xd5,
-	0xfc, 0xb7, 0x0f, 0x7b, 0xad, 0xd7, 0xce, 0x8d, 0x53, 0xe8, 0x7d, 0x26, 0xfa, 0x82, 0xdd, 0x71,
-	0xb4, 0xe5, 0xd6, 0x8c, 0x9f, 0x09, 0x8a, 0x3b, 0xe8, 0x2d, 0x84, 0x97, 0x54, 0x69, 0x34, 0x74,
-	0x35, 0xfb, 0x06, 0x8c, 0x0f, 0x9e, 0x32, 0x95, 0xa5, 0x76, 0x17, 0x3a, 0x95, 0x7a, 0xeb, 0x6c,
-	0x68, 0xfa, 0xa5, 0x99, 0x3a, 0x85, 0x70, 0xa1, 0xb9, 0x78, 0x01, 0xf3, 0x1d, 0xf4, 0x12, 0xa2,
-	0x5e, 0x38, 0xf6, 0x03, 0x84, 0xe7, 0x0f, 0x24, 0x6b, 0x99, 0x8f, 0x5c, 0x19, 0x6f, 0xc9, 0xc5,
-	0x9d, 0xa9, 0x77, 0xe2, 0xa1, 0xd7, 0x10, 0x7e, 0xa1, 0xac, 0x78, 0xf2, 0x8b, 0x91, 0x43, 0xe6,
-	0x5d, 0x8b, 0x3b, 0xe8, 0x18, 0xc2, 0x4b, 0x5e, 0x28, 0x34, 0x72, 0x69, 0xf7, 0x10, 0x8d, 0x37,
-	0xfe, 0xc6, 0x9d, 0x13, 0xef, 0x76, 0xc7, 0xbe, 0x99, 0xef, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff,
-	0xd5, 0x70, 0x81, 0x92, 0x40, 0x05, 0x00, 0x00,
+	// 1060 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0x1c, 0xb5,
+	0x1b, 0xcf, 0x1c, 0x36, 0x9b, 0xf9, 0x36, 0x4d, 0x5b, 0xff, 0xfb, 0x2f, 0x26, 0x81, 0xb0, 0x1d,
+	0x54, 0x08, 0xaa, 0x88, 0xda, 0xc0, 0x15, 0x37, 0x08, 0xb5, 0x11, 0xaa, 0x14, 0xb5, 0x95, 0xd3,
+	0xaa, 0xdc, 0xad, 0x26, 0x1e, 0x77, 0x63, 0x75, 0xc6, 0xb6, 0x6c, 0x4f, 0x36, 0xe5, 0x29, 0x90,
+	0x78, 0x00, 0xae, 0xb8, 0xe4, 0x09, 0x78, 0x39, 0xe4, 0xc3, 0xcc, 0x6e, 0xc2, 0x8a, 0xe6, 0xce,
+	0xbf, 0xef, 0xe4, 0xef, 0xf0, 0xf3, 0x37, 0x03, 0x45, 0xa5, 0xf8, 0xa1, 0xd2, 0xd2, 0x4a, 0x34,
+	0x52, 0x67, 0x5a, 0xd1, 0x72, 0x0f, 0x46, 0xac, 0x55, 0xf6, 0x03, 0x42, 0x90, 0x57, 0x9d, 0x3d,
+	0xc7, 0xc9, 0x34, 0x39, 0x28, 0x88, 0x3f, 0x97, 0x18, 0x72, 0x25, 0xc5, 0x1c, 0xdd, 0x81, 0xac,
+	0x35, 0xf3, 0xa8, 0x72, 0xc7, 0xf2, 0x13, 0xc8, 0x98, 0xd6, 0x4e, 0xc1, 0xb4, 0xee, 0x15, 0x4c,
+	0xeb, 0xf2, 0x09, 0x4c, 0x9e, 0x4a, 0x61, 0x2b, 0x2e, 0x98, 0x7e, 0xfe, 0x0c, 0xed, 0x40, 0xca,
+	0xeb, 0xa8, 0x4f, 0x79, 0x3d, 0xdc, 0x92, 0xae, 0xdc, 0xf2, 0x77, 0x02, 0xe3, 0x46, 0xce, 0x5f,
+	0x2a, 0x6b, 0xd0, 0x14, 0x12, 0xea, 0xcd, 0x27, 0x47, 0xe8, 0xd0, 0x67, 0x78, 0xb8, 0x12, 0x8e,
+	0x24, 0x14, 0xdd, 0x87, 0xcd, 0x77, 0xb2, 0x69, 0xe4, 0xc2, 0xc7, 0xd8, 0x22, 0x11, 0xb9, 0xc8,
+	0xb6, 0xe2, 0x0d, 0xce, 0x42, 0x64, 0x77, 0x46, 0xf7, 0x60, 0x64, 0xb8, 0xa0, 0x0c, 0xe7, 0xd3,
+	0xe4, 0x20, 0x23, 0x01, 0x38, 0x69, 0x27, 0x2c, 0x6f, 0xf0, 0x28, 0x48, 0x3d, 0x40, 0xfb, 0x00,
+	0x96, 0xb7, 0xcc, 0xd8, 0xaa, 0x55, 0x06, 0x6f, 0xfa, 0xd8, 0x2b, 0x12, 0x77, 0xaf, 0xb1, 0x9a,
+	0x55, 0x2d, 0x1e, 0xfb, 0x1b, 0x22, 0x2a, 0xff, 0xca, 0xa1, 0x18, 0x52, 0x5c, 0x57, 0xaf, 0xa8,
+	0x5a, 0xd6, 0xd7, 0xeb, 0xce, 0xee, 0x7e, 0xde, 0x56, 0x73, 0x16, 0x53, 0x0d, 0x00, 0x61, 0x18,
+	0x53, 0xd9, 0xb6, 0x95, 0xa8, 0x7d, 0xb6, 0x05, 0xe9, 0xa1, 0xaf, 0xc2, 0x56, 0x96, 0xf9, 0x7c,
+	0x0b, 0x12, 0x40, 0xc8, 0xa7, 0xb2, 0x5d, 0xc8, 0xb5, 0x20, 0x11, 0xb9, 0x91, 0x70, 0x65, 0xf0,
+	0x78, 0x9a, 0xb9, 0x91, 0x70, 0x65, 0xbc, 0xff, 0x39, 0x6b, 0x1a, 0xbc, 0x15, 0xfd, 0x1d, 0x40,
+	0x9f, 0xc2, 0x96, 0x92, 0xf5, 0xcc, 0x67, 0x57, 0x84, 0x0b, 0x95, 0xac, 0x5f, 0xb8, 0x04, 0x1f,
+	0xc2, 0x0e, 0xed, 0x2b, 0x0a, 0x06, 0xe0, 0x0d, 0x6e, 0x0d, 0x52, 0x6f, 0xf6, 0x19, 0x14, 0x4e,
+	0x69, 0x54, 0x45, 0x19, 0x9e, 0x78, 0x8b, 0xa5, 0x00, 0x3d, 0x80, 0x6d, 0xdd, 0x09, 0xc1, 0xc5,
+	0x7c, 0x26, 0x64, 0xcd, 0xf0, 0xb6, 0x37, 0x98, 0x44, 0xd9, 0x0b, 0x59, 0x33, 0xf4, 0x39, 0x40,
+	0x23, 0xe9, 0xcc, 0x30, 0x7d, 0xc1, 0x34, 0xbe, 0x15, 0x22, 0x34, 0x92, 0x9e, 0x7a, 0x81, 0xeb,
+	0x08, 0xbb, 0x64, 0xf4, 0x69, 0x5b, 0xe3, 0x9d, 0x90, 0x60, 0x84, 0x68, 0x17, 0xb6, 0xdc, 0xf1,
+	0x8d, 0x61, 0x1a, 0xdf, 0xf6, 0xaa, 0x01, 0xf7, 0x5e, 0xc7, 0xe2, 0x02, 0xdf, 0x59, 0x7a, 0x1d,
+	0x8b, 0x0b, 0xdf, 0xe1, 0xa6, 0x33, 0x96, 0x69, 0x7c, 0x37, 0x76, 0x38, 0x40, 0x57, 0x89, 0x33,
+	0x7a, 0xc6, 0xce, 0xba, 0x39, 0x46, 0x7e, 0xf4, 0x4b, 0x41, 0x7f, 0xdb, 0x4f, 0x7a, 0x6e, 0xf0,
+	0xff, 0x7c, 0x5b, 0x07, 0x1c, 0x67, 0xd3, 0x30, 0x7c, 0xcf, 0x7b, 0x05, 0xe0, 0x3c, 0x16, 0x52,
+	0xbf, 0x6f, 0x64, 0x55, 0xe3, 0xff, 0x87, 0xfc, 0x7a, 0x5c, 0x1e, 0x02, 0x0c, 0x74, 0x71, 0x7c,
+	0x4f, 0xa9, 0xc1, 0xc9, 0x34, 0x3b, 0x98, 0x1c, 0xdd, 0xb9, 0x4e, 0x78, 0x92, 0x52, 0x53, 0xfe,
+	0x08, 0x23, 0x76, 0xc1, 0x84, 0x75, 0x03, 0xaf, 0xa8, 0xe5, 0x52, 0x44, 0x7a, 0x45, 0x84, 0xf6,
+	0xdd, 0x93, 0x49, 0xa7, 0xc9, 0xda, 0x08, 0x09, 0x2d, 0x7f, 0x4b, 0x03, 0x7f, 0xcc, 0x3a, 0x72,
+	0x3a, 0x82, 0x7b, 0xe7, 0x8c, 0xf8, 0x33, 0xfa, 0x02, 0x26, 0x54, 0x75, 0x33, 0xc5, 0x34, 0x65,
+	0xc2, 0x7a, 0x8a, 0x26, 0x04, 0xa8, 0xea, 0x5e, 0x05, 0x89, 0x9b, 0x6b, 0xcb, 0x5a, 0xa9, 0x3f,
+	0xcc, 0x3a, 0xe3, 0x48, 0xec, 0xc8, 0x9a, 0x93, 0x49, 0x90, 0xbd, 0x71, 0xa2, 0x15, 0x93, 0x86,
+	0xb7, 0xdc, 0xe2, 0xd1, 0xaa, 0xc9, 0x89, 0x13, 0xb9, 0xd1, 0x0b, 0x66, 0x5d, 0x53, 0x66, 0xfa,
+	0xd2, 0x33, 0x38, 0x27, 0x45, 0x94, 0x90, 0xcb, 0x55, 0xb5, 0xbd, 0xc4, 0xe3, 0x2b, 0xea, 0xd7,
+	0x5e, 0x7d, 0xd6, 0x48, 0xfa, 0x7e, 0xa6, 0x59, 0x55, 0x7b, 0x5a, 0xe7, 0xa4, 0xf0, 0x12, 0xc2,
+	0xaa, 0xda, 0xd5, 0x10, 0xd4, 0x0b, 0xcd, 0x6d, 0x60, 0x77, 0x4e, 0x82, 0xc7, 0x5b, 0x27, 0x29,
+	0xff, 0x48, 0x60, 0xac, 0xb4, 0xa4, 0xcc, 0xf8, 0xf7, 0xa2, 0x62, 0x57, 0x46, 0x24, 0x53, 0xa1,
+	0x2d, 0x9d, 0x63, 0x56, 0x7c, 0xb3, 0xee, 0xfc, 0xf1, 0xb6, 0xdc, 0x87, 0xcd, 0x50, 0x5f, 0x6c,
+	0x48, 0x44, 0xab, 0xcf, 0x7a, 0x74, 0xf5, 0x59, 0xef, 0x41, 0x21, 0xe4, 0xcc, 0xf0, 0xb9, 0xa8,
+	0x9a, 0xb8, 0x6f, 0xb6, 0x84, 0x3c, 0xf5, 0xb8, 0x7c, 0x04, 0x45, 0x4c, 0x90, 0x19, 0xb4, 0x0f,
+	0xa9, 0xea, 0x49, 0xb2, 0x13, 0x47, 0x1c, 0xb5, 0x24, 0x55, 0xa6, 0xfc, 0x05, 0x20, 0x84, 0xb9,
+	0xe1, 0x0a, 0x8d, 0x25, 0xa7, 0xcb, 0x92, 0xdd, 0x32, 0x09, 0x89, 0x64, 0x71, 0x99, 0x84, 0x34,
+	0xbe, 0x82, 0x94, 0x4b, 0xcf, 0x9b, 0xc0, 0xba, 0x6d, 0x92, 0x72, 0xe1, 0xfc, 0x65, 0x67, 0xbd,
+	0xff, 0x36, 0x71, 0xc7, 0xf2, 0x07, 0x80, 0x05, 0x17, 0xb5, 0x5c, 0x9c, 0xf2, 0x5f, 0xfd, 0x6a,
+	0x3a, 0x67, 0x7c, 0x7e, 0x6e, 0x63, 0x57, 0x23, 0x72, 0x8f, 0x65, 0xc1, 0xeb, 0xb8, 0xfd, 0x47,
+	0x24, 0x80, 0xf2, 0xf7, 0x04, 0x26, 0xee, 0x3d, 0xbd, 0x54, 0x8e, 0xce, 0x06, 0xed, 0x41, 0x46,
+	0xdb, 0x3a, 0x56, 0x50, 0xc4, 0x0a, 0xb8, 0x24, 0x4e, 0xfa, 0x31, 0xb2, 0xf7, 0x1f, 0xa4, 0x6c,
+	0xf8, 0x20, 0x0d, 0x5f, 0x9c, 0x7c, 0xf9, 0xc5, 0x41, 0x0f, 0x20, 0x5d, 0x18, 0x3f, 0x8f, 0xc9,
+	0xd1, 0xdd, 0x18, 0x66, 0x99, 0x3f, 0x49, 0x17, 0xe6, 0xe8, 0xcf, 0x1c, 0x6e, 0x0f, 0xeb, 0x2e,
+	0x2e, 0xa4, 0x27, 0x30, 0xfe, 0x99, 0xd9, 0xe7, 0xe2, 0x9d, 0x44, 0x6b, 0x3a, 0xbb, 0xfb, 0xaf,
+	0x84, 0xca, 0x0d, 0xf4, 0x0d, 0xe4, 0x27, 0xdc, 0x58, 0xb4, 0x1d, 0x75, 0xfe, 0x5b, 0xbb, 0x7b,
+	0xf7, 0xba, 0xa5, 0xf1, 0xa6, 0xa3, 0x53, 0x5b, 0x69, 0xbb, 0x36, 0x36, 0xf4, 0xfe, 0xda, 0x45,
+	0x3d, 0x80, 0xfc, 0xd4, 0x4a, 0x75, 0x03, 0xcb, 0x47, 0x30, 0x26, 0xcc, 0xdc, 0x30, 0xec, 0xf7,
+	0x90, 0x1f, 0x5f, 0x32, 0x3a, 0x58, 0xae, 0x4c, 0x65, 0x77, 0x8d, 0xac, 0xdc, 0x38, 0x48, 0x1e,
+	0x27, 0xe8, 0x4b, 0xc8, 0x5f, 0x71, 0x31, 0xbf, 0x56, 0xe2, 0x24, 0x22, 0xf7, 0xff, 0x50, 0x6e,
+	0xa0, 0x87, 0x90, 0x9f, 0xc8, 0xb9, 0x41, 0x3d, 0x7d, 0xe3, 0xf7, 0x7e, 0x77, 0x39, 0xdf, 0x72,
+	0xe3, 0x71, 0x82, 0xbe, 0x86, 0xd1, 0xdb, 0xca, 0xd2, 0xf3, 0x6b, 0xc1, 0x06, 0xe4, 0x16, 0xa1,
+	0x37, 0xfc, 0xd6, 0x37, 0xcb, 0x9a, 0xb5, 0x55, 0xf5, 0xe6, 0x7e, 0xeb, 0x45, 0xf3, 0xec, 0xb5,
+	0x54, 0xff, 0x39, 0xb5, 0xe1, 0xb9, 0xf9, 0x51, 0x6c, 0x86, 0x77, 0x88, 0xfa, 0x49, 0x2d, 0xdf,
+	0xd7, 0xd5, 0x9e, 0x9d, 0x6d, 0xfa, 0xbf, 0xa9, 0xef, 0xfe, 0x19, 0x00, 0x3b, 0xfc, 0x7e, 0xe6,
+	0x5a, 0x09, 0x00, 0x00,
 }
@@ -6,6 +6,8 @@ import (
 	"io"
 
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/wrfly/container-web-tty/container"
 	pb "github.com/wrfly/container-web-tty/proxy/pb"
@@ -244,11 +246,7 @@ func (svc *containerService) Logs(logOpts *pb.LogOpts, stream pb.ContainerServer
 	}
 
 	logrus.Debugf("get container logs: %s", cid.Id)
-	rc, err := svc.cli.Logs(stream.Context(), types.LogOptions{
-		Follow: logOpts.Follow,
-		Tail:   logOpts.Tail,
-		ID:     cid.Id,
-	})
+	rc, err := svc.cli.Logs(stream.Context(), util.ConvertPbLogOpts(logOpts))
 	if err != nil {
 		return err
 	}
@@ -271,3 +269,106 @@ func (svc *containerService) Logs(logOpts *pb.LogOpts, stream pb.ContainerServer
 
 	return nil
 }
+
+func (svc *containerService) Watch(e *pb.Empty, stream pb.ContainerServer_WatchServer) error {
+	if err := checkNil(e); err != nil {
+		return err
+	}
+
+	if err := svc.checkAuth(e.Auth); err != nil {
+		return err
+	}
+
+	watcher, ok := svc.cli.(container.Watcher)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "the backend doesn't report the changes")
+	}
+
+	logrus.Debugf("watch containers")
+	for event := range watcher.Watch(stream.Context()) {
+		if err := stream.Send(&pb.Event{
+			Action: event.Action,
+			C:      util.ConvertTpContainer(event.Container),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *containerService) Stats(cid *pb.ContainerID, stream pb.ContainerServer_StatsServer) error {
+	if err := checkNil(cid); err != nil {
+		return err
+	}
+
+	if err := svc.checkAuth(cid.Auth); err != nil {
+		return err
+	}
+
+	reporter, ok := svc.cli.(container.StatsReporter)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "the backend doesn't report the stats")
+	}
+
+	logrus.Debugf("get container stats: %s", cid.Id)
+	ctx := stream.Context()
+	stats, err := reporter.Stats(ctx, svc.cli.GetInfo(ctx, cid.Id))
+	if err != nil {
+		return err
+	}
+	for s := range stats {
+		if err := stream.Send(util.ConvertTpStats(s)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (svc *containerService) Top(ctx context.Context, cid *pb.ContainerID) (*pb.Processes, error) {
+	if err := checkNil(cid); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkAuth(cid.Auth); err != nil {
+		return nil, err
+	}
+
+	manager, ok := svc.cli.(container.ProcessManager)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "the backend doesn't list the processes")
+	}
+
+	logrus.Debugf("top container: %s", cid.Id)
+	processes, err := manager.Top(ctx, svc.cli.GetInfo(ctx, cid.Id))
+	if err != nil {
+		return nil, err
+	}
+	return util.ConvertTpProcesses(processes), nil
+}
+
+func (svc *containerService) Signal(ctx context.Context, opts *pb.SignalOpts) (*pb.Err, error) {
+	cid := opts.C
+	if err := checkNil(cid); err != nil {
+		return nil, err
+	}
+
+	if err := svc.checkAuth(cid.Auth); err != nil {
+		return nil, err
+	}
+
+	manager, ok := svc.cli.(container.ProcessManager)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "the backend doesn't signal the processes")
+	}
+
+	logrus.Debugf("signal %s to process %d of container: %s", opts.Signal, opts.Pid, cid.Id)
+	err := manager.Signal(ctx, svc.cli.GetInfo(ctx, cid.Id), int(opts.Pid), opts.Signal)
+	if err == nil {
+		return &pb.Err{}, nil
+	}
+	return &pb.Err{
+		Err: err.Error(),
+	}, nil
+}
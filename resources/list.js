@@ -0,0 +1,142 @@
+// live updates of the container list, by the Server-Sent Events
+
+(function () {
+    if (!window.EventSource) {
+        return;
+    }
+    var base = document.currentScript.getAttribute('data-base');
+    var tbody = document.querySelector('.table-body tbody');
+
+    function find(rows, id) {
+        return rows.querySelector('tr[data-id="' + id + '"]');
+    }
+
+    function setStatus(row, status, title) {
+        var td = row.querySelector('.column7');
+        td.querySelector('a').textContent = status;
+        td.title = title;
+    }
+
+    // the usage of the containers, if the stats are shown
+    var streams = {};
+    function watchUsage(row) {
+        var usage = row.querySelector('.usage');
+        if (!usage) {
+            return;
+        }
+        var id = row.getAttribute('data-id');
+        unwatchUsage(id);
+        streams[id] = watchStats(base, id, function (s) {
+            usage.textContent = formatUsage(s);
+        }, function (err) {
+            usage.title = err;
+        });
+    }
+
+    function unwatchUsage(id) {
+        if (streams[id]) {
+            streams[id].close();
+            delete streams[id];
+        }
+    }
+
+    // fetchRows renders the list again and calls back with the rows
+    function fetchRows(callback) {
+        var xmlhttp = new XMLHttpRequest();
+        xmlhttp.open("GET", base + "/" + location.search);
+        xmlhttp.onreadystatechange = function () {
+            if (xmlhttp.readyState == 4 && xmlhttp.status == 200) {
+                var doc = new DOMParser().parseFromString(xmlhttp.responseText, "text/html");
+                callback(document.importNode(doc.querySelector('.table-body tbody'), true));
+            }
+        };
+        xmlhttp.send();
+    }
+
+    // the created containers are rendered by the server in batches
+    var created = {};
+    var timer = null;
+    function create(c) {
+        created[c.ID] = true;
+        if (timer) {
+            return;
+        }
+        timer = setTimeout(function () {
+            var ids = created;
+            created = {};
+            timer = null;
+            fetchRows(function (rows) {
+                for (var id in ids) {
+                    var row = find(rows, id);
+                    if (!row) {
+                        continue;
+                    }
+                    var old = find(tbody, id);
+                    if (old) {
+                        tbody.replaceChild(row, old);
+                    } else {
+                        tbody.appendChild(row);
+                    }
+                    watchUsage(row);
+                }
+            });
+        }, 500);
+    }
+
+    function update(c) {
+        var row = find(tbody, c.ID);
+        if (!row) {
+            create(c);
+            return;
+        }
+        row.setAttribute('data-state', c.State);
+        setStatus(row, c.Status + (c.Stale ? " (stale)" : ""),
+            c.State + (c.Stale ? ", the backend is disconnected" : ""));
+    }
+
+    // the removed containers are kept until reloaded
+    function remove(c) {
+        var row = find(tbody, c.ID);
+        if (!row) {
+            return;
+        }
+        row.setAttribute('data-state', 'removed');
+        setStatus(row, "Removed", "the container is removed");
+        unwatchUsage(c.ID);
+    }
+
+    // the events are lost while disconnected, reload the list
+    function resync() {
+        fetchRows(function (rows) {
+            tbody.parentNode.replaceChild(rows, tbody);
+            tbody = rows;
+            for (var id in streams) {
+                unwatchUsage(id);
+            }
+            tbody.querySelectorAll('tr').forEach(watchUsage);
+        });
+    }
+    tbody.querySelectorAll('tr').forEach(watchUsage);
+
+    var handlers = { create: create, update: update, remove: remove };
+    var source = new EventSource(base + "/events");
+    var lost = false;
+    for (var action in handlers) {
+        (function (handler) {
+            source.addEventListener(action, function (event) {
+                var c = JSON.parse(event.data);
+                console.debug(event.type, c.ID);
+                handler(c);
+            });
+        })(handlers[action]);
+    }
+    source.onerror = function () {
+        lost = true;
+    };
+    source.onopen = function () {
+        if (lost) {
+            lost = false;
+            resync();
+        }
+    };
+})();
\ No newline at end of file
@@ -0,0 +1,69 @@
+// the resource usage of the containers, by the websocket streams
+
+// humanBytes formats the bytes in the binary units
+function humanBytes(n) {
+    var units = ["B", "KiB", "MiB", "GiB", "TiB"];
+    var i = 0;
+    while (n >= 1024 && i < units.length - 1) {
+        n /= 1024;
+        i++;
+    }
+    return (i == 0 ? n : n.toFixed(2)) + units[i];
+}
+
+// formatUsage describes the CPU and the memory in short
+function formatUsage(s) {
+    var usage = s.cpu_percent.toFixed(2) + "% " + humanBytes(s.memory_usage);
+    if (s.memory_limit) {
+        usage += " / " + humanBytes(s.memory_limit);
+    }
+    return usage;
+}
+
+// watchStats calls back with the stats of the container until the
+// websocket is closed, onerror is called if it cannot be streamed
+function watchStats(base, id, callback, onerror) {
+    var scheme = location.protocol == "https:" ? "wss://" : "ws://";
+    var ws = new WebSocket(scheme + location.host + base + "/stats/" + id + "/ws");
+    ws.onmessage = function (event) {
+        var s = JSON.parse(event.data);
+        if (s.err) {
+            onerror(s.err);
+            return;
+        }
+        callback(s);
+    };
+    return ws;
+}
+
+// the page of a container
+(function () {
+    var view = document.getElementById('stats');
+    if (!view) {
+        return;
+    }
+    var error = document.getElementById('stats-error');
+    function set(name, text) {
+        view.querySelector('.' + name).textContent = text;
+    }
+
+    var ws = watchStats(view.getAttribute('data-base'), view.getAttribute('data-id'), function (s) {
+        var memory = humanBytes(s.memory_usage);
+        if (s.memory_limit) {
+            memory += " / " + humanBytes(s.memory_limit) +
+                " (" + (s.memory_usage / s.memory_limit * 100).toFixed(2) + "%)";
+        }
+        set('cpu', s.cpu_percent.toFixed(2) + "%");
+        set('memory', memory);
+        set('network', humanBytes(s.network_rx) + " / " + humanBytes(s.network_tx));
+        set('block', humanBytes(s.block_read) + " / " + humanBytes(s.block_write));
+        set('time', new Date(s.time).toLocaleTimeString());
+    }, function (err) {
+        error.textContent = err;
+    });
+    ws.onclose = function () {
+        if (!error.textContent) {
+            error.textContent = "the stream is closed";
+        }
+    };
+})();
\ No newline at end of file
@@ -0,0 +1,98 @@
+// the processes of a container, refreshed periodically
+
+(function () {
+    var view = document.getElementById('top');
+    if (!view) {
+        return;
+    }
+    var base = view.getAttribute('data-base');
+    var id = view.getAttribute('data-id');
+    var signal = view.getAttribute('data-signal') == "true";
+    var error = document.getElementById('top-error');
+
+    function cell(row, column, text) {
+        var td = document.createElement('td');
+        td.className = column;
+        td.textContent = text;
+        td.title = text;
+        row.appendChild(td);
+        return td;
+    }
+
+    function render(processes) {
+        var rows = document.createDocumentFragment();
+        processes.forEach(function (p, i) {
+            var row = document.createElement('tr');
+            if (i % 2 == 1) {
+                row.className = "t c ver2";
+            }
+            row.setAttribute('data-pid', p.pid);
+            cell(row, 'column1', p.pid);
+            cell(row, 'column4', p.user || "-");
+            cell(row, 'column5', p.cpu_percent ? p.cpu_percent.toFixed(1) + "%" : "-");
+            cell(row, 'column7', p.memory ? humanBytes(p.memory) : "-");
+            cell(row, 'column2', p.command);
+            if (signal) {
+                var btn = document.createElement('button');
+                btn.textContent = "Signal";
+                if (p.no_signal) {
+                    // e.g. the docker PIDs of the host, except the main one
+                    btn.disabled = true;
+                    btn.title = "only the main process can be signaled";
+                }
+                cell(row, 'column8', "").appendChild(btn);
+            }
+            rows.appendChild(row);
+        });
+        view.textContent = "";
+        view.appendChild(rows);
+    }
+
+    function refresh() {
+        var xmlhttp = new XMLHttpRequest();
+        xmlhttp.open("GET", base + "/top/" + id + "/json");
+        xmlhttp.onreadystatechange = function () {
+            if (xmlhttp.readyState != 4) {
+                return;
+            }
+            var j = JSON.parse(xmlhttp.responseText);
+            if (xmlhttp.status != 200) {
+                error.textContent = j.err;
+                return;
+            }
+            error.textContent = "";
+            render(j);
+        };
+        xmlhttp.send();
+    }
+    refresh();
+    setInterval(refresh, 3000);
+
+    view.addEventListener('click', function (event) {
+        var btn = event.target;
+        if (btn.tagName != 'BUTTON') {
+            return;
+        }
+        var pid = btn.parentElement.parentElement.getAttribute('data-pid');
+        var sig = document.getElementById('signal').value;
+        if (!confirm("send SIG" + sig + " to process " + pid)) {
+            return;
+        }
+        var form = new FormData();
+        form.append("pid", pid);
+        form.append("signal", sig);
+        var xmlhttp = new XMLHttpRequest();
+        xmlhttp.open("POST", base + "/top/" + id + "/signal");
+        xmlhttp.onreadystatechange = function () {
+            if (xmlhttp.readyState == 4) {
+                var j = JSON.parse(xmlhttp.responseText);
+                console.debug(j);
+                if (xmlhttp.status != 200) {
+                    alert(j.err);
+                }
+                refresh();
+            }
+        };
+        xmlhttp.send(form);
+    });
+})();
\ No newline at end of file
@@ -13,7 +13,12 @@ Files:
 	/js
 	/js/control.js
 	/js/gotty-bundle.js
+	/js/list.js
+	/js/stats.js
+	/js/top.js
 	/list.html
+	/stats.html
+	/top.html
 
 DO NOT EDIT!
 */
@@ -87,76 +92,91 @@ var _file_2 = &file{
 }
 
 var _compress_bytes_3 = []byte("" +
-	"\x78\x9c\xa4\x56\xcd\x6e\xe3\x36\x10\xbe\xeb\x29\x58\x04\x0b" +
-	"\xb4\x81\x68\x4b\xb6\xe3\xc4\x32\x7a\xe8\xb6\xdb\x62\x81\xa0" +
-	"\x28\x36\x7b\x29\x16\x3d\x50\xd2\xc8\x62\x43\x91\x02\x39\x8a" +
-	"\xed\x04\x79\xf7\x82\x94\x64\x49\xb6\xec\x26\x58\x0a\x08\x62" +
-	"\xce\xcc\xc7\x99\x6f\x7e\xc8\xe9\xf5\xf4\xbb\x97\xf7\x8d\x7c" +
-	"\xf9\xf4\xf0\xf5\xef\xfb\x4f\xe4\xeb\x2f\x7f\x90\x7f\xae\xa7" +
-	"\xde\x35\x79\xf1\x08\x21\xa4\x60\x7a\xc3\x65\x44\x82\x72\xb7" +
-	"\x26\x6e\xa7\x64\x69\xca\xe5\xa6\xbf\x15\xab\x1d\x35\xfc\xd9" +
-	"\xed\xc6\x4a\xa7\xa0\x69\xac\x76\x6b\xef\xd5\xf3\x62\x95\xee" +
-	"\x7d\x92\x63\x21\x1a\xc0\x1c\xf8\x26\xc7\x88\x84\x41\xf0\x61" +
-	"\xed\x76\x32\x25\x91\x66\xac\xe0\x62\x1f\x11\xc3\xa4\xa1\x06" +
-	"\x34\xcf\x6a\x61\xcc\x92\xc7\x8d\x56\x95\x4c\x69\xa2\x84\xd2" +
-	"\x11\xb9\x9a\xaf\xec\xb7\xb6\x52\x7b\xc2\xf4\x9a\xd0\x37\x2c" +
-	"\x72\x3d\xf5\xd8\x48\x50\x6e\x03\x35\x93\x86\x23\x57\x32\x22" +
-	"\x4c\x08\x12\x4c\x16\xa6\x96\xd0\x2d\xc4\x8f\x1c\xe9\x05\x0d" +
-	"\x75\x41\x88\xb0\x43\x9a\x42\xa2\x34\xab\xc5\x52\x49\x68\xec" +
-	"\x0a\xf5\x7c\xc1\xb2\x89\x56\x6f\xe2\x1f\xc3\x70\xe5\x93\x65" +
-	"\xe0\x93\x70\x79\xf7\x93\x63\x95\x45\xb9\x7a\x02\xdd\x84\xa3" +
-	"\x2a\x14\x5c\x42\x0d\x4e\x7e\xe0\x45\xa9\x34\x32\x89\x03\xa0" +
-	"\xab\xf0\x16\x56\xe1\xca\x99\xbf\x87\xb3\x3c\xf4\xf3\x99\x9f" +
-	"\xcf\xfd\x7c\xe1\xe7\x37\x7e\xbe\x24\x2f\x7d\xfa\x5e\x3d\xaf" +
-	"\x3c\xd9\xa9\x84\x4f\x04\x3f\x47\xb6\xe0\x06\xa9\xc1\xbd\x00" +
-	"\x8a\xfb\x12\x5a\x4e\xde\xe9\x17\x97\x65\x85\xe4\xc5\x4b\xb9" +
-	"\x29\x05\xdb\x47\x24\x16\x2a\x79\x5c\x9f\x12\xd2\xd4\x91\x2b" +
-	"\xcb\x11\x8a\x5e\x3d\xcf\x26\x89\x69\x68\xab\xe3\x0d\x88\x3d" +
-	"\xa3\x28\x53\x49\x65\x7c\xe2\xfc\xa9\x7f\x34\x38\x4d\x27\x34" +
-	"\xfc\xbb\x4c\x97\x4c\x83\xc4\xe3\xf3\xdf\x11\x75\x5c\x21\x2a" +
-	"\xf9\xa6\xbc\xf7\x23\x3e\xee\xa5\x81\x3b\x75\x9f\x3a\xe0\x41" +
-	"\x59\x25\x95\x36\xd6\xf3\x52\x71\x89\xa0\x9d\x1a\xcf\x34\x2b" +
-	"\x60\x10\xe0\x38\xa7\xde\x24\x51\x12\x19\x97\xa0\x29\xb2\x58" +
-	"\xb4\x36\x5b\x9e\x62\xde\xef\xfe\x82\x4b\xda\x9b\x09\x4f\xf9" +
-	"\xa9\xaf\x57\x59\x96\xad\xbd\x61\x6e\xda\xbe\x74\x73\x66\x54" +
-	"\x92\x09\x38\x11\xd9\x96\x1b\xb1\x28\x8c\xd3\x3e\x95\x74\x18" +
-	"\x4c\xf0\x8d\xa4\x1c\xa1\x30\x11\x49\xa0\x26\xc4\x0a\xfe\xad" +
-	"\x0c\xf2\x6c\x4f\x6d\xb8\x20\x71\x28\xb4\xf6\x74\xab\x59\x19" +
-	"\x11\xfb\x77\x3d\x1c\xa0\xf3\x79\xb9\x23\x73\xd7\x17\xaf\x9e" +
-	"\x37\xb1\x1a\x07\xae\x5a\x9e\xc2\xdb\x56\xee\x5d\xa4\xb1\x2b" +
-	"\x36\xc1\x4a\x03\x11\x69\xff\x6b\xe6\x90\xb5\xa5\x82\xed\x95" +
-	"\x2d\x52\xbe\x83\xb4\x97\xf5\x97\xc1\x9c\x48\x61\x15\x84\x49" +
-	"\x5d\xe3\xb9\x4f\x30\x6d\x8e\x74\x63\x7a\xdb\x64\xaa\x92\x06" +
-	"\x70\x10\x0e\xd5\xb5\x64\x76\xe8\xf3\x56\x20\x20\x1b\xec\xbb" +
-	"\x99\xe8\xf8\x1c\x92\xb5\xcd\x39\x02\x35\x25\x4b\x5c\x49\x77" +
-	"\x84\xd9\x9a\xcc\x84\xda\x46\x24\xe7\x69\x0a\xb2\x6d\x9a\xcf" +
-	"\xbf\xd9\x96\x98\x24\x4a\x54\x85\x0c\x8f\x98\xb9\xf9\x30\xe6" +
-	"\xc5\xa2\x65\xd3\x9a\x17\x6c\x03\x3d\x84\xd9\x10\x61\x66\x11" +
-	"\x6a\xcd\x5f\x55\x51\x30\x99\xf6\x74\xe7\x23\xa7\xd5\xba\x7f" +
-	"\xda\xfe\xe8\x14\x17\x67\x15\x3f\xff\xd5\x53\xbb\x39\x52\x9b" +
-	"\x1d\xd4\xee\x55\xf2\x00\xda\x76\x65\xa7\xbd\x3c\xae\x82\x83" +
-	"\xf6\x03\x32\xac\x4c\x4f\xf5\x76\x44\x75\x24\x6b\xf3\x1e\x2f" +
-	"\x1f\x5d\x51\xf4\x41\xee\xfe\x87\xdb\x41\xea\x6d\x29\xd7\xe5" +
-	"\x96\x03\x4b\x09\xe6\x8d\x71\xab\x8c\xaa\x8c\x48\x78\x77\x5c" +
-	"\x25\xb1\x42\x54\x45\x2b\xe9\x40\xec\x03\xa2\x2b\xc2\x21\xc8" +
-	"\xf2\x2c\xc8\xf2\x10\xce\xcf\xdf\xbd\xbc\x6f\xe4\x77\xbe\x23" +
-	"\x36\x1a\xd0\xee\x71\x34\xe9\xb7\x62\xa9\xda\x0b\x5c\x83\x60" +
-	"\xc8\x9f\x60\x7d\xea\xea\xf2\x50\xfd\xe7\x1f\x34\xc7\xcc\x1d" +
-	"\xc3\xb3\xd8\x28\x51\x61\x03\x7f\x32\x01\xdc\x39\x41\x73\xc5" +
-	"\xba\x62\x0f\x7a\x90\x93\x27\xd0\xf3\x2e\x19\x83\x37\xd7\x3d" +
-	"\x43\x45\x3f\x2a\x91\xf6\xde\x63\x86\x3f\x83\xcd\x74\xeb\x76" +
-	"\xeb\x6b\x10\xb0\xf4\x26\x6b\x2f\x72\x09\xdd\xec\x9e\x2c\x7a" +
-	"\xed\xed\xae\x97\x4c\xe9\x22\x22\x55\x59\x82\x4e\x98\x39\xb9" +
-	"\x84\xc6\xe3\x9f\x24\xd6\xd5\x59\x97\xf2\x11\x83\xf0\xd6\x7e" +
-	"\xa7\xd1\xa5\x67\xa3\xfb\x02\x9b\x4a\x30\xfd\x86\x00\x1d\x74" +
-	"\x78\x29\xc0\x11\x87\x02\xb7\xee\x66\xd6\xa5\xff\x02\x00\x00" +
-	"\xff\xff\x3f\x7e\x4b\x56")
+	"\x78\x9c\xa4\x57\x5f\x8b\xdb\x48\x12\x7f\xd7\xa7\xa8\x4b\x08" +
+	"\xdc\x19\xc9\xb6\x6c\x8f\x67\x46\x26\x1c\x97\xdb\xec\x12\x08" +
+	"\xcb\x92\xe4\x65\x09\x79\x68\xab\x4b\x56\x6f\x5a\xdd\xa2\xbb" +
+	"\x34\xb6\x63\xe6\xbb\x2f\xdd\x92\x6c\xc9\xb2\xbd\x09\x91\x60" +
+	"\x98\xe9\xaa\xfa\x55\xd5\xaf\xfe\xa8\x67\x32\x9a\xfc\xf4\x13" +
+	"\x7c\x86\x0f\x6f\x3f\x7e\xfa\xf3\xfd\x5b\xf8\xf4\xbf\xdf\xe0" +
+	"\xcb\x68\x12\x8c\xe0\x10\x00\x00\x14\xcc\x6c\x84\x4a\x60\x5a" +
+	"\xee\x56\xe0\x4f\x4a\xc6\xb9\x50\x9b\xee\xd1\x5a\xef\x22\x2b" +
+	"\xbe\xf9\xd3\xb5\x36\x1c\x4d\xb4\xd6\xbb\x55\xf0\x1c\x04\x6b" +
+	"\xcd\xf7\x21\xe4\x54\xc8\x06\x30\x47\xb1\xc9\x29\x81\x78\x3a" +
+	"\x7d\xb5\xf2\x27\x99\x56\x14\x65\xac\x10\x72\x9f\x80\x65\xca" +
+	"\x46\x16\x8d\xc8\x6a\xe1\x9a\xa5\x5f\x37\x46\x57\x8a\x47\xa9" +
+	"\x96\xda\x24\xf0\x72\xfe\xe8\xde\x95\x93\x3a\x0f\x93\x11\x44" +
+	"\xdf\xf1\xc0\x68\x12\xb0\x0b\x49\xf9\x03\x32\x4c\x59\x41\x42" +
+	"\xab\x04\x98\x94\x30\x1d\x2f\x6c\xed\x3f\xda\xe2\xfa\xab\xa0" +
+	"\xe8\x86\x86\xbe\x21\x24\xdc\x51\xc4\x31\xd5\x86\xd5\xb6\x4a" +
+	"\x2b\x6c\xec\x0a\xfd\xed\x86\x65\x93\xad\xd9\xac\xff\x1d\xc7" +
+	"\x8f\x21\x2c\xa7\x21\xc4\xcb\x87\xff\x78\x56\x59\x92\xeb\x27" +
+	"\x34\x4d\x3a\xba\x22\x29\x14\xd6\xe0\xf0\x2f\x51\x94\xda\x10" +
+	"\x53\xd4\x03\x7a\x19\xdf\xe3\x63\xfc\xe8\xcd\x7f\x84\xb3\x3c" +
+	"\x0e\xf3\x59\x98\xcf\xc3\x7c\x11\xe6\x77\x61\xbe\x84\x43\x97" +
+	"\xbe\xe7\x20\x28\x07\x27\x95\x0c\x41\x8a\x6b\x64\x4b\x61\x29" +
+	"\xb2\xb4\x97\x18\xd1\xbe\x6c\xc2\xfe\xe1\xb8\x84\x2a\x2b\x82" +
+	"\x43\xc0\x85\x2d\x25\xdb\x27\xb0\x96\x3a\xfd\xba\x1a\x12\xd2" +
+	"\xf4\x91\x6f\xcb\x0b\x14\x3d\x07\x81\x2b\x12\x33\xd8\x76\xc7" +
+	"\x77\x20\x76\x8c\x92\x4c\xa7\x95\x0d\xc1\xc7\x53\xff\xd1\xe0" +
+	"\x34\x93\xd0\xf0\xef\x2b\x5d\x32\x83\x8a\xce\xfd\xff\x40\xd6" +
+	"\xeb\x8a\x48\x2b\x38\x0c\x83\xea\x81\x9e\x67\x7c\x3e\x4b\xbd" +
+	"\x70\x3c\xf5\x35\x70\xaf\xad\xd2\xca\x58\x37\x70\xa5\x16\x8a" +
+	"\xd0\x78\x35\x91\x19\x56\x20\x1c\x06\x1e\xce\x73\x0a\xc6\xa9" +
+	"\x56\xc4\x84\x42\x13\x11\x5b\xcb\xd6\x66\x2b\x38\xe5\xdd\xe9" +
+	"\x2f\x84\x8a\x3a\x3b\xe1\x29\x1f\xc6\xfa\x32\xcb\xb2\x55\xd0" +
+	"\xaf\x4d\x3b\x97\x7e\xcf\x5c\x94\x64\x12\x07\x22\x37\x72\x17" +
+	"\x2c\x0a\xeb\xb5\x87\x92\x13\x06\x93\x62\xa3\x22\x41\x58\xd8" +
+	"\x04\x52\xac\x09\x71\x82\xbf\x2a\x4b\x22\xdb\x47\x2e\x5d\x54" +
+	"\xd4\x17\x3a\xfb\x68\x6b\x58\x99\x80\xfb\xb9\xea\x2f\xd0\xf9" +
+	"\xbc\xdc\xc1\xdc\xcd\x8c\x63\x6c\xec\x34\x8e\x5c\xb5\x3c\xc5" +
+	"\xf7\xad\x3c\xb8\x49\xe3\xa9\xd9\x24\x2b\x2d\x26\xd0\xfe\xd6" +
+	"\xec\x21\x67\x1b\x49\xb6\xd7\x15\x25\x99\xd8\x21\xef\x54\xfd" +
+	"\xd0\xdb\x13\x1c\x1f\xa7\x71\xea\xc5\x94\x87\x40\xbc\x71\xe9" +
+	"\xd7\xf4\xb6\xa9\x54\xa5\x2c\x52\x2f\x9d\xc8\xd4\x92\xd9\x71" +
+	"\xce\x5b\x81\xc4\xac\x77\xee\xc6\x2d\xf2\x7c\xf6\xc9\xda\xe6" +
+	"\x82\x30\xb2\x25\x4b\xfd\x9c\x9d\x08\x73\x3d\x99\x49\xbd\x4d" +
+	"\x20\x17\x9c\xa3\x6a\x87\xe6\xdd\x2f\x6e\x41\x8d\x53\x2d\xab" +
+	"\x42\xc5\x67\xcc\xdc\xbd\xba\x14\xc5\xa2\x65\x73\x32\x82\x77" +
+	"\x05\xdb\x60\x07\x61\xd6\x47\x98\x39\x84\xda\xd1\xff\x75\x51" +
+	"\x30\xc5\x3b\xba\xf3\x0b\xde\x6a\xdd\xdf\x59\xd1\x05\x5d\x5c" +
+	"\x55\x7c\xf7\x47\x47\xed\xee\x4c\x6d\x76\x54\x7b\xaf\xd3\x8f" +
+	"\x68\xdc\xb2\x3f\x69\x2f\xcf\xb4\xa7\x47\xed\x8f\xc4\xa8\xb2" +
+	"\x1d\xd5\xfb\x0b\xaa\x17\xaa\x76\xec\xc2\xc9\x08\xde\xf8\xa6" +
+	"\xe8\x82\x3c\xfc\x03\xb7\xbd\xd2\xbb\x56\xf6\xad\x1a\xe5\xc8" +
+	"\x38\x50\x0e\x87\x9e\x32\xe9\x32\x81\xf8\xe1\xbc\x4b\xd6\x9a" +
+	"\x48\x17\xad\xe4\x04\xe2\x2e\x10\xa7\x26\xec\x83\x2c\xaf\x82" +
+	"\x2c\x1b\x90\xc9\x08\x28\x47\x90\x3a\xf5\xdf\x5f\x0b\xae\x8c" +
+	"\x94\xa3\x30\x90\x6a\xa5\x30\x75\xa7\x60\x89\x11\xd6\x09\x9f" +
+	"\x34\x7b\x0e\x1d\x73\xe5\x0e\x16\xc7\x26\xee\x5d\x5a\xde\x33" +
+	"\xd2\xd1\x07\xdc\x54\x92\xb5\x83\xef\xc4\x56\x7c\xc3\x04\xe2" +
+	"\x79\x9b\xd0\x09\xdb\x96\xac\x5d\xe2\xf5\xa7\xb3\x3f\x3d\x7d" +
+	"\xe5\x71\x13\x29\x72\xe8\x4f\xea\x74\xca\xf8\x5d\x36\x50\xe7" +
+	"\xc2\x5e\xb3\xe8\xcc\xf6\x64\xf4\xfa\xa7\x9f\xe0\x33\xfc\x2a" +
+	"\x76\xe0\xea\x8c\xc6\x5f\x1b\xc7\xdd\x25\x55\xea\xf6\x6a\x63" +
+	"\x50\x32\x12\x4f\xb8\xea\x72\x5a\x17\x71\x79\xa4\xf4\xfa\x55" +
+	"\xaf\xd3\x0e\xce\xd7\x00\x9e\xad\xad\x96\x15\xe1\xaa\xd7\xa5" +
+	"\xc7\xdd\xe8\xfd\x4c\x9b\xcb\x87\x5f\x03\xd3\x0e\xe4\xf8\x09" +
+	"\xcd\xfc\xd4\xa6\xc3\xc2\xbe\xd1\x92\x0f\xab\x7a\xd7\x86\x7d" +
+	"\x5e\x0d\x77\xe6\x3e\xcb\xa7\xaf\xda\x78\xd1\x59\x7c\xfe\x1e" +
+	"\x90\x69\x53\x24\x50\x95\x25\x9a\x94\x59\xfc\xbe\xfc\xc7\xa9" +
+	"\x0b\x75\x76\x1a\x86\x0b\x06\xf1\xbd\x7b\x87\xd9\xf1\xab\xd9" +
+	"\x5d\x6d\xdb\x41\x82\x1e\x3a\xbe\x95\xe0\x85\x80\xa6\xfe\x79" +
+	"\x98\x75\xa7\xd1\x92\x2e\x4b\xe4\x70\xbc\x21\xd8\xb0\x9d\x4b" +
+	"\x30\x58\xe8\x27\xe4\xa0\x15\x5a\xb0\x42\xa5\xe8\x8f\x4b\xb7" +
+	"\xaa\x85\x05\xa9\x19\xc7\x7a\x11\x77\xd3\x33\x9f\x39\x23\x16" +
+	"\xf9\x39\x7e\xfd\x02\x77\x82\x90\xbf\xf8\x02\xc4\xc3\x5b\x7a" +
+	"\x1c\x59\xad\x05\x87\x5e\xa2\x9d\x29\xb9\x61\xdd\x44\x7a\x09" +
+	"\x60\xc1\xdc\x7b\xe5\x3f\x00\x4f\x1d\xe5\x46\x57\x9b\xbc\xcb" +
+	"\x8a\x41\xab\x2b\x93\x22\x54\x96\x6d\x30\x04\x9b\xeb\xad\x82" +
+	"\xad\xa0\x1c\xfe\xeb\x5c\xda\xd7\xf1\x20\x6f\x0e\x63\xaf\x0d" +
+	"\x87\x41\xf9\x66\xe5\x6e\x15\x3c\x07\x7f\x03\x00\x00\xff\xff" +
+	"\x01\x00\x00\xff\xff\x28\xc3\x1d\x55")
 
 var _file_3 = &file{
 	fileInfo: &fileInfo{
 		name:  "list.css",
 		isDir: false,
-		size:  2961,
+		size:  3615,
 		mode:  os.FileMode(0),
 		mTime: time.Unix(-62135596800, 0),
 		cType: "text/css; charset=utf-8",
@@ -482,41 +502,45 @@ var _file_8 = &file{
 }
 
 var _compress_bytes_9 = []byte("" +
-	"\x78\x9c\x94\x53\x41\x8f\xd3\x3c\x10\xbd\xf7\x57\xbc\xaf\x97" +
-	"\xba\xea\x2a\xad\x3e\x71\x40\x14\x1f\x58\x09\x09\x21\xd8\x45" +
-	"\xb4\x07\x24\xc4\xc1\x75\xa6\x89\x17\xd7\xee\xda\xe3\x65\x2b" +
-	"\x94\xff\x8e\x92\x8d\x97\xb4\xa5\x48\xcc\xc9\x1e\xbf\x37\xef" +
-	"\xcd\x4c\x32\x9f\x43\x7b\xc7\xca\x38\x0a\xdd\x29\x78\x3b\x1a" +
-	"\x71\x38\xe0\xe7\x08\x00\x1e\x54\x40\xcd\x3b\x7b\xcd\x2e\x42" +
-	"\xa2\xf4\x3a\xed\xc8\x71\x51\x11\xbf\xb5\xd4\x1e\xe3\xf5\x61" +
-	"\xad\xaa\x1b\xb5\x23\x31\xd9\x24\x66\xef\x26\xd3\x65\xc7\xdd" +
-	"\xfa\x00\xd1\x16\x30\x90\x58\x2c\x61\xf0\xfa\xb9\x56\x61\xc9" +
-	"\x55\x5c\x2f\x31\x9b\x99\x69\xaf\xd5\x46\x7e\xff\x6a\xbe\x15" +
-	"\xde\x69\x6b\xf4\x77\x48\x6c\x93\xd3\x6c\xbc\x83\x18\x62\xb3" +
-	"\x3f\x6d\x4a\x48\x70\x6d\x62\xb1\x57\x81\x5c\x76\x76\x72\xbb" +
-	"\x4f\x14\x0e\x2b\xb2\xa4\xd9\x07\x31\x51\x93\x69\xdb\xc5\x1b" +
-	"\xe6\x60\x36\x89\x49\x4c\x1e\x94\x4d\x94\xcd\x0f\x05\xd4\x93" +
-	"\x78\xaf\xc1\x86\x2d\x9d\x83\x12\x24\xc6\xf3\xe7\x61\xce\xc7" +
-	"\x98\x65\xe2\x0c\xe3\xee\xaa\x4d\x79\xce\x7b\xdc\xd9\x9a\x79" +
-	"\x0f\x09\x47\x3f\xf0\xe5\xe3\x87\x77\xcc\xfb\xcf\x74\x9f\x28" +
-	"\xb2\x38\x31\xd3\x63\x0b\xbf\x27\x27\xc6\x9f\x6e\x57\xeb\xf1" +
-	"\x15\xd2\x25\x90\x0b\xa4\xca\x43\x64\xc5\xa4\x6b\xe5\x2a\xfa" +
-	"\xeb\x24\xdb\x30\x5b\x88\x4c\xef\xc8\xab\x96\x0c\x29\xf1\xe2" +
-	"\x4f\xf0\xdc\xc2\x1d\x24\xde\xaf\x6e\x6f\xda\x81\x47\x1a\x54" +
-	"\x88\x7b\xef\x22\xad\xe9\x91\x4f\x3c\xe6\xd0\xde\x45\x6f\xa9" +
-	"\x28\x69\x93\x2a\x71\x77\x01\x35\xb4\xd5\xb6\x93\x22\xfe\x93" +
-	"\xf8\x7f\xb1\xb8\x64\xaa\x0d\x65\x29\xf0\xbf\x78\x69\xce\xb2" +
-	"\xc7\x99\xe6\x98\xf6\x54\xff\xf7\x86\x07\xff\x51\xbf\xeb\x22" +
-	"\xa6\x4d\xe4\x60\x5c\x25\x16\x57\x78\x39\x3d\xd1\x3d\xee\xbd" +
-	"\xdb\xe6\xab\x8e\x7a\x69\xa1\x91\x5c\x39\xfc\x22\x7a\x43\xcd" +
-	"\xa8\x81\x56\xac\x6b\x08\x0a\xc1\x87\x3c\x94\x5c\xbf\x4b\xf6" +
-	"\x4f\xcb\x51\xf3\x2b\x00\x00\xff\xff\x54\x02\x16\x01")
+	"\x78\x9c\x8c\x93\x41\x6f\xdb\x3c\x0c\x86\xef\xfe\x15\xfc\x72" +
+	"\xb1\x8d\x06\x76\xf1\x61\x87\x61\x81\x0f\x1b\x50\x60\x18\xba" +
+	"\x66\x58\x32\x60\x57\x59\x62\x1d\x75\x8a\xe4\x52\x54\xda\x60" +
+	"\xf0\x7f\x1f\xa4\xc4\x89\xd3\xe5\x30\xc8\x07\xd1\xe4\x43\xbe" +
+	"\xd2\x6b\xd7\x35\x48\x67\x59\x68\x8b\x94\x76\xe4\x4c\x96\x31" +
+	"\xed\xe1\x77\x06\x00\x50\xd7\xc0\x1b\x04\x72\x2f\x1e\xb6\x62" +
+	"\x0f\x2d\x82\x50\x0a\x15\xb4\xfb\x94\x30\x7a\x87\x10\x7a\x25" +
+	"\x18\xfd\x7c\x24\xbc\x4b\x39\x69\xb4\xfc\xe5\x41\x10\xc2\x46" +
+	"\x58\x65\xce\x94\x72\x32\x6c\xd1\x72\x02\xc6\xa0\x12\x4a\xdd" +
+	"\xed\xd0\xf2\xbd\xf6\x8c\x16\xa9\xc8\x53\x87\x7c\x0e\x8f\xc1" +
+	"\x4a\xd6\xce\x42\x81\xb1\xa0\x3c\x8a\x8b\xcf\x4e\x10\xb4\x6c" +
+	"\xa1\x81\x94\xaa\x58\x50\x87\xbc\x38\xe5\xf5\x23\x14\x2d\xdb" +
+	"\x8a\x45\xf7\x20\xb6\x08\xff\x35\x90\x7f\xfa\xb1\x5e\x2f\x1f" +
+	"\xf2\x69\x9b\xb8\x08\x39\x90\x3d\xa3\xc3\x69\x17\x87\x48\xad" +
+	"\xa0\x89\xa3\xaa\x5e\x10\x5a\xbe\x33\x98\x54\x5f\x46\xcf\x01" +
+	"\x69\xbf\x42\x83\x92\x1d\x15\xb9\xc8\xcb\xaa\x43\xfe\xc8\x4c" +
+	"\xba\x0d\x8c\x45\xbe\x13\x26\x60\x5e\x9e\xa7\xc4\xde\xe2\x70" +
+	"\xba\x43\x7b\xd6\x6c\xf0\x32\x1f\xa0\x81\x59\x7d\x32\xaa\x9e" +
+	"\xc1\xcd\xc8\xdc\xc0\x2c\x85\x52\xab\x4b\xe6\x75\x6b\x36\xcc" +
+	"\x3d\x34\x60\xf1\x05\x7e\x7e\xbd\xff\xcc\xdc\x7f\xc7\xe7\x80" +
+	"\x9e\x8b\xc9\xfc\x63\x5d\xe5\x7a\xb4\xc5\xec\xdb\x72\xb5\x9e" +
+	"\xcd\x21\x5c\x2b\xb0\x84\x42\xed\x3d\x0b\x46\xb9\x11\xb6\x43" +
+	"\x68\x26\xce\xbc\xbd\xcd\x78\xf1\x23\x9a\xc0\x55\x04\xa1\x69" +
+	"\xe0\xdd\xdb\xd2\x51\xf2\x13\x34\xf0\x65\xb5\x7c\x88\x37\xec" +
+	"\x71\x42\xfb\xde\x59\x8f\x6b\x7c\xe5\x72\xf1\x17\x29\x9d\xf5" +
+	"\xce\x60\xa5\xb0\x0d\x5d\xf1\x74\xa5\x62\x2a\x25\xca\x0f\x3e" +
+	"\x7e\x06\xff\xdf\xde\x5e\x13\x12\x97\x30\x48\xfc\xaf\xf3\x87" +
+	"\xec\x7a\x34\x9c\x4b\x0f\xfd\xce\x8e\x4d\xfe\xb9\xa3\x77\x95" +
+	"\x0f\xad\x67\xd2\xb6\x2b\x6e\xe7\xf0\xbe\x9c\xcc\xb9\x3c\x5f" +
+	"\x72\xe8\x43\xc2\xae\x99\xe4\xd1\xaa\xd1\xdd\xa1\x5c\x64\x03" +
+	"\x48\xc1\x72\x03\x05\x12\x39\x1a\xcf\x3b\xb6\x4c\x2f\x0b\x24" +
+	"\x72\x54\x2e\xb2\xe1\x0f\x00\x00\x00\xff\xff\x01\x00\x00\xff" +
+	"\xff\x57\x9f\x28\x79")
 
 var _file_9 = &file{
 	fileInfo: &fileInfo{
 		name:  "control.js",
 		isDir: false,
-		size:  1010,
+		size:  1037,
 		mode:  os.FileMode(0),
 		mTime: time.Unix(-62135596800, 0),
 		cType: "text/javascript; charset=utf-8",
@@ -11154,63 +11178,360 @@ var _file_10 = &file{
 }
 
 var _compress_bytes_11 = []byte("" +
-	"\x78\x9c\x9c\x96\xdd\x6e\xeb\x36\x0c\xc7\xef\xf3\x14\x9c\x90" +
-	"\x0d\x2d\x30\x5b\x4d\xda\xae\xc5\x20\x7b\x28\xd6\x5d\x04\x28" +
-	"\x86\x62\x7d\x80\x41\x91\x95\x44\xad\x2c\xe5\x48\x4a\xda\xc2" +
-	"\xf0\xbb\x1f\xc8\x5f\xf1\x47\x52\xe7\x9c\xab\x38\xe4\x5f\x3f" +
-	"\x52\x34\x49\x38\xcb\x02\x98\x32\x27\xe1\xcf\x08\x42\xa6\x95" +
-	"\x33\x5a\x42\x90\xe7\x50\x38\xec\x46\xbf\x3f\x69\x46\x9d\xd0" +
-	"\xaa\x50\x48\xcd\xda\x5e\x6a\x78\x61\x2e\x9f\x1a\xc7\x92\xda" +
-	"\xd2\x5e\x3c\x04\x79\x3e\x21\xbf\x24\x9a\xb9\xcf\x2d\x87\x8d" +
-	"\x4b\x65\x3c\x21\xe5\xcf\x84\x6c\x38\x4d\xe2\x09\x00\x71\xc2" +
-	"\x49\x1e\x67\x19\x84\xc5\x13\xe4\x39\xc1\xa5\xcd\x7b\xa5\x50" +
-	"\x6f\x60\xb8\x8c\x90\x60\x5a\x21\xf0\xa8\x08\x89\x94\xae\x39" +
-	"\xde\xaa\x35\x82\x8d\xe1\xab\x08\x65\x59\x11\x32\xcf\xf1\x8a" +
-	"\xee\xbd\x32\xf4\xce\x1e\xc1\xba\x4f\xc9\xed\x86\x73\x77\x38" +
-	"\x36\xad\x8e\x31\x6b\xb1\x14\xd6\x85\xcc\x5a\x04\x38\x9e\x10" +
-	"\x5c\x66\x38\x21\x4b\x9d\x7c\x16\xa4\x44\xec\x81\x49\x6a\x6d" +
-	"\x84\x1c\x5d\x4a\x0e\x7b\x6e\xae\x21\x0d\x96\xc1\x6c\x76\x55" +
-	"\x04\x3b\x22\x0a\x3c\xa6\x72\xfa\xdb\x7a\x5b\xfd\xcf\xff\xaf" +
-	"\xeb\x70\xb0\x98\xf6\xdf\x42\x52\x03\x99\x96\xbb\x54\xcd\x50" +
-	"\xfc\xb7\x56\x8e\x0a\xc5\x0d\x2c\x1e\x09\x76\x9b\x91\x13\x73" +
-	"\x14\x2f\x7c\xc5\xce\x90\x5e\x7b\x78\x9a\x52\x95\x9c\x21\xbe" +
-	"\x41\xf1\xbf\x34\x3d\x07\x7b\x8b\xe2\xc5\xf3\x50\xe7\x9b\x46" +
-	"\xac\x7a\xed\x96\xe7\x5f\xb3\xfe\x40\x71\xad\x3d\x4e\xe4\x2a" +
-	"\x19\x85\xdc\xa1\xf8\xc5\x51\xb7\xb3\xa7\x93\x62\x4e\x86\xff" +
-	"\xa8\xe2\x45\x8f\xd1\xee\x51\xfc\xc0\x7c\x42\x27\x70\x3e\xa3" +
-	"\xa0\x03\x21\xb8\xfd\x9e\xfd\xa9\x56\x1f\x10\xdc\x6a\x13\x82" +
-	"\x13\xb1\x8f\x27\x27\xba\xcb\x37\xe7\x17\xdd\x55\xf7\xee\x21" +
-	"\x19\x30\x54\xad\x39\x4c\xc5\xef\x30\xe5\xcd\xf0\x17\xcd\x64" +
-	"\xbb\xf7\x24\xce\x78\xbd\x58\xc1\x05\xff\x06\x17\xa9\x4e\x60" +
-	"\x2a\x60\x7e\x09\xb3\x4b\x7f\x99\x3a\x0d\x60\x7e\x0e\xe6\xe8" +
-	"\x50\xf9\x7e\x2f\x24\xfd\xfe\x85\x62\xbe\x23\xc4\x3f\x38\x03" +
-	"\xa1\x9c\x86\x26\x07\xd4\x3d\x0c\x40\xe8\x60\x56\x39\xce\x32" +
-	"\xd8\x1a\xa1\xdc\x0a\xd0\xaf\xe1\x6c\x6e\x11\x84\x8b\x47\xc8" +
-	"\x73\x04\x7b\x2a\x77\xdc\x8b\x1b\x8b\xa3\x66\xcd\x5d\x84\xfe" +
-	"\x5f\x4a\xaa\xde\x50\x7c\xea\x2c\xc1\xb4\x97\x38\x76\xc9\xc8" +
-	"\x55\xe6\xcd\x55\x8a\x80\x7e\xc6\x7c\xcc\x56\x8c\xc6\x78\x06" +
-	"\xed\xba\x43\xab\xc6\xb0\xcf\x3b\x98\xcf\x20\xde\x74\x88\x7e" +
-	"\x56\x0b\xdc\x78\x89\xa5\x5e\xdb\x93\x55\xfe\x6b\xa5\xa5\xd4" +
-	"\xef\xd1\xec\x37\x47\x85\x8c\x66\x57\x83\x22\xd7\x51\xd7\xdc" +
-	"\x81\x47\x75\x6e\x50\xa5\xf1\x33\xf5\xbe\xed\xd6\xfb\xd9\xd6" +
-	"\xd5\x11\x2a\xe1\x1f\xa5\xe5\xea\x68\x69\x8e\x6e\x9a\x60\x30" +
-	"\xd7\x49\x7f\xd5\xb4\xe3\x3d\x69\xf6\xc2\xcd\x9e\x9b\xfe\x3b" +
-	"\x69\x3b\x8e\x87\x1e\x2e\x80\x23\xd1\xee\x3a\xd1\xfc\x7a\x6a" +
-	"\xba\x29\x2c\x97\xd5\x09\x7e\x7f\x5d\x8d\x46\xba\x1f\xf4\x40" +
-	"\x09\xd1\xa6\xe4\xbc\x38\x6a\x5c\xf9\xf8\x20\x65\x7f\xfd\x01" +
-	"\x90\xe5\xce\x39\xad\xea\x74\xad\x97\x17\x0b\xd5\x38\x82\x4b" +
-	"\x9f\xcf\xba\x5c\x07\x03\xb6\xde\xfe\x08\x5a\x6f\x3d\x59\x6f" +
-	"\x47\xc1\xff\x71\xdb\x49\x7b\x0c\x6d\x78\x95\x77\x75\x70\x18" +
-	"\x60\xa4\x3d\x2b\xdd\x57\x8b\xbd\x11\xb5\x34\x04\x77\xd6\xf2" +
-	"\xb1\x65\xdf\xde\xfa\xc4\x32\x23\xb6\x0e\xac\x61\xed\x09\x7d" +
-	"\xb5\xb8\xfa\x6a\x0b\x5f\x2d\x8a\x09\x2e\x65\xfe\xc3\xa5\xa4" +
-	"\xfb\x2f\x18\xff\xad\xf5\x3d\x00\x00\xff\xff\x5e\xc4\xd6\x42")
+	"\x78\x9c\xac\x57\x6d\x6b\x1b\xb9\x13\x7f\x9f\x4f\x31\xdd\x17" +
+	"\xf5\x9a\x6c\x37\xe1\xcf\xff\x38\x88\x31\x47\x69\x7b\x4f\xf4" +
+	"\xe1\xa8\x7b\x70\x10\xfa\x42\x96\xc6\xb1\xa8\x2c\xb9\xd2\x6c" +
+	"\xdc\x50\xfc\xdd\x8f\xd1\x6a\xd7\xda\xb5\xdd\x86\x72\x56\x88" +
+	"\xcd\x6a\xe6\x37\xcf\x0f\x7b\x75\x05\x46\xdf\x23\x34\x5b\x25" +
+	"\x08\x03\xb8\x15\xd0\x1a\x41\x3a\x4b\x42\x5b\xf4\x60\x74\xa0" +
+	"\x0a\x96\x0f\xf1\xf1\x02\xfd\x3d\xfa\x67\x0b\xb4\x04\xaf\xee" +
+	"\xd1\x52\xb8\xb8\x28\x57\x8d\x95\xa4\x9d\x85\x72\x0a\x5f\x2f" +
+	"\x00\x00\xf4\x0a\xca\x27\x3b\x6d\x95\xdb\xd5\x91\x6c\xe1\x1a" +
+	"\x2f\xb1\xbb\xe6\xe3\x91\x1a\x6f\x67\x91\x7c\x1f\xff\xdf\x0b" +
+	"\x0f\x4b\x11\x10\xe6\xa0\x9c\x6c\x36\x68\xa9\x96\x8d\xf7\xcc" +
+	"\x2e\xbd\xde\x52\x7d\x87\xf4\x9c\xc8\xeb\x65\x43\x58\x4e\x94" +
+	"\x20\xf1\x8c\x19\x26\xd3\x59\x0f\x40\x4b\xa7\x1e\x72\x84\xcf" +
+	"\x0d\xfa\x87\x05\x1a\x94\xe4\x7c\x39\xa9\x49\x2c\x0d\x3e\x8b" +
+	"\x54\x91\x96\x99\x23\x77\x6f\xc5\x4a\x5b\x55\x7a\xb7\x0b\x15" +
+	"\x68\x75\xac\x32\xf0\xd5\x18\x96\xfc\x6d\x54\x47\xab\x79\x31" +
+	"\x81\x4b\xd0\x0a\x2e\x61\x52\x7c\xec\x54\xdb\x8f\x64\x04\xa4" +
+	"\x05\x09\x6a\x02\x0b\xaa\x20\xc4\xdf\x15\x90\x26\x33\xf0\x52" +
+	"\x34\x49\xc1\x9c\x85\x1e\x99\x22\x9d\x69\x36\xf6\xe7\x4e\x06" +
+	"\x1f\x52\x63\x2a\x31\x99\xd6\x84\x5f\xe8\x85\xb3\xc4\x51\x9b" +
+	"\x27\x61\x03\x9e\x28\x17\xe6\xad\xfc\x81\xc6\x57\x57\x31\xee" +
+	"\x4d\x10\x77\x78\x94\x1b\xec\xa1\xf6\x11\x63\x06\x10\x1e\x21" +
+	"\xac\xdd\xce\xf6\xe1\x08\xe4\x51\x6c\x02\xcc\xe1\xeb\x7e\x36" +
+	"\x74\xc1\x4e\x90\x5c\xff\xcd\xb8\xec\x83\xb1\xd1\xad\xc0\xd3" +
+	"\x76\xc7\xbb\xdc\xea\x98\x6e\xf1\x69\x0e\x33\xce\xb2\x43\xa6" +
+	"\x75\x42\x74\xe7\xd9\x13\x89\xa5\x55\x2e\xa1\xb1\x99\xba\x5a" +
+	"\x65\x37\xc9\xc2\x5b\xad\x3e\xc2\x1c\x22\x15\x47\x36\x94\x9c" +
+	"\x99\x9c\x41\xd5\xc1\xe4\x32\x8c\xf5\x8b\x4a\x8f\xe2\xb3\x72" +
+	"\x7e\x23\xa8\xf5\x4c\xc8\x24\xed\x73\x24\xf4\xfe\x0c\x56\x0a" +
+	"\x25\x7a\x9f\xb1\x4e\x07\x41\xed\x61\xc6\x66\x65\x88\xec\xd3" +
+	"\xcc\xb6\xb1\xb0\xec\xaa\x96\xc6\x05\x2c\x33\x4d\xf9\x4f\xa1" +
+	"\x41\xc2\x9c\x2e\x53\x67\x94\x61\x2b\x24\xb9\x7e\xef\x76\x01" +
+	"\x3c\x5a\x85\x3e\xc4\x9c\xe2\xc6\x03\xe2\x4e\x68\x0b\xc2\x2a" +
+	"\x90\xc2\x98\x00\x4b\x21\x3f\xc1\x4e\xd3\x3a\x92\x70\x29\x0e" +
+	"\x2d\xea\xa1\x4a\xa6\x67\xea\x5c\x73\x0e\xfa\x97\x8d\x59\x13" +
+	"\x6d\x61\x0e\x16\x77\xf0\xcf\x9b\xd7\xbf\x13\x6d\xdf\xe3\xe7" +
+	"\x06\x03\xe5\x46\x24\xba\xda\x6d\xd1\x96\xc5\x6f\xaf\x3e\x14" +
+	"\x55\xdb\x9e\x2e\xa1\xb8\x2a\xe0\x12\x8c\x93\x82\xbd\x58\x07" +
+	"\x14\x5e\xae\x4f\xb1\x5a\x8f\x42\x3d\x04\x12\x84\x72\x2d\x6c" +
+	"\xcc\xe8\xe3\x6e\xd9\x7d\xd8\xe5\x1d\x6b\x64\xe4\x44\x42\x98" +
+	"\xcf\xe1\xff\xf0\xf4\x69\x8f\xca\x70\x4d\xe0\xc7\xff\xbb\xbe" +
+	"\x1e\x63\x74\x56\x2a\x27\x93\x85\x2f\xdf\xbd\xf9\x4b\xf8\x80" +
+	"\xbe\x9c\xd6\x5b\xfe\xf1\xab\x77\x9b\x05\x79\x6d\xef\x32\x69" +
+	"\x61\xeb\x6c\xc0\x0f\xf8\x85\x2a\x28\x38\x1f\xaf\xd6\xb4\x31" +
+	"\xc5\x74\x76\x84\xde\xf9\xb5\xec\x5b\xac\xde\x6c\x9d\xa7\xb7" +
+	"\x4e\x21\x3f\x7b\x44\xc7\xad\x80\x7c\x83\xd3\x11\xf8\xa1\x36" +
+	"\xf7\xc7\xae\x0c\x68\x55\x39\xcc\xe2\xd4\x9a\xa4\x47\x41\xa8" +
+	"\xb2\xae\x14\xfb\x50\x9b\x49\xa8\xba\xc9\x15\xe2\xe4\x02\x6d" +
+	"\x61\xc9\x35\x8a\xa1\xef\x51\x1d\xc0\xa1\x47\xb1\x03\x49\x6f" +
+	"\xd0\xb3\x0b\x1b\x63\x46\x9d\xab\x65\x28\x65\xee\xfb\x04\x72" +
+	"\x2b\xeb\x3f\x5e\x72\x23\x60\x03\x0f\x56\x70\x64\x23\xe0\xe3" +
+	"\xfb\x53\x27\x3f\x20\x7d\xd0\x1b\x74\x0d\x9d\x98\xb3\xdd\x61" +
+	"\x85\xb5\xe2\x36\x9b\xf4\x38\x20\x66\xca\x65\x16\x8e\xa5\x1c" +
+	"\xac\xec\x3e\x87\x42\x3a\x88\xe5\x72\x1b\x8b\xe6\xb3\x72\x1e" +
+	"\xca\xd4\x50\xb5\x05\xad\x4e\x52\x75\x8a\x7a\xb7\x83\xf9\x68" +
+	"\xca\xce\x2e\x4e\x10\xf3\x70\x29\x9f\x8c\x86\xc3\xf8\x70\xd8" +
+	"\xb5\xcd\x9d\x7d\x3a\xa7\xf2\x0f\x6b\xe1\x8c\xea\xb4\x88\x59" +
+	"\xf9\x1d\x35\x9c\x19\x74\xc7\xf1\x89\x10\xb5\xc7\xad\x11\x12" +
+	"\x5f\xac\xb5\x89\xb6\x55\xe0\xcc\x39\xd0\x3d\xa0\x09\xf8\x5d" +
+	"\x48\xb1\xdd\xa2\x55\x3d\xe2\x39\xb0\x93\x4f\xb3\xde\x7e\x9a" +
+	"\x75\xc8\xb6\xcf\x28\xf6\x15\xfc\x74\x7d\x3d\xac\xb7\x3e\x0f" +
+	"\xda\x55\x71\x98\xff\xa3\xc0\x26\x97\x72\x35\x64\xa8\xe7\xe2" +
+	"\xd9\x17\xd4\xec\x91\xc5\xc1\x33\x3b\x1c\xcf\x6c\xee\x8c\x38" +
+	"\xa9\x40\xd6\xb1\x73\x66\x92\x47\xfb\x56\x4b\xd0\x04\xb8\x84" +
+	"\x32\xfe\x36\x08\xbf\x40\xc1\x13\x4f\x18\x9c\x16\x70\x03\x45" +
+	"\x31\xad\x7a\x76\xfe\x4b\xa0\x23\x96\x2a\xf6\x20\xee\x87\x68" +
+	"\x15\xe8\x00\x4a\x07\xe9\xac\x45\x49\xa8\x12\xce\xd0\x8d\xa9" +
+	"\x6d\x79\xdc\xb8\xfb\xe3\xb6\xf5\x09\xb7\x04\x8d\x25\x6d\xc0" +
+	"\xa3\x71\x42\xa1\x1a\x3a\xbf\xe5\xfb\x0f\x9d\xff\xc3\x5e\x9e" +
+	"\x24\x13\x26\xe7\xfd\x5c\xbc\x6f\x49\x0a\x1e\x2a\x83\xb7\x0a" +
+	"\x1d\x92\x25\xaa\x38\xb7\x66\x65\xfa\x0f\x5d\x87\xfc\x46\xd1" +
+	"\xba\xcb\xb8\x40\xb0\x5b\x6b\x83\x03\xc7\x57\xc9\x79\xfd\x1e" +
+	"\x31\xf6\x61\x78\xb0\x72\xd0\x45\x1f\xdb\xee\x62\x62\xf3\x1c" +
+	"\x45\x1b\x27\xde\x51\xcd\x87\xaa\xad\xdc\xe9\xec\x98\xaf\xdd" +
+	"\x36\xc3\xec\xe2\x1b\xcd\x33\x2d\x4c\x63\xb9\xdf\x5e\x42\x87" +
+	"\x71\x3b\xe8\x39\x18\xc5\xcf\x8d\xe1\x17\x95\xc9\xb4\x5e\x39" +
+	"\xff\x4a\xc8\x75\x79\x80\xcb\xb0\xba\x36\xb0\xbf\xf8\x31\x9c" +
+	"\x7e\x82\xae\x85\x55\x86\xf3\x7a\x0e\x5f\x53\x89\xdf\xa4\xef" +
+	"\x2a\xbd\x6e\xde\xa4\xef\x2a\x25\xc3\x4d\xfa\xee\x36\x00\x4e" +
+	"\xec\x10\x5f\x1d\xd3\x32\x93\xbd\x4c\x96\xfd\x32\xd6\xe6\x43" +
+	"\x97\x47\xcc\x13\xd3\x62\x0e\x2b\x61\x42\x1a\x0c\xbd\x97\x45" +
+	"\x9b\x02\xda\xf6\xfa\xe5\xae\xce\xa2\x9f\xae\xc7\x81\x68\xd5" +
+	"\xa9\x85\x52\x51\x97\xd7\x3a\x10\x5a\xf4\x65\x8b\x3b\x58\xd1" +
+	"\xf9\x7e\xcc\xde\x69\xc8\xdb\xd9\x9f\x8b\x77\x6f\x39\x95\x02" +
+	"\x96\xd1\x84\x9a\xcb\x2b\x59\x91\x1f\xe9\x6c\x70\x06\x6b\x85" +
+	"\xcb\xe6\x2e\x91\xd2\xc3\x16\x8f\x4a\xbc\x3b\x49\xf5\xa3\x86" +
+	"\x3a\xe8\xf0\xd3\x32\x91\x85\xdb\x56\xf7\x8f\xe9\x76\x7f\x91" +
+	"\xd9\xe9\x2c\x7a\xef\xfc\xd9\xbd\x35\x79\xfa\xb0\xee\xec\x67" +
+	"\x43\x76\x5e\x9f\xcf\x72\xf3\x40\x60\x84\xfc\x59\x86\x9a\xc5" +
+	"\xaf\x3b\x5d\xe9\x66\x86\x74\x62\xf7\xd3\x72\x3a\xfb\x17\x00" +
+	"\x00\xff\xff\x01\x00\x00\xff\xff\x64\x04\xb6\xed")
 
 var _file_11 = &file{
+	fileInfo: &fileInfo{
+		name:  "list.js",
+		isDir: false,
+		size:  4303,
+		mode:  os.FileMode(0),
+		mTime: time.Unix(-62135596800, 0),
+		cType: "text/javascript; charset=utf-8",
+	},
+	path:  "/js/list.js",
+	dirP:  "/js",
+	sPath: "/js/list.js",
+	id:    11,
+	cb:    _compress_bytes_11,
+}
+
+var _compress_bytes_12 = []byte("" +
+	"\x78\x9c\x8c\x55\xdb\x6e\xe3\x36\x10\x7d\xf7\x57\x9c\x15\xd0" +
+	"\x95\x54\x2b\x52\xb2\xe8\x53\x5c\x75\xd1\x6c\x2f\xe8\xbd\x40" +
+	"\x76\xd1\x87\xc5\x22\xa0\xa5\x49\x44\x44\x22\x5d\x72\x14\xc5" +
+	"\x28\xfc\xef\x05\xa9\xab\x9d\xa4\x5b\x48\xb0\xa8\xe1\xcc\x99" +
+	"\x99\xc3\xa3\x71\x96\x81\x2b\x82\x21\xab\x5b\x53\x10\x5a\x2b" +
+	"\xee\x08\xfa\xd6\x5b\x0b\xad\x58\x48\x45\xc6\x26\xd8\xee\xbd" +
+	"\xa9\xa3\xad\xd5\xc5\x3d\x31\x2c\x1b\x12\x8d\x5d\xad\xb2\x0c" +
+	"\x55\xdb\x08\x75\xb5\x67\xb2\xb8\xd5\xa6\x11\x6c\xbd\xf3\xd6" +
+	"\x5b\xa4\xea\x5f\xa4\x12\x66\x8f\x56\x49\xb6\xab\xdb\x56\x15" +
+	"\x2c\xb5\x5a\x44\x46\x2a\xc6\x3f\x2b\x00\x78\x10\xa6\x77\x43" +
+	"\x8e\x8f\xc1\x55\x90\x20\xf8\x45\xfa\xc7\x6f\xfd\xe3\xc7\xfe" +
+	"\xf1\x5e\x5e\x05\x9f\x36\x53\x88\x44\x8e\xf3\xfe\xb5\xab\x64" +
+	"\x4d\x88\x14\xbe\xc9\x71\x71\xfe\xe6\x2b\xbc\x7e\x0d\x89\xaf" +
+	"\x7b\xd4\xb4\x26\x75\xc7\x15\xce\x70\x31\x66\x74\x97\x42\xd6" +
+	"\x3b\x6f\x26\x93\x5c\xaf\xfb\x97\x83\xff\x35\xc4\xad\x51\x88" +
+	"\x24\xf2\x1c\xe7\x78\x0b\x85\x4b\xa8\x94\xf5\x0f\xf2\x91\xca" +
+	"\xe8\x4d\x1c\x63\xdd\xa7\xf8\x28\x3f\x6d\x56\x07\x4f\x4d\xcf" +
+	"\xc7\x07\x4f\x6b\x49\xb6\x30\x72\x4b\x3d\x3b\xef\xfe\xfc\x00" +
+	"\xa1\x4a\xbf\x6e\xa8\xd1\x66\x0f\xa9\x60\x2b\x6d\x78\xe6\x67" +
+	"\x11\x1e\xd9\x23\x82\x3c\x62\x0e\x9b\x16\xbb\xf6\x66\x47\xa6" +
+	"\x20\xc5\x8b\x5a\xb0\x46\xf0\x05\x02\xac\x97\x14\xdb\xb4\x4f" +
+	"\x74\xe3\x8f\x39\xee\x9b\x93\xb7\x98\x37\x6a\xd9\x48\x5e\xd2" +
+	"\xe2\x3d\xb1\xce\x11\x20\x7b\x11\xae\x8f\x7a\x86\x2b\x1f\x3d" +
+	"\x52\xd1\x09\x2e\xaa\x6b\x76\xea\x28\x44\x5d\x5b\x6c\x45\x71" +
+	"\x8f\x4e\x72\xe5\x39\xb0\x7e\xe7\x54\x7a\x68\x15\xcb\xda\xd9" +
+	"\x3c\xc4\x24\x3f\x69\x51\xd4\xda\x52\x99\x40\x2b\x32\x46\x1b" +
+	"\x6f\x12\x75\x4d\x25\xe4\x2d\x24\xa3\x10\x4a\x69\xc6\x96\x06" +
+	"\xad\x52\x39\xf3\x3a\xd7\x12\x6d\x85\xa5\x04\xb2\x4c\x7c\xb4" +
+	"\xab\x69\x82\x5c\x12\x6e\x8b\x8a\x1a\x42\x8e\x5a\x17\xc2\x69" +
+	"\x37\xdd\x19\xcd\xba\xd0\xb5\x93\x43\x50\x31\xef\xec\x65\x80" +
+	"\xb7\x08\x3a\x6b\x2f\xb3\x2c\xc0\xa5\x5b\xba\xd5\xac\xd1\xce" +
+	"\x22\x87\xa2\x0e\x7f\xd1\xf6\xda\x7f\x47\xd1\x80\xbb\x9e\x71" +
+	"\x2b\x6d\x19\x6b\xb8\xba\xdc\x29\x66\x9e\x98\xcc\x71\x2f\x4b" +
+	"\x6f\xe8\x6c\x30\x90\xdd\xd9\x54\xab\x86\xec\x20\x86\xa9\xbd" +
+	"\x88\x1e\x48\x1d\x9d\xa3\xcb\xee\x92\xff\x7c\xfd\xc7\xef\xe9" +
+	"\x4e\x18\x4b\xbd\x4f\x5a\x0a\x16\x03\xda\xac\x06\x32\x66\x19" +
+	"\xec\xae\x81\x92\x61\x73\x0e\x98\x4f\x7b\xb6\x1d\xa6\xd5\x48" +
+	"\x69\x64\x87\x1c\x87\xcd\x52\x1f\x9d\x1d\xc5\xe1\x14\xb0\x1b" +
+	"\x66\x8f\x98\x8f\x7f\x15\xcd\x3d\x8d\x15\xb9\x56\x1e\x24\x75" +
+	"\xc8\x51\xea\xa2\x6d\x9c\xf0\xef\x88\xbf\xaf\xc9\x2d\xaf\xf6" +
+	"\x3f\x95\x51\xe8\x39\x0b\x87\x9c\xae\xa7\x57\x2e\x62\xd9\xd3" +
+	"\xb2\xe6\xc3\x84\xeb\x7b\xfc\x2c\xf0\x99\x77\x1b\xe1\xa7\x0a" +
+	"\x2d\x71\xa4\x44\x43\x09\x98\x1e\x8f\xd9\x97\xd4\xa5\x7f\xb7" +
+	"\x64\xf6\xd7\x54\x53\xc1\xda\x44\x61\x1a\x62\x0d\xe7\x1e\xa7" +
+	"\xce\xfd\x9d\x56\x4c\x8a\x91\xfb\xe0\xb1\xae\xa9\x30\xaf\x9c" +
+	"\x85\x6a\x5d\x3b\xe9\x1d\xf1\xb7\xcc\x46\x6e\x5b\xa6\x28\x74" +
+	"\x27\x79\xe6\x54\x13\xc6\x09\x5e\xda\x97\xa5\xdb\x9d\x49\x9d" +
+	"\x86\xca\x98\x68\x18\x45\xf9\x67\xe7\xc6\xac\x96\xa3\x29\xb0" +
+	"\x80\x73\xf7\x00\xf7\xbf\x06\x08\xd6\x47\xa1\xee\x0e\x10\x39" +
+	"\xe1\xcf\x9e\x7e\xa2\x20\xc3\x71\x28\xbe\xc4\xc5\xf9\x79\x7c" +
+	"\x3a\xff\xe2\xe0\x39\x4d\xba\x63\x0a\x8b\x5d\x1b\x26\xff\x3d" +
+	"\x3d\xc7\x8f\x6c\x8a\xe9\x33\x86\xc9\xd0\xd4\xe9\xb6\x22\xee" +
+	"\xb4\xb9\x0f\x93\xe3\x16\x07\xf3\x8d\x79\xf4\xb0\xcf\xb1\x30" +
+	"\xba\xf0\x63\x7c\x0a\xba\xad\x75\xf1\x04\xd2\x1b\x6f\x0c\x89" +
+	"\xf2\x45\xc8\xde\xa5\x33\x92\xe9\x09\x26\xcb\x86\xc2\x04\x8a" +
+	"\x3a\x7c\x27\x98\x22\x9b\x3a\x8b\x63\xef\x57\x5d\x88\x9a\xde" +
+	"\xcb\x86\xae\xd9\x48\x75\x17\x8d\xb1\x87\xa5\x66\x4e\xa6\x83" +
+	"\xff\x18\x4e\x34\x4c\xc6\x0c\x81\x03\x80\x9f\x54\x7e\x62\x1f" +
+	"\xcd\xa9\x25\x8e\x13\xd3\xab\x27\x60\x4b\x8f\x97\xb2\x05\x6e" +
+	"\x7a\xf4\x43\x7e\xfe\x63\x78\x72\xf6\x87\xcd\xea\x10\x47\xf1" +
+	"\xe6\x5f\x00\x00\x00\xff\xff\x01\x00\x00\xff\xff\x88\x76\xb0" +
+	"\x21")
+
+var _file_12 = &file{
+	fileInfo: &fileInfo{
+		name:  "stats.js",
+		isDir: false,
+		size:  2292,
+		mode:  os.FileMode(0),
+		mTime: time.Unix(-62135596800, 0),
+		cType: "text/javascript; charset=utf-8",
+	},
+	path:  "/js/stats.js",
+	dirP:  "/js",
+	sPath: "/js/stats.js",
+	id:    12,
+	cb:    _compress_bytes_12,
+}
+
+var _compress_bytes_13 = []byte("" +
+	"\x78\x9c\xb4\x56\x6f\x6f\xdb\x36\x13\x7f\x9f\x4f\x71\x11\x50" +
+	"\x48\x42\x55\x39\xcd\xd3\x07\x1b\x66\x18\xc5\xda\xa6\x5d\x86" +
+	"\x2e\x29\x96\x0c\xd8\xbb\x82\x16\xcf\x36\x53\x89\xe4\xc8\x93" +
+	"\x1d\x63\xf5\x77\x1f\x8e\x96\x6c\x59\xb1\x9d\xb4\xd8\x40\xbd" +
+	"\x10\xc9\xbb\xdf\xfd\xbf\xe3\x60\x00\x34\x43\xb0\xce\x14\xe8" +
+	"\x3d\x7a\x30\x13\x10\x50\x18\x4d\x42\x69\x74\x19\x38\x9c\x38" +
+	"\xf4\x33\x94\x60\xd1\x29\x23\x55\x21\xca\x72\x79\x72\x92\x4c" +
+	"\x6a\x5d\x90\x32\x1a\x92\x14\xfe\x3e\x01\x00\x98\x0b\x07\x73" +
+	"\x85\x0b\x18\x81\x34\x45\x5d\xa1\xa6\x7c\x8a\x74\x51\x22\xff" +
+	"\xbe\x59\x5e\xca\x24\x26\x63\xe3\x74\x18\xc8\xd5\x04\x92\x53" +
+	"\xa6\x6f\xf9\x79\x39\xa4\xda\xe9\x35\xc1\x6a\x83\x3a\x16\x1e" +
+	"\x61\x14\xc0\x19\xf1\x67\x22\xa7\xc6\x35\x61\x12\x4b\x41\xe2" +
+	"\x05\x5f\xb7\xa8\xac\x84\x92\x47\x88\x95\xec\x92\x7a\x35\xd5" +
+	"\xa2\x3c\x42\xbe\x26\x88\x53\x18\x8d\x20\x22\x57\x63\xb4\x65" +
+	"\x46\xe7\x8c\x7b\xc4\xda\x17\x81\x88\x45\x06\xb6\x8d\xd7\x0a" +
+	"\x2c\xcb\xc4\x99\x45\x06\x85\x29\xeb\x4a\x67\x40\x78\x4f\x5d" +
+	"\x57\xb0\x04\x92\x5d\xf8\xc2\xa1\x20\x6c\x24\x24\x31\x6d\x2c" +
+	"\xe1\x8f\x64\x5e\x94\xc2\xfb\x2b\x51\xb1\xaf\xd6\xa8\x3b\xd7" +
+	"\x2c\xe0\xad\xd1\x84\x9a\x60\x14\xc4\xed\x5e\x2b\x2a\xf1\xc1" +
+	"\x85\x33\x8b\x5c\x58\x8b\x5a\xbe\x9d\xa9\x52\x26\x24\x3b\x32" +
+	"\xd7\xd1\x02\x92\x6d\xc0\x76\x6d\x74\xa8\x25\xba\x64\x93\x5c" +
+	"\x7d\xeb\x9c\x59\xf8\x87\xf6\xbd\x6b\xb6\xef\x9d\x98\xb2\xd9" +
+	"\x49\x47\xe0\x06\x2a\x9f\x18\x77\x21\x8a\x59\x27\x0d\x6d\x06" +
+	"\xaa\x2b\xa1\x23\xe5\x98\x13\x5d\xd7\x89\x6d\x5e\x2a\x78\x06" +
+	"\xe7\x1c\xf2\x97\x7d\xc4\xd6\x27\x5d\x5f\x47\x04\x05\xcc\xd1" +
+	"\x9d\x37\xb9\xd1\xae\xd5\xce\x8e\xb9\xfc\xc3\x04\xb3\x4a\xc6" +
+	"\x19\xd8\xdc\xaa\xae\x67\x79\x6d\x53\x24\x5e\x47\xf3\xe5\x53" +
+	"\x09\x5f\x05\xc2\xda\xa3\x83\xaf\x5f\x21\x7a\x11\x3d\xc6\xf0" +
+	"\xff\xc0\x50\xd8\xfa\xb3\x45\x57\x70\x82\xbc\xde\xdd\xe7\x64" +
+	"\xde\xab\x7b\x94\xc9\xcb\x14\x9e\x43\xf4\x2c\x82\x9f\x9e\x82" +
+	"\xfb\x43\xc0\xad\xb0\x32\x6e\x09\xaf\x61\x56\x57\x42\xbf\x59" +
+	"\x12\xfa\xa4\x3d\x4d\x9f\x06\x74\x1e\x80\x0a\x53\x55\x42\xf7" +
+	"\xcd\xe7\x80\xad\xcb\x74\x5f\xb0\x38\x05\xc6\xa4\x8f\xa4\xc0" +
+	"\xb8\x26\x32\xba\x9f\x06\xbc\xc6\xa4\x7b\x55\x13\xdd\x04\x41" +
+	"\xbd\x40\xb7\x5a\xd8\x5c\x9b\xcf\x87\x55\xe1\x35\x18\x00\xe6" +
+	"\xd3\x3c\x74\x5d\x69\x8a\x2f\xe8\xe0\xd3\xe5\xbb\xd0\x77\xf9" +
+	"\x68\x66\x3c\x65\x80\xf7\x05\x5a\x0a\x34\x95\x50\x1a\x8c\xc6" +
+	"\xbd\x60\xac\x9f\x54\x5e\x8c\x4b\xe4\x46\xc1\xed\x69\x78\x90" +
+	"\xb0\xad\xef\xc8\xe8\x72\xb9\xc5\x6e\x4a\x0a\x0a\xa1\x61\x8c" +
+	"\x4d\x43\x44\xb9\xc7\xc2\xdd\x74\xde\x1b\xa6\x1f\xe3\x0c\xa2" +
+	"\x28\xdd\xe9\x18\x63\xd2\xe9\x23\x75\xe1\x77\x18\x9c\x59\x74" +
+	"\x18\x56\x9d\xff\x30\x00\x7a\x01\xe9\x28\x1a\xae\x7b\x48\x3e" +
+	"\x3d\xd4\x9b\xc2\x5c\xdb\x0c\xaf\x36\x55\xee\xab\x72\x46\x64" +
+	"\x61\x04\x1a\x17\xf0\xe7\x6f\x1f\x7f\x21\xb2\xbf\xe3\x5f\x35" +
+	"\xfa\x9d\x4e\xd4\xd0\xe5\xc6\xa2\x4e\xa2\x0f\x17\xb7\x51\x06" +
+	"\x3c\x87\xb8\x3a\x06\x64\xec\x20\x82\xe7\xa0\x64\xd8\xde\x79" +
+	"\xa3\xa3\x7d\xbc\xda\xa1\x90\x4b\x4f\x82\xb0\x98\x09\x3d\xe5" +
+	"\xf0\x6c\x1b\x5a\x3f\x85\x38\xc3\x5a\xd6\xc0\x78\xc3\x8c\x70" +
+	"\x3a\x82\x57\x7d\xd2\xfe\x28\xdd\xef\x79\xb6\xf7\x0e\x46\xf0" +
+	"\xeb\xcd\xf5\x55\x6e\x85\xf3\xd8\xc1\xf7\xd6\x68\x8f\xb7\x3c" +
+	"\x94\x86\x07\xd5\x60\xd5\x6b\xcf\x2a\x9c\x9f\x9d\xed\x53\x22" +
+	"\x4c\xbf\x5e\x0d\xdd\xe5\xe8\xdc\xf0\x3b\xf4\xdd\x07\x16\xf5" +
+	"\x12\xb5\x99\x39\x77\x1d\xa5\x57\xdb\xdf\x8d\xde\xa8\x65\x1b" +
+	"\xcd\xb5\x8c\x4d\x3e\xac\x0f\x3d\xd2\xa5\x26\x74\x73\x51\x26" +
+	"\xcd\x55\x06\xff\x3b\x3b\x3b\x6b\x07\xf9\x3a\xd7\xa4\xbc\x98" +
+	"\xa3\xa6\x8f\xca\x13\x6a\x74\x49\x5c\x94\xaa\xf8\x12\x67\x9d" +
+	"\x28\x22\x13\x74\x5d\xb3\x6d\x48\xe1\x2a\x27\xe1\xa6\xd8\x99" +
+	"\xb8\xec\xdf\x50\xaf\x62\x1a\x66\xf9\xe9\x08\xe2\x37\x7f\xdc" +
+	"\xde\x5e\x5f\xc5\x7d\x0f\xf7\x5d\xb6\x75\x17\x0b\xb1\xe1\x1d" +
+	"\xc4\x50\x56\x38\xd4\xed\xd3\xa4\xb7\xdb\xf3\xe6\xb1\xdb\x37" +
+	"\x52\x8b\xe5\xd5\xf4\xd8\x43\xa7\x7d\x25\xe5\x73\x51\x76\x7b" +
+	"\x10\xdb\x72\x5a\x18\x3d\x51\xae\x4a\x22\x8f\x5a\xc2\xcd\xe5" +
+	"\x07\x2e\x0d\x06\x7c\x0e\x11\x90\xd9\x74\x20\x3e\xe6\x29\xf8" +
+	"\x6d\x56\x4e\x8c\xab\x9a\x6a\x7d\x6f\x5c\xf5\x4e\x90\x68\x83" +
+	"\xc8\x8b\xaf\x9b\xee\x92\x44\x56\xc9\x28\x83\xdd\x01\xba\x43" +
+	"\xb0\xb6\x23\xca\xb8\x0d\xa6\xc3\x7f\xa1\x31\x7c\xba\xbe\x39" +
+	"\xd6\x19\x1a\x79\xff\x59\x6f\x18\x1d\xe8\x0d\xdf\x57\xf9\xbc" +
+	"\x0a\xa3\xbd\x29\x31\x97\x38\xae\xa7\xc9\xdd\x1e\x8a\x6f\xea" +
+	"\x0f\xfc\x89\x12\x1d\x25\xa1\x2b\xec\x81\x5b\x9d\xf4\x0e\xfa" +
+	"\xa5\xfa\x90\xf0\x50\xc1\x73\xa8\x1b\xa6\x55\x3a\x3c\x59\xa5" +
+	"\x49\x3a\xfc\x07\x00\x00\xff\xff\x01\x00\x00\xff\xff\x45\xc4" +
+	"\x96\x98")
+
+var _file_13 = &file{
+	fileInfo: &fileInfo{
+		name:  "top.js",
+		isDir: false,
+		size:  3329,
+		mode:  os.FileMode(0),
+		mTime: time.Unix(-62135596800, 0),
+		cType: "text/javascript; charset=utf-8",
+	},
+	path:  "/js/top.js",
+	dirP:  "/js",
+	sPath: "/js/top.js",
+	id:    13,
+	cb:    _compress_bytes_13,
+}
+
+var _compress_bytes_14 = []byte("" +
+	"\x78\x9c\x9c\x57\xdb\x6e\xe3\x36\x10\x7d\xf7\x57\x4c\x89\xb4" +
+	"\xd8\x05\xd6\x62\x9c\xec\x0d\x05\xad\x62\x9b\xdd\x87\x00\x8b" +
+	"\x62\xd1\x7c\x40\x41\x53\xb4\xc5\x84\x16\x5d\x72\xec\x6c\x20" +
+	"\xe8\xdf\x8b\xa1\x2e\x91\x2c\x3b\x76\x6a\x3f\x98\x1a\x1e\x1e" +
+	"\xce\x1c\xce\x0c\xe5\xb2\x9c\xc2\x85\x42\x0b\xbf\xcf\x21\x51" +
+	"\xae\x40\xef\x2c\x4c\xab\x0a\xe2\x44\xc8\xdd\xe3\x77\xa7\x24" +
+	"\x1a\x57\x44\x84\x75\xaa\x3f\x2b\xbd\x8e\xe6\x7a\xd4\x4d\x2c" +
+	"\x64\xa8\xed\x71\xf0\x8c\x47\x89\x21\xda\xeb\xd1\xb4\xaa\x26" +
+	"\xe2\x97\xcc\x29\x7c\xda\x68\xc8\x71\x6d\xd3\x89\xa8\x7f\x26" +
+	"\x22\xd7\x32\x4b\x27\x00\x02\x0d\x5a\x9d\x96\x25\x24\x71\x04" +
+	"\x55\x25\x78\x1c\xc5\x59\x6b\x8a\x07\xf0\xda\xce\x99\x51\xae" +
+	"\x60\x40\x54\x73\x66\xd6\x72\xa5\xf9\xa6\x58\x31\xc8\xbd\x5e" +
+	"\xce\x59\x59\x46\x5f\xaa\x8a\x2f\xe5\x8e\x90\x09\x4d\xee\x31" +
+	"\x04\x7c\xb2\x3a\xe4\x5a\xe3\xf3\xb2\x8b\x66\x99\x0a\x81\x5b" +
+	"\x13\x30\x51\x21\x30\xe0\xe9\x44\xf0\xda\xc3\x89\x58\xb8\xec" +
+	"\x89\x98\x28\xc6\x47\x83\x39\x24\xb6\x91\x2c\x40\x55\xd1\x16" +
+	"\x99\xd9\x81\xb2\x32\x84\x39\xeb\xa6\x18\x2d\xa9\x17\x79\x59" +
+	"\xac\x34\x24\x35\x18\x40\x84\x8d\x2c\x5a\x7c\x59\x82\x59\x42" +
+	"\x72\xe3\x8a\x42\x2b\xd4\x19\x54\x95\x6a\xc7\x65\x09\xda\x06" +
+	"\x0d\x55\x95\x99\x30\xb0\x16\x84\x63\x10\x65\x3a\x97\x83\x14" +
+	"\xfe\xe6\xbd\xf3\xf5\xb8\xe1\x48\x9b\xc5\x7f\x4a\xf5\x50\x9b" +
+	"\xca\xb2\xff\x04\x1d\x94\xec\x7f\xc9\x35\x51\x09\x4e\x21\x3c" +
+	"\x07\x58\x43\x49\x09\x9e\x99\x5d\x3a\xd9\xb7\xf6\xf4\x41\xb9" +
+	"\xb0\x1a\x76\xda\x5f\xc3\x7a\xba\x98\xce\x66\x97\x8d\x50\x23" +
+	"\xd0\x94\xf4\x6f\x26\x29\x4d\x68\x61\xfb\x44\xcf\x6d\x02\xb5" +
+	"\x1f\x81\xbe\xff\x48\x86\xbc\x25\x54\xce\x6e\xd7\xc5\x8c\xa5" +
+	"\x37\xae\x40\x69\x0a\xed\xe1\xf6\xab\xe0\x98\x9f\x58\x71\xc5" +
+	"\xd2\x5b\x4a\xb5\x33\xa0\xd7\x44\xbe\x5e\xcb\x22\x3b\x03\xfc" +
+	"\x9e\xa5\x24\xe5\x19\xc8\x0f\x2c\xbd\xfd\x31\xc6\x91\xbe\x66" +
+	"\xb9\x57\xc0\x55\x35\xc0\x8c\xb8\x3e\xb2\xb4\xc5\x1e\x66\xec" +
+	"\x4e\xec\x05\x92\x4f\x2c\xbd\x43\x89\xdb\x70\xdc\x29\x85\x36" +
+	"\xf9\x56\xd0\x79\x9d\x64\xfb\xcc\xd2\x2f\x8a\x1c\x3a\x42\x47" +
+	"\x1e\x4d\x07\x24\x82\xf7\xcf\x59\xf0\x41\x1e\x08\xde\x4b\x93" +
+	"\x26\x19\x8f\x64\x17\x55\xf5\x0b\xd9\xd5\x16\x7d\xfb\x29\xcb" +
+	"\xa6\x8c\x2f\xcc\x3b\xb8\xd0\x5d\x3b\x8d\xc9\xd4\xf4\x81\xf6" +
+	"\x2b\xd0\x43\x26\x51\x4e\x4d\x16\x8b\x33\xb9\xfd\x4a\xa5\x56" +
+	"\xdb\xa8\x35\xea\xda\x4c\x3a\x52\x39\xb1\x56\xb9\x37\xfa\x5f" +
+	"\x78\xb3\x76\x19\x5c\x18\xb8\x7a\x0b\xb3\xb7\x50\x55\x9d\xd7" +
+	"\xa0\xa8\x6c\xae\xd8\xf3\x41\xf5\xfd\xa3\x5d\xb3\xfd\x74\x6f" +
+	"\x1b\x84\xfe\xa9\x15\x98\x02\x1d\x74\x2e\x77\xa1\xb7\x5f\x21" +
+	"\x47\x3d\x51\xf3\xb2\x84\x8d\x37\x05\x2e\x81\xfd\x9a\xcc\xae" +
+	"\x02\xeb\x62\xd9\x49\xbb\xd5\x83\xe8\x50\xfa\x95\xc6\x39\xfb" +
+	"\x67\x61\x65\xf1\xc0\xd2\x63\x6b\x05\x97\x7b\x8e\x73\xcc\x4e" +
+	"\x84\x72\xd5\xef\x75\x49\x2c\xc9\xb6\x79\x35\x7b\x74\xc6\x33" +
+	"\xd8\xae\x3b\x36\x89\x28\x55\x0e\xe8\xa8\xcd\x25\x4d\xfd\x46" +
+	"\xe6\xd3\xea\xd4\x6b\x5f\x90\xe8\xb8\x20\xbd\x9d\xfe\x8f\x1a" +
+	"\xef\x07\x6a\x34\x3d\xf9\x1c\x97\xad\x5b\x85\xa3\x0e\xff\xb1" +
+	"\x74\xd6\xba\xc7\xf9\xec\x37\x94\xc6\xce\x67\x97\xa3\x08\xda" +
+	"\x5d\x57\x1a\x81\xa8\x06\x21\x75\x57\xc3\xeb\xe3\xf9\x30\x88" +
+	"\xe7\xf6\x47\xe8\x2e\xa6\x22\xd3\x3f\x6b\xcb\xe5\xc1\xa3\x3d" +
+	"\xd8\x06\x87\xfd\xe2\xc0\x7e\x1f\x07\xfb\x0d\xef\xba\xe4\xc6" +
+	"\x6e\x03\x6a\xdf\x3e\x7e\x77\xea\x4e\xfb\x9d\xf6\xaf\xbe\x2c" +
+	"\xcd\xb2\x4f\x36\xa6\xee\x80\xad\x82\xfd\xad\x0e\x87\x3a\xee" +
+	"\x86\x07\xa2\xfb\x34\x88\xae\xed\x31\x8d\x43\x77\x28\x63\x57" +
+	"\x7e\x07\x98\x6b\x58\x34\x9e\x9b\x00\x87\xdf\x30\xce\xc8\x29" +
+	"\xea\x67\xc7\x93\x8a\x1f\x2c\x83\xe8\xd5\x36\x8c\xdd\x82\x37" +
+	"\x81\x46\x6f\x3b\x0f\x46\xe9\xd4\x3b\x73\xda\x98\x20\x0b\x9f" +
+	"\x0e\x5e\xa9\xb6\x41\xae\x34\x4b\x9b\x97\x94\x8e\x6a\x40\x73" +
+	"\x48\xdf\xfd\xbb\xeb\xa4\xd2\x9f\x47\xfa\xd4\x24\xce\xd7\x77" +
+	"\xe0\x1d\x4a\x8f\xf5\xf0\x8b\xb5\xfb\x2e\x00\x88\xc5\x16\xd1" +
+	"\x15\xed\x71\x05\x82\xc7\xdb\xd5\xa3\xe0\xf5\xdc\xb3\xfb\x23" +
+	"\x6e\xb7\x79\x0d\xb5\xdb\x10\xb3\xdb\x9c\x24\xfe\x5b\x87\x81" +
+	"\xdb\xa7\xa8\xbd\x6e\xfc\x6e\x16\x8e\x37\x38\x2d\xfc\xa9\x5b" +
+	"\x1e\x60\x4c\x26\xf8\xe0\x8e\x3e\x74\xf3\xf7\x5f\x01\x44\x50" +
+	"\xde\x6c\x10\x82\x57\xfd\xec\xbd\x0f\xbc\xf9\x53\x94\xdc\x87" +
+	"\x98\x33\x11\x96\xbe\xb8\x22\xa6\xfc\x2b\xf0\xf1\x7f\xc5\x7d" +
+	"\x68\x5e\x00\x88\xa7\x07\xe8\x93\x08\x5e\x87\x34\x11\x3c\xc7" +
+	"\xb5\x4d\xff\x03\x00\x00\xff\xff\x01\x00\x00\xff\xff\xec\x0f" +
+	"\x15\x56")
+
+var _file_14 = &file{
 	fileInfo: &fileInfo{
 		name:  "list.html",
 		isDir: false,
-		size:  2537,
+		size:  3517,
 		mode:  os.FileMode(0),
 		mTime: time.Unix(-62135596800, 0),
 		cType: "text/html; charset=utf-8",
@@ -11218,15 +11539,120 @@ var _file_11 = &file{
 	path:  "/list.html",
 	dirP:  "/",
 	sPath: "/list.html",
-	id:    11,
-	cb:    _compress_bytes_11,
+	id:    14,
+	cb:    _compress_bytes_14,
+}
+
+var _compress_bytes_15 = []byte("" +
+	"\x78\x9c\x8c\x54\xcd\x6e\xdb\x3c\x10\xbc\xeb\x29\xf6\x23\xf0" +
+	"\x1d\x25\xd6\x76\x80\x5c\x68\x1e\xda\x53\x0f\xfd\xb9\xe4\x01" +
+	"\x68\x72\x1d\x31\xa6\x44\x81\xbb\x71\x61\x08\x7a\xf7\x82\xb2" +
+	"\x85\x2a\x72\xda\x88\x17\x73\x77\x87\x63\xee\xcc\x52\xea\x3f" +
+	"\x17\x2d\x5f\x3a\x84\x9a\x9b\xa0\x0b\x75\xfd\x29\x54\x8d\xc6" +
+	"\xe9\x02\x40\xb1\xe7\x80\xba\xef\xa1\x1a\x77\x30\x0c\x4a\x8e" +
+	"\xbb\xb1\x1a\x7c\x7b\x82\x84\x61\x2f\xbc\x8d\xad\x80\x4c\xb5" +
+	"\x17\xbe\x31\xcf\x28\xbb\xf6\x59\x40\x9d\xf0\xb8\x17\x7d\x5f" +
+	"\x1d\x0c\xe1\x30\xc8\xa3\x39\x67\x64\x95\x8b\x0b\x06\xe2\x4b" +
+	"\x40\xaa\x11\xf9\xfe\x98\x25\x92\xc1\x13\x57\x96\x48\x80\xd4" +
+	"\x85\x92\xd7\x1b\x16\xea\x10\xdd\x65\x64\x72\xfe\x0c\x36\x18" +
+	"\xa2\xbd\x08\xd1\x1a\xf6\xb1\x25\x91\x2b\x00\x8a\x3a\xd3\x2e" +
+	"\x9a\x18\x53\xb3\xaa\x32\x77\xff\xca\xb1\x93\x7d\x0f\x5d\xf2" +
+	"\x2d\x1f\x41\xfc\x5f\x6d\xb6\x24\xa0\xf2\x0e\x86\x41\x0a\xdd" +
+	"\xa5\x68\x91\x08\x49\x49\xa3\xef\x08\xa7\xbb\x38\x4f\x36\xb6" +
+	"\x2d\x5a\x46\x27\xc0\xbb\xbd\x20\x36\x4c\x25\xa6\x14\x93\x98" +
+	"\x9d\x53\xd2\xf9\xf3\xb2\x13\x36\x87\x80\x70\xc6\xb4\x83\xa6" +
+	"\x3c\x94\x9b\xcd\xa7\xa9\xa5\x25\xa8\xcc\x82\xdc\x8a\xd9\xb7" +
+	"\x9c\x9b\xa2\x1c\x4f\x8e\x4e\x4b\x71\x9a\x87\x39\x51\x4f\x84" +
+	"\x36\x86\xd7\xa6\xdd\x08\xfd\xe5\xe7\x93\x92\x5c\x7f\x00\xdc" +
+	"\x0a\xfd\x0d\x9b\x98\x2e\x2b\xb0\x3b\xa1\xbf\x23\xc3\x57\xf9" +
+	"\x63\x05\xf8\x41\xe8\xcf\x21\xda\xd3\x4a\xf8\xa3\xd0\x4f\x9d" +
+	"\x33\x8c\x6e\x09\x56\x72\xde\xad\x92\x6f\xd4\x50\x72\x26\xd6" +
+	"\xcd\x85\xbf\x68\x9c\x87\xed\x1f\x1a\x4f\xb3\x38\x2d\xc5\xe9" +
+	"\x8f\xe3\x02\x9c\x61\x53\xe6\x38\x4f\xe2\x38\x45\xb7\x5c\x9e" +
+	"\xb7\x6b\x36\xef\x72\x7e\xce\x92\x79\xdc\xdb\x4e\x37\x60\xbb" +
+	"\x57\xa1\x4b\x25\xd9\x7d\x00\xdd\x42\x33\x7a\xb3\x0e\xbd\x83" +
+	"\x16\xf9\x57\x4c\xa7\x75\xf0\x07\x38\x64\x7f\xd6\x81\x1f\x81" +
+	"\x7d\x83\xef\x60\xef\xdc\x99\xeb\xf8\x9e\x3b\x73\x9b\x14\xd9" +
+	"\xe4\x3b\x06\x4a\x76\xfe\x76\x5f\x48\x8e\xef\xac\x7a\xa1\xf1" +
+	"\x91\x8d\xa0\xfc\xdd\xb8\x72\x17\x4a\xd6\xdc\x04\xfd\x1b\x00" +
+	"\x00\xff\xff\x01\x00\x00\xff\xff\x91\xae\x6f\x57")
+
+var _file_15 = &file{
+	fileInfo: &fileInfo{
+		name:  "stats.html",
+		isDir: false,
+		size:  1281,
+		mode:  os.FileMode(0),
+		mTime: time.Unix(-62135596800, 0),
+		cType: "text/html; charset=utf-8",
+	},
+	path:  "/stats.html",
+	dirP:  "/",
+	sPath: "/stats.html",
+	id:    15,
+	cb:    _compress_bytes_15,
+}
+
+var _compress_bytes_16 = []byte("" +
+	"\x78\x9c\x8c\x54\xcf\x6e\xdb\x3e\x0c\xbe\xfb\x29\xf8\x13\xf0" +
+	"\x3b\xda\x5a\xba\x0d\xdb\x81\x11\x30\x74\x97\x1d\x06\xf4\xd2" +
+	"\x07\x50\x24\x25\x56\x27\x4b\x86\xa8\x06\x08\x0c\xbf\xfb\x20" +
+	"\x39\x6e\x5d\xa7\xe9\xf2\x07\x88\x4c\x7e\xfa\x48\x7e\x24\x8d" +
+	"\xff\xe9\xa0\xd2\xa9\x37\xd0\xa6\xce\x89\x0a\xa7\xbf\x0a\x5b" +
+	"\x23\xb5\xa8\x00\x30\xd9\xe4\x8c\x18\x06\x68\xca\x09\xc6\x11" +
+	"\x79\x39\x15\xaf\xb3\xfe\x0f\x44\xe3\xb6\xcc\xaa\xe0\x19\x64" +
+	"\xaa\x2d\xb3\x9d\x3c\x18\xde\xfb\x03\x83\x36\x9a\xfd\x96\x0d" +
+	"\x43\xb3\x93\x64\xc6\x91\xef\xe5\x31\x23\x9b\xec\x5c\x31\x50" +
+	"\x3a\x39\x43\xad\x31\xe9\xf2\x9a\x22\xe2\xce\x52\x6a\x14\x11" +
+	"\x03\x2e\x2a\xe4\x53\x86\x15\xee\x82\x3e\x15\x26\x6d\x8f\xa0" +
+	"\x9c\x24\xda\x32\x17\x94\x4c\x36\x78\x62\xd9\x03\x80\xd4\x4b" +
+	"\xbf\x2a\xa2\x98\x16\x5e\x94\x17\x51\x29\xc9\x44\x7c\x18\xa0" +
+	"\x8f\xd6\xa7\x3d\xb0\xff\x9b\xcd\x1d\x31\x68\xac\x86\x71\xe4" +
+	"\x4c\x14\x00\x72\x29\x96\x74\xc3\x50\x83\xdd\x43\x43\xf6\xe0" +
+	"\xa5\x83\x71\x5c\x04\x99\x6c\xc5\x90\xb3\x32\xce\xa8\x04\x56" +
+	"\x6f\xd9\xe4\x38\xa7\x3b\xb3\x44\xe9\x0f\x66\x26\xa2\x99\x29" +
+	"\x7f\x31\xf4\xb9\x3e\x38\x4a\xf7\x6c\xb2\x50\xd0\xc0\x38\x32" +
+	"\x71\x3e\x20\x9f\xfc\x6f\xe9\x8c\xd7\xaf\x1c\xc8\xa7\xe8\x67" +
+	"\x81\x56\xf9\x2f\xa0\x45\xbb\x59\x58\x6d\x49\x05\xef\x8d\x4a" +
+	"\x46\xb3\x92\x78\x0a\x7d\x6d\x62\x0c\x91\x2d\x44\x40\xae\xed" +
+	"\x71\xdd\x94\x24\x77\xce\xc0\xd1\xc4\xcf\xd0\xd5\xbb\x7a\xb3" +
+	"\xf9\x34\x77\x67\x0d\xaa\x73\x6f\x5f\xb4\xc0\x72\xf1\xb5\x14" +
+	"\x4c\xf3\x70\xce\x1f\x4c\x71\xf9\x98\x0d\xed\x4c\xa8\x82\x7b" +
+	"\xee\xfc\x86\x89\x87\x5f\x3f\x91\xa7\xf6\x1f\xc0\x2f\x4c\x3c" +
+	"\x92\x89\x37\x20\xbf\x32\x71\xff\xf0\x78\x03\xf0\x1b\x13\xbf" +
+	"\x4d\x17\xe2\xe9\x06\xec\x1d\x13\xf7\xa1\xeb\xa4\xd7\x97\xe0" +
+	"\xf7\xe7\xea\x2a\xd5\x77\x26\x7e\xa8\x3c\x04\xf4\x3e\xd5\x9b" +
+	"\x69\xc8\x3f\xe4\x4b\x19\x91\xbf\x91\x19\xf9\xa2\x0b\xe7\xf6" +
+	"\x5e\x69\x5e\x5e\xc8\x0f\x9a\x97\xdd\xf3\xe4\x30\xd0\x32\xc9" +
+	"\x3a\x3f\xe5\xc9\x2d\x6b\x75\xb6\xe5\x15\x9c\xac\xf9\xf4\x6a" +
+	"\x9f\xaa\x9f\x3c\x2f\x4a\xbc\x84\x2b\x79\xcf\x6f\x84\x6b\x79" +
+	"\x2f\x0b\x40\x52\xd1\xf6\x09\x28\xaa\xe5\xe6\x3f\x11\x2f\xbb" +
+	"\xdd\x3c\x51\x99\xeb\x02\x12\x1f\xe2\x53\xe8\x57\x68\xe4\x53" +
+	"\x26\x15\xf2\x36\x75\x4e\xfc\x05\x00\x00\xff\xff\x01\x00\x00" +
+	"\xff\xff\xac\xf1\x95\x02")
+
+var _file_16 = &file{
+	fileInfo: &fileInfo{
+		name:  "top.html",
+		isDir: false,
+		size:  1389,
+		mode:  os.FileMode(0),
+		mTime: time.Unix(-62135596800, 0),
+		cType: "text/html; charset=utf-8",
+	},
+	path:  "/top.html",
+	dirP:  "/",
+	sPath: "/top.html",
+	id:    16,
+	cb:    _compress_bytes_16,
 }
 
 func init() {
     fs = []*file{
 		_file_0, _file_1, _file_2, _file_3, _file_4,
 		_file_5, _file_6, _file_7, _file_8, _file_9,
-		_file_10, _file_11,
+		_file_10, _file_11, _file_12, _file_13, _file_14,
+		_file_15, _file_16,
 	}
 
 	root = &data{
@@ -14,7 +14,9 @@ import (
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
 	"github.com/wrfly/container-web-tty/audit"
+	"github.com/wrfly/container-web-tty/container"
 	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
 )
 
 func (server *Server) handleExecRedirect(c *gin.Context) {
@@ -28,6 +30,15 @@ func (server *Server) handleExecRedirect(c *gin.Context) {
 	}
 }
 
+// handleAttachRedirect redirects to the exec page in the attach mode,
+// so the session can be shared like an exec
+func (server *Server) handleAttachRedirect(c *gin.Context) {
+	containerID := c.Param("cid")
+	execID := server.setContainerID(containerID)
+	base := filepath.Join(server.options.Base, "/exec/") + "/"
+	c.Redirect(302, base+execID+"?attach=1")
+}
+
 func (server *Server) handleExec(c *gin.Context, counter *counter) {
 	execID := c.Param("eid")
 	containerID, ok := server.getContainerID(execID)
@@ -52,11 +63,6 @@ func (server *Server) handleExec(c *gin.Context, counter *counter) {
 
 func (server *Server) generateHandleWS(ctx context.Context, execID string, counter *counter, container types.Container) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if container.Shell == "" {
-			log.Errorf("cannot find a valid shell in container [%s]", container.ID)
-			return
-		}
-
 		num := counter.add(1)
 		closeReason := "unknown reason"
 
@@ -111,25 +117,35 @@ func (server *Server) processTTY(ctx context.Context, execID string, timeoutCanc
 	if err != nil {
 		return err
 	}
-	log.Debugf("exec container: %s, params: [%s]", container.ID[:7], arguments)
+	log.Debugf("exec container: %s, params: [%s]", util.ShortID(container.ID, 7), arguments)
 
 	q, err := parseQuery(strings.TrimSpace(arguments))
 	if err != nil {
 		return err
 	}
+	if q.Get("cmd") != "" && len(q["arg"]) != 0 {
+		return fmt.Errorf("cmd and arg cannot be used together")
+	}
 	container.Exec = types.ExecOptions{
 		Cmd:        q.Get("cmd"),
+		Args:       q["arg"],
 		Env:        q.Get("env"),
 		User:       q.Get("user"),
 		Privileged: q.Get("p") != "",
+		Debug:      q.Get("debug") != "",
 	}
 
-	containerTTY, err := server.containerCli.Exec(ctx, container)
+	var containerTTY types.TTY
+	if q.Get("attach") != "" {
+		containerTTY, err = server.attach(ctx, container)
+	} else {
+		containerTTY, err = server.exec(ctx, container)
+	}
 	if err != nil {
-		return fmt.Errorf("exec container error: %s", err)
+		return err
 	}
 	defer func() {
-		log.Infof("container %s exit", container.ID[:7])
+		log.Infof("container %s exit", util.ShortID(container.ID, 7))
 		if err := containerTTY.Exit(); err != nil {
 			log.Warnf("exit container err: %s", err)
 		}
@@ -159,7 +175,7 @@ func (server *Server) processTTY(ctx context.Context, execID string, timeoutCanc
 		}()
 	}
 
-	titleBuf, err := server.makeTitleBuff(container)
+	titleBuf, err := server.makeTitleBuff(container, containerTTY.WindowTitleVariables())
 	if err != nil {
 		return fmt.Errorf("failed to fill window title template: %s", err)
 	}
@@ -196,7 +212,7 @@ func (server *Server) processTTY(ctx context.Context, execID string, timeoutCanc
 		})
 	}
 
-	log.Infof("new web tty for container: %s", container.ID[:7])
+	log.Infof("new web tty for container: %s", util.ShortID(container.ID, 7))
 	wrapper := &wsWrapper{conn}
 	tty, err := webtty.New(wrapper, masterTTY, opts...)
 	if err != nil {
@@ -205,3 +221,28 @@ func (server *Server) processTTY(ctx context.Context, execID string, timeoutCanc
 
 	return tty.Run(ctx)
 }
+
+func (server *Server) exec(ctx context.Context, c types.Container) (types.TTY, error) {
+	// the debug mode brings its own shell, the argv needs none
+	if c.Shell == "" && !c.Exec.Debug && len(c.Exec.Args) == 0 {
+		return nil, fmt.Errorf("cannot find a valid shell in container [%s], "+
+			"try the debug mode with '?debug=1'", c.ID)
+	}
+	tty, err := server.containerCli.Exec(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("exec container error: %s", err)
+	}
+	return tty, nil
+}
+
+func (server *Server) attach(ctx context.Context, c types.Container) (types.TTY, error) {
+	attacher, ok := server.containerCli.(container.Attacher)
+	if !ok {
+		return nil, fmt.Errorf("attach is not supported by the backend")
+	}
+	tty, err := attacher.Attach(ctx, c)
+	if err != nil {
+		return nil, fmt.Errorf("attach container error: %s", err)
+	}
+	return tty, nil
+}
@@ -4,14 +4,19 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/wrfly/container-web-tty/container"
 	"github.com/wrfly/container-web-tty/types"
+	"github.com/wrfly/container-web-tty/util"
 )
 
 func (server *Server) handleAuthToken(c *gin.Context) {
@@ -48,6 +53,50 @@ func (server *Server) titleVariables(order []string, varUnits map[string]map[str
 	return titleVars
 }
 
+// handleHealth reports 503 if the backend is not ready
+func (server *Server) handleHealth(c *gin.Context) {
+	if checker, ok := server.containerCli.(container.HealthChecker); ok {
+		if err := checker.Health(); err != nil {
+			c.String(http.StatusServiceUnavailable, err.Error())
+			return
+		}
+	}
+	c.String(http.StatusOK, "ok")
+}
+
+// handleEvents streams the changes of the containers by the Server-Sent
+// Events, the event name is the action and the data is the container
+func (server *Server) handleEvents(c *gin.Context) {
+	watcher, ok := server.containerCli.(container.Watcher)
+	if !ok {
+		// stops the EventSource from reconnecting
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	events := watcher.Watch(c.Request.Context())
+	keepalive := time.NewTicker(time.Second * 30)
+	defer keepalive.Stop()
+
+	// open the stream before the first event
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Action, event.Container)
+		case <-keepalive.C:
+			c.SSEvent("ping", "")
+		}
+		return true
+	})
+}
+
 func (server *Server) handleListContainers(c *gin.Context) {
 	listVars := map[string]interface{}{
 		"title":      "List Containers",
@@ -55,6 +104,10 @@ func (server *Server) handleListContainers(c *gin.Context) {
 		"control":    server.options.Control,
 		"loc":        server.options.ShowLocation,
 		"base":       strings.TrimSuffix(server.options.Base, "/"),
+		"stats":      c.Query("stats") == "1",
+	}
+	if locator, ok := server.containerCli.(container.Locator); ok {
+		listVars["locations"] = locator.Locations()
 	}
 
 	listBuf := new(bytes.Buffer)
@@ -88,7 +141,7 @@ func (server *Server) handleContainerActions(c *gin.Context, action string) {
 	}
 	c.JSON(0, types.ContainerActionMessage{
 		Code:    0,
-		Message: fmt.Sprintf("%s container %s successfully", action, cid[:7]),
+		Message: fmt.Sprintf("%s container %s successfully", action, util.ShortID(cid, 7)),
 	})
 }
 
@@ -104,10 +157,17 @@ func (server *Server) handleRestartContainer(c *gin.Context) {
 	server.handleContainerActions(c, "restart")
 }
 
-func (server *Server) makeTitleBuff(c types.Container, extra ...string) ([]byte, error) {
+// makeTitleBuff fills the window title, slaveVars are the
+// variables of the TTY, e.g. the exec options took effect
+func (server *Server) makeTitleBuff(c types.Container, slaveVars map[string]interface{}, extra ...string) ([]byte, error) {
 	location := "localhost"
-	if c.LocServer != "" {
+	switch {
+	case c.LocServer != "" && c.Cluster != "":
+		location = c.LocServer + "/" + c.Cluster
+	case c.LocServer != "":
 		location = c.LocServer
+	case c.Cluster != "":
+		location = c.Cluster
 	}
 
 	cName := c.Name
@@ -116,8 +176,9 @@ func (server *Server) makeTitleBuff(c types.Container, extra ...string) ([]byte,
 	}
 
 	titleVars := server.titleVariables(
-		[]string{"server"},
+		[]string{"slave", "server"},
 		map[string]map[string]interface{}{
+			"slave": slaveVars,
 			"server": {
 				"containerLoc":  location,
 				"containerName": cName,
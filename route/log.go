@@ -2,6 +2,7 @@ package route
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/wrfly/container-web-tty/third-part/gotty/webtty"
 
@@ -42,9 +43,24 @@ func (server *Server) handleLogs(c *gin.Context) {
 		tail = v
 	}
 	opts := types.LogOptions{
-		ID:     c.Param("cid"),
-		Follow: follow,
-		Tail:   tail,
+		ID:         c.Param("cid"),
+		Follow:     follow,
+		Tail:       tail,
+		Timestamps: q.Get("timestamps") == "1",
+		Stream:     q.Get("stream"),
+	}
+	if opts.Stream != "" && opts.Stream != "stdout" && opts.Stream != "stderr" {
+		c.String(http.StatusBadRequest, "bad stream %q, neither stdout nor stderr", opts.Stream)
+		return
+	}
+	now := time.Now()
+	if opts.Since, err = util.ParseLogTime(q.Get("since"), now); err != nil {
+		c.String(http.StatusBadRequest, "bad since: %s", err)
+		return
+	}
+	if opts.Until, err = util.ParseLogTime(q.Get("until"), now); err != nil {
+		c.String(http.StatusBadRequest, "bad until: %s", err)
+		return
 	}
 
 	container := server.containerCli.GetInfo(ctx, opts.ID)
@@ -57,7 +73,7 @@ func (server *Server) handleLogs(c *gin.Context) {
 	}
 	defer logsReadCloser.Close()
 
-	titleBuf, err := server.makeTitleBuff(container)
+	titleBuf, err := server.makeTitleBuff(container, nil)
 	if err != nil {
 		c.String(http.StatusInternalServerError, "failed to fill window title template: %s", err)
 		return
@@ -45,6 +45,8 @@ type Server struct {
 var (
 	indexTemplate *template.Template
 	listTemplate  *template.Template
+	statsTemplate *template.Template
+	topTemplate   *template.Template
 	titleTemplate *noesctmpl.Template
 )
 
@@ -72,7 +74,19 @@ func init() {
 		panic(err)
 	}
 
-	titleFormat := "{{ .containerName }}@{{ .containerLoc }}"
+	statsData, err := asset.Find("/stats.html")
+	if err != nil {
+		log.Fatal(err)
+	}
+	statsTemplate = statsData.Template()
+
+	topData, err := asset.Find("/top.html")
+	if err != nil {
+		log.Fatal(err)
+	}
+	topTemplate = topData.Template()
+
+	titleFormat := "{{ .containerName }}@{{ .containerLoc }}{{ with .execOptions }} ({{ . }}){{ end }}"
 	titleTemplate, err = noesctmpl.New("title").Parse(titleFormat)
 	if err != nil {
 		log.Fatal(err)
@@ -136,6 +150,8 @@ func (server *Server) Run(ctx context.Context, options ...RunOption) error {
 	api.GET("/", server.handleListContainers)
 	api.GET("/auth_token.js", server.handleAuthToken)
 	api.GET("/config.js", server.handleConfig)
+	api.GET("/healthz", server.handleHealth)
+	api.GET("/events", server.handleEvents)
 
 	rewriteH := func(c *gin.Context) {
 		if base != "/" {
@@ -156,11 +172,21 @@ func (server *Server) Run(ctx context.Context, options ...RunOption) error {
 	api.GET("/e/:cid/", server.handleExecRedirect) // containerID
 	api.GET("/exec/:eid/", server.handleWSIndex)   // execID
 	api.GET("/exec/:eid/"+"ws", func(c *gin.Context) { server.handleExec(c, counter) })
+	api.GET("/attach/:cid/", server.handleAttachRedirect) // containerID
 
 	// logs
 	api.GET("/logs/:cid/", server.handleWSIndex)
 	api.GET("/logs/:cid/"+"ws", func(c *gin.Context) { server.handleLogs(c) })
 
+	// stats
+	api.GET("/stats/:cid/", server.handleStatsIndex)
+	api.GET("/stats/:cid/"+"json", server.handleStatsJSON)
+	api.GET("/stats/:cid/"+"ws", server.handleStats)
+
+	// processes
+	api.GET("/top/:cid/", server.handleTopIndex)
+	api.GET("/top/:cid/"+"json", server.handleTopJSON)
+
 	ctl := server.options.Control
 	if ctl.Enable {
 		// container actions: start|stop|restart
@@ -174,6 +200,10 @@ func (server *Server) Run(ctx context.Context, options ...RunOption) error {
 		if ctl.Restart || ctl.All {
 			containerG.POST("/restart/:id", server.handleRestartContainer)
 		}
+		// the signals may stop the container as well
+		if server.signalEnabled() {
+			api.POST("/top/:cid/"+"signal", server.handleSignal)
+		}
 	}
 
 	// pprof
@@ -36,7 +36,7 @@ func (server *Server) processShare(c *gin.Context, execID string, masterTTY *typ
 	if server.options.Collaborate {
 		titleExtra = "[SLAVE]"
 	}
-	titleBuf, err := server.makeTitleBuff(cInfo, titleExtra)
+	titleBuf, err := server.makeTitleBuff(cInfo, nil, titleExtra)
 	if err != nil {
 		e := fmt.Sprintf("failed to fill window title template: %s", err)
 		conn.WriteMessage(websocket.CloseMessage, []byte(e))
@@ -0,0 +1,119 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/container"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// statsTimeout is how long to wait for the first stats
+const statsTimeout = time.Second * 10
+
+// stats starts streaming the resource usage of the container
+func (server *Server) stats(ctx context.Context, cid string) (<-chan types.Stats, error) {
+	reporter, ok := server.containerCli.(container.StatsReporter)
+	if !ok {
+		return nil, fmt.Errorf("the backend doesn't report the stats")
+	}
+	c := server.containerCli.GetInfo(ctx, cid)
+	if c.ID == "" {
+		return nil, fmt.Errorf("container %s not found", cid)
+	}
+	return reporter.Stats(ctx, c)
+}
+
+// handleStatsIndex renders the page of the resource usage of the container
+func (server *Server) handleStatsIndex(c *gin.Context) {
+	container := server.containerCli.GetInfo(c.Request.Context(), c.Param("cid"))
+	if container.ID == "" {
+		c.String(http.StatusNotFound, "container %s not found", c.Param("cid"))
+		return
+	}
+
+	titleBuf, err := server.makeTitleBuff(container, nil, "stats of")
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to fill window title template: %s", err)
+		return
+	}
+
+	statsBuf := new(bytes.Buffer)
+	err = statsTemplate.Execute(statsBuf, map[string]interface{}{
+		"title": string(titleBuf),
+		"base":  strings.TrimSuffix(server.options.Base, "/"),
+		"id":    container.ID,
+	})
+	if err != nil {
+		c.Error(err)
+	}
+	c.Writer.Write(statsBuf.Bytes())
+}
+
+// handleStatsJSON responds the current resource usage of the container
+func (server *Server) handleStatsJSON(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), statsTimeout)
+	defer cancel()
+
+	stats, err := server.stats(ctx, c.Param("cid"))
+	if err == nil {
+		s, ok := <-stats
+		if ok {
+			c.JSON(http.StatusOK, s)
+			return
+		}
+		err = fmt.Errorf("no stats in %s", statsTimeout)
+	}
+	c.JSON(http.StatusInternalServerError, types.ContainerActionMessage{
+		Code:  http.StatusInternalServerError,
+		Error: err.Error(),
+	})
+}
+
+// handleStats streams the resource usage of the container by the
+// websocket, until the client closes it
+func (server *Server) handleStats(c *gin.Context) {
+	conn, err := server.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		c.String(http.StatusInternalServerError, "server error: %s", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		// nothing to read, it returns when closed
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	stats, err := server.stats(ctx, c.Param("cid"))
+	if err != nil {
+		conn.WriteJSON(types.ContainerActionMessage{
+			Code:  http.StatusInternalServerError,
+			Error: err.Error(),
+		})
+		return
+	}
+	for s := range stats {
+		if err := conn.WriteJSON(s); err != nil {
+			log.Debugf("write stats error: %s", err)
+			return
+		}
+	}
+	conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "the stats stream ends"))
+}
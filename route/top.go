@@ -0,0 +1,151 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/wrfly/container-web-tty/audit"
+	"github.com/wrfly/container-web-tty/container"
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// signals can be sent to the processes, without the SIG prefix
+var signals = []string{"TERM", "KILL", "INT", "HUP", "QUIT", "USR1", "USR2", "STOP", "CONT"}
+
+// processManager returns the backend and the container of the cid
+func (server *Server) processManager(ctx context.Context, cid string) (container.ProcessManager, types.Container, error) {
+	manager, ok := server.containerCli.(container.ProcessManager)
+	if !ok {
+		return nil, types.Container{}, fmt.Errorf("the backend doesn't list the processes")
+	}
+	c := server.containerCli.GetInfo(ctx, cid)
+	if c.ID == "" {
+		return nil, types.Container{}, fmt.Errorf("container %s not found", cid)
+	}
+	return manager, c, nil
+}
+
+// signalEnabled tells whether the processes can be signaled, which
+// can stop the container as well
+func (server *Server) signalEnabled() bool {
+	ctl := server.options.Control
+	return ctl.Enable && (ctl.Stop || ctl.All)
+}
+
+// handleTopIndex renders the page of the processes of the container
+func (server *Server) handleTopIndex(c *gin.Context) {
+	container := server.containerCli.GetInfo(c.Request.Context(), c.Param("cid"))
+	if container.ID == "" {
+		c.String(http.StatusNotFound, "container %s not found", c.Param("cid"))
+		return
+	}
+
+	titleBuf, err := server.makeTitleBuff(container, nil, "processes of")
+	if err != nil {
+		c.String(http.StatusInternalServerError, "failed to fill window title template: %s", err)
+		return
+	}
+
+	topBuf := new(bytes.Buffer)
+	err = topTemplate.Execute(topBuf, map[string]interface{}{
+		"title":   string(titleBuf),
+		"base":    strings.TrimSuffix(server.options.Base, "/"),
+		"id":      container.ID,
+		"signal":  server.signalEnabled(),
+		"signals": signals,
+	})
+	if err != nil {
+		c.Error(err)
+	}
+	c.Writer.Write(topBuf.Bytes())
+}
+
+// handleTopJSON responds the processes of the container
+func (server *Server) handleTopJSON(c *gin.Context) {
+	ctx := c.Request.Context()
+	processes, err := func() ([]types.Process, error) {
+		manager, container, err := server.processManager(ctx, c.Param("cid"))
+		if err != nil {
+			return nil, err
+		}
+		return manager.Top(ctx, container)
+	}()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ContainerActionMessage{
+			Code:  http.StatusInternalServerError,
+			Error: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, processes)
+}
+
+// handleSignal sends the signal of the form to the process of the form
+func (server *Server) handleSignal(c *gin.Context) {
+	signal := strings.TrimPrefix(strings.ToUpper(c.PostForm("signal")), "SIG")
+	pid, err := strconv.Atoi(c.PostForm("pid"))
+	if err != nil || pid <= 0 {
+		err = fmt.Errorf("bad pid %q", c.PostForm("pid"))
+	} else if !knownSignal(signal) {
+		err = fmt.Errorf("bad signal %q, only %s are allowed",
+			c.PostForm("signal"), strings.Join(signals, ", "))
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, types.ContainerActionMessage{
+			Code:  http.StatusBadRequest,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	manager, container, err := server.processManager(ctx, c.Param("cid"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, types.ContainerActionMessage{
+			Code:  http.StatusNotFound,
+			Error: err.Error(),
+		})
+		return
+	}
+
+	log.Debugf("client [%s] is going to send SIG%s to process %d of container [%s]",
+		c.ClientIP(), signal, pid, container.ID)
+	err = manager.Signal(ctx, container, pid, signal)
+	if server.options.EnableAudit {
+		result := "ok"
+		if err != nil {
+			result = err.Error()
+		}
+		audit.Record(audit.LogOpts{
+			Dir:         server.options.AuditLogDir,
+			ContainerID: container.ID,
+			ClientIP:    c.ClientIP(),
+		}, fmt.Sprintf("signal SIG%s to process %d: %s", signal, pid, result))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, types.ContainerActionMessage{
+			Code:  http.StatusInternalServerError,
+			Error: err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, types.ContainerActionMessage{
+		Message: fmt.Sprintf("send SIG%s to process %d successfully", signal, pid),
+	})
+}
+
+func knownSignal(signal string) bool {
+	for _, s := range signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -17,7 +18,11 @@ import (
 func run(c *cli.Context, conf config.Config) {
 	srvOptions := conf.Server
 
-	if len(conf.Backend.GRPC.Servers) > 0 {
+	if len(conf.Backend.GRPC.Servers) > 0 ||
+		len(conf.Backend.Kube.Contexts) > 0 ||
+		len(conf.Backend.Docker.Hosts) > 1 ||
+		len(conf.Backend.Docker.Contexts) > 0 ||
+		strings.Contains(conf.Backend.Type, ",") {
 		srvOptions.ShowLocation = true
 	}
 	if err := ecp.Parse(&srvOptions); err != nil {
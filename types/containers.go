@@ -12,9 +12,14 @@ type Containers struct {
 	once sync.Once
 }
 
+// List returns a copy of the containers
 func (cs *Containers) List() []Container {
 	cs.init()
-	return cs.cs
+
+	cs.m.RLock()
+	defer cs.m.RUnlock()
+
+	return append([]Container(nil), cs.cs...)
 }
 
 func (cs *Containers) Len() int {
@@ -54,6 +59,54 @@ func (cs *Containers) Append(c Container) {
 	cs.m.Unlock()
 }
 
+// Put replaces the container of the same ID, or appends it,
+// returns whether it's replaced
+func (cs *Containers) Put(c Container) bool {
+	cs.init()
+	if c.ID == "" {
+		return false
+	}
+
+	cs.m.Lock()
+	defer cs.m.Unlock()
+	cs.c[c.ID] = c
+	if len(c.ID) >= 12 {
+		cs.c[c.ID[:12]] = c
+	}
+	for i := range cs.cs {
+		if cs.cs[i].ID == c.ID {
+			cs.cs[i] = c
+			return true
+		}
+	}
+	cs.cs = append(cs.cs, c)
+	return false
+}
+
+// Remove removes the container of the full ID,
+// returns whether it's removed
+func (cs *Containers) Remove(cID string) bool {
+	cs.init()
+
+	cs.m.Lock()
+	defer cs.m.Unlock()
+	if _, exist := cs.c[cID]; !exist {
+		return false
+	}
+	delete(cs.c, cID)
+	if len(cID) >= 12 {
+		delete(cs.c, cID[:12])
+	}
+	for i := range cs.cs {
+		if cs.cs[i].ID == cID {
+			// don't modify the slices returned by List
+			cs.cs = append(cs.cs[:i:i], cs.cs[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
 func (cs *Containers) Find(cID string) Container {
 	cs.init()
 
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// the actions of the container events
+const (
+	ContainerCreated = "create"
+	ContainerUpdated = "update"
+	ContainerRemoved = "remove"
+)
+
+// ContainerEvent is a change of the listed containers
+type ContainerEvent struct {
+	Action    string
+	Container Container // only the ID and the location are set when removed
+}
+
+// EventHub fans out the container events to the subscribers, the events
+// are dropped for a subscriber which doesn't keep up
+type EventHub struct {
+	m    sync.Mutex
+	subs map[chan ContainerEvent]struct{}
+}
+
+// Subscribe returns the events until the context is done
+func (h *EventHub) Subscribe(ctx context.Context) <-chan ContainerEvent {
+	ch := make(chan ContainerEvent, 64)
+	h.m.Lock()
+	if h.subs == nil {
+		h.subs = make(map[chan ContainerEvent]struct{})
+	}
+	h.subs[ch] = struct{}{}
+	h.m.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.m.Lock()
+		delete(h.subs, ch)
+		close(ch)
+		h.m.Unlock()
+	}()
+	return ch
+}
+
+// Publish sends the event to all the subscribers
+func (h *EventHub) Publish(event ContainerEvent) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- event:
+		default:
+			logrus.Warnf("container event %s %s is dropped, the subscriber is slow",
+				event.Action, event.Container.ID)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // Container instance
 type Container struct {
 	// common
@@ -12,18 +14,40 @@ type Container struct {
 	// k8s
 	PodName, ContainerName string
 	Namespace, RunningNode string
+	Cluster                string // the kube context, if several clusters
+	Workload               string // "kind/name" of the stopped workload, which has no pods
 
 	// remote location server address
 	// use this to locate the container
 	// in the proxy mode
 	LocServer string
 
+	// the backend lost the connection, it's
+	// of the last known list
+	Stale bool
+
+	// the backend serving this container
+	// when several backends are enabled
+	Backend string
+
 	// exec commands in arguments
 	// permit user to execute any command
 	// in that container
 	Exec ExecOptions
 }
 
+// Location is where the containers are served from, e.g. a docker
+// daemon, its name is the LocServer of the containers
+type Location struct {
+	Name      string
+	Connected bool
+	Error     string // why it's disconnected
+
+	// the backend serving this location
+	// when several backends are enabled
+	Backend string
+}
+
 // ContainerActionMessage tells the web browser the action's status
 type ContainerActionMessage struct {
 	Error   string `json:"err"`
@@ -36,10 +60,45 @@ type InitMessage struct {
 	AuthToken string `json:"AuthToken,omitempty"`
 }
 
+// Stats is the resource usage of a container at a moment, the unknown
+// values are 0, e.g. the network and block IO of the kube containers
+type Stats struct {
+	ID   string    `json:"id"`
+	Time time.Time `json:"time"`
+
+	CPUPercent  float64 `json:"cpu_percent"` // 100 is a whole CPU
+	MemoryUsage uint64  `json:"memory_usage"`
+	MemoryLimit uint64  `json:"memory_limit"`
+
+	// the bytes since the container started
+	NetworkRx  uint64 `json:"network_rx"`
+	NetworkTx  uint64 `json:"network_tx"`
+	BlockRead  uint64 `json:"block_read"`
+	BlockWrite uint64 `json:"block_write"`
+}
+
+// Process is a process in a container, the unknown values are 0
+type Process struct {
+	PID        int     `json:"pid"`
+	User       string  `json:"user"`
+	CPUPercent float64 `json:"cpu_percent"`
+	Memory     uint64  `json:"memory"` // the resident bytes
+	Command    string  `json:"command"`
+
+	// the PID can't be signaled, e.g. the docker ones are of the host,
+	// only the main process is signaled via the container
+	NoSignal bool `json:"no_signal"`
+}
+
 type LogOptions struct {
 	ID     string
 	Follow bool
 	Tail   string
+	// the logs between Since and Until, unlimited if zero
+	Since      time.Time
+	Until      time.Time
+	Timestamps bool
+	Stream     string // stdout or stderr, both if empty
 }
 
 type ContainerAct int
@@ -52,7 +111,13 @@ const (
 type ExecOptions struct {
 	User string
 	Env  string
-	Cmd  string
+	Cmd  string // run by the shell
+	// the argv executed directly without a shell,
+	// alias as repeated `arg`
+	Args []string
 	// alias as `p`
 	Privileged bool
+	// run a shell in a helper container with the debug tools,
+	// for the containers without a shell
+	Debug bool
 }
@@ -26,3 +26,12 @@ func ID(salt string) string {
 
 	return string(b64)
 }
+
+// ShortID returns the first n characters of the ID, the IDs of some
+// backends are shorter, e.g. the containerd ones are named by the user
+func ShortID(id string, n int) string {
+	if len(id) > n {
+		id = id[:n]
+	}
+	return id
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// the stderr outputs are red in the log terminal
+var (
+	stderrColor = []byte("\x1b[31m")
+	resetColor  = []byte("\x1b[0m")
+)
+
+// ParseLogTime converts the since or until option of the logs, which is
+// a duration before now, e.g. 10m, or a RFC3339 time, zero if empty
+func ParseLogTime(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("bad time %q, neither a duration "+
+			"like 10m nor a RFC3339 time", value)
+	}
+	return t, nil
+}
+
+// ColorStderr colors the stderr outputs, the line breaks are kept out
+// of the color so that the terminal won't fill the next line with it
+func ColorStderr(p []byte) []byte {
+	body := bytes.TrimRight(p, "\r\n")
+	if len(body) == 0 {
+		return p
+	}
+	colored := make([]byte, 0, len(p)+len(stderrColor)+len(resetColor))
+	colored = append(colored, stderrColor...)
+	colored = append(colored, body...)
+	colored = append(colored, resetColor...)
+	return append(colored, p[len(body):]...)
+}
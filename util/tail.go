@@ -0,0 +1,114 @@
+package util
+
+import (
+	"context"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+const tailPollInterval = time.Millisecond * 300
+
+// ParseTail converts the "tail" option of the logs into the number of lines,
+// -1 means all the lines
+func ParseTail(tail string) int {
+	n, err := strconv.Atoi(tail)
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+type tailReader struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	f      *os.File
+	follow bool
+}
+
+func (t *tailReader) Read(p []byte) (int, error) {
+	for {
+		n, err := t.f.Read(p)
+		if err != io.EOF || !t.follow || n != 0 {
+			return n, err
+		}
+		// wait for the new lines
+		select {
+		case <-t.ctx.Done():
+			return 0, io.EOF
+		case <-time.After(tailPollInterval):
+		}
+	}
+}
+
+func (t *tailReader) Close() error {
+	t.cancel()
+	return t.f.Close()
+}
+
+// TailFile reads the last n lines of the file (all of them if n < 0),
+// and keeps reading the appended lines until ctx done if follow is true
+func TailFile(ctx context.Context, path string, n int, follow bool) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if n >= 0 {
+		offset, err := lastLinesOffset(f, n)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	return &tailReader{
+		ctx:    ctx,
+		cancel: cancel,
+		f:      f,
+		follow: follow,
+	}, nil
+}
+
+// lastLinesOffset returns the offset of the last n lines of the file
+func lastLinesOffset(f *os.File, n int) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	size := info.Size()
+	if n == 0 {
+		return size, nil
+	}
+
+	const chunkSize = 4096
+	buff := make([]byte, chunkSize)
+	lines := 0
+	for end := size; end > 0; {
+		start := end - chunkSize
+		if start < 0 {
+			start = 0
+		}
+		chunk := buff[:end-start]
+		if _, err := f.ReadAt(chunk, start); err != nil && err != io.EOF {
+			return 0, err
+		}
+		for i := len(chunk) - 1; i >= 0; i-- {
+			// the trailing newline doesn't start a new line
+			if chunk[i] != '\n' || start+int64(i) == size-1 {
+				continue
+			}
+			if lines++; lines == n {
+				return start + int64(i) + 1, nil
+			}
+		}
+		end = start
+	}
+	return 0, nil
+}
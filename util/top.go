@@ -0,0 +1,88 @@
+package util
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wrfly/container-web-tty/types"
+)
+
+// ParseTop converts the columns of `ps` into the processes, the known
+// titles are of procps and busybox, the others are ignored
+func ParseTop(titles []string, rows [][]string) []types.Process {
+	processes := make([]types.Process, 0, len(rows))
+	for _, row := range rows {
+		var p types.Process
+		for i, title := range titles {
+			if i >= len(row) {
+				break
+			}
+			value := row[i]
+			switch strings.ToUpper(title) {
+			case "PID":
+				p.PID, _ = strconv.Atoi(value)
+			case "USER", "UID", "RUSER":
+				p.User = value
+			case "%CPU", "C":
+				p.CPUPercent, _ = strconv.ParseFloat(value, 64)
+			case "RSS":
+				p.Memory = parseKiB(value)
+			case "COMMAND", "CMD", "ARGS":
+				p.Command = value
+			}
+		}
+		if p.PID != 0 {
+			processes = append(processes, p)
+		}
+	}
+	return processes
+}
+
+// ParsePs parses the output of `ps`, the last column may contain spaces
+func ParsePs(out string) []types.Process {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	titles := strings.Fields(lines[0])
+	if len(titles) == 0 {
+		return nil
+	}
+	rows := make([][]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) > len(titles) {
+			last := len(titles) - 1
+			// the spaces in the command are kept
+			rest := line
+			for _, field := range fields[:last] {
+				rest = strings.TrimLeft(rest, " \t")
+				rest = strings.TrimPrefix(rest, field)
+			}
+			fields = append(fields[:last], strings.TrimSpace(rest))
+		}
+		rows = append(rows, fields)
+	}
+	return ParseTop(titles, rows)
+}
+
+// parseKiB converts the RSS into bytes, busybox prints the large
+// ones with a unit, e.g. 12m
+func parseKiB(value string) uint64 {
+	if value == "" {
+		return 0
+	}
+	unit := uint64(1 << 10)
+	switch strings.ToLower(value[len(value)-1:]) {
+	case "k":
+		value = value[:len(value)-1]
+	case "m":
+		unit = 1 << 20
+		value = value[:len(value)-1]
+	case "g":
+		unit = 1 << 30
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return uint64(n * float64(unit))
+}
@@ -6,9 +6,11 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
 	pb "github.com/wrfly/container-web-tty/proxy/pb"
 	"github.com/wrfly/container-web-tty/types"
@@ -29,11 +31,16 @@ func ConvertPbContainer(c *pb.Container) types.Container {
 		ContainerName: c.ContainerName,
 		Namespace:     c.Namespace,
 		RunningNode:   c.RunningNode,
+		Cluster:       c.Cluster,
+		Workload:      c.Workload,
 		LocServer:     c.LocServer,
+		Stale:         c.Stale,
 		Exec: types.ExecOptions{
-			Cmd:  c.ExecCmd,
-			Env:  c.ExecEnv,
-			User: c.ExecUser,
+			Cmd:   c.ExecCmd,
+			Env:   c.ExecEnv,
+			User:  c.ExecUser,
+			Args:  c.ExecArgs,
+			Debug: c.ExecDebug,
 		},
 	}
 }
@@ -53,13 +60,157 @@ func ConvertTpContainer(c types.Container) *pb.Container {
 		ContainerName: c.ContainerName,
 		Namespace:     c.Namespace,
 		RunningNode:   c.RunningNode,
+		Cluster:       c.Cluster,
+		Workload:      c.Workload,
 		LocServer:     c.LocServer,
+		Stale:         c.Stale,
 		ExecCmd:       c.Exec.Cmd,
 		ExecEnv:       c.Exec.Env,
 		ExecUser:      c.Exec.User,
+		ExecArgs:      c.Exec.Args,
+		ExecDebug:     c.Exec.Debug,
 	}
 }
 
+// ConvertPbStats *pb.Stats -> types.Stats
+func ConvertPbStats(s *pb.Stats) types.Stats {
+	stats := types.Stats{
+		ID:          s.Id,
+		CPUPercent:  s.CpuPercent,
+		MemoryUsage: s.MemoryUsage,
+		MemoryLimit: s.MemoryLimit,
+		NetworkRx:   s.NetworkRx,
+		NetworkTx:   s.NetworkTx,
+		BlockRead:   s.BlockRead,
+		BlockWrite:  s.BlockWrite,
+	}
+	if s.Time != 0 {
+		stats.Time = time.Unix(0, s.Time).UTC()
+	}
+	return stats
+}
+
+// ConvertTpStats types.Stats -> *pb.Stats
+func ConvertTpStats(s types.Stats) *pb.Stats {
+	stats := &pb.Stats{
+		Id:          s.ID,
+		CpuPercent:  s.CPUPercent,
+		MemoryUsage: s.MemoryUsage,
+		MemoryLimit: s.MemoryLimit,
+		NetworkRx:   s.NetworkRx,
+		NetworkTx:   s.NetworkTx,
+		BlockRead:   s.BlockRead,
+		BlockWrite:  s.BlockWrite,
+	}
+	if !s.Time.IsZero() {
+		stats.Time = s.Time.UnixNano()
+	}
+	return stats
+}
+
+// ConvertPbLogOpts *pb.LogOpts -> types.LogOptions
+func ConvertPbLogOpts(o *pb.LogOpts) types.LogOptions {
+	opts := types.LogOptions{
+		ID:         o.GetC().GetId(),
+		Follow:     o.Follow,
+		Tail:       o.Tail,
+		Timestamps: o.Timestamps,
+		Stream:     o.Stream,
+	}
+	if o.Since != 0 {
+		opts.Since = time.Unix(0, o.Since).UTC()
+	}
+	if o.Until != 0 {
+		opts.Until = time.Unix(0, o.Until).UTC()
+	}
+	return opts
+}
+
+// ConvertTpLogOpts types.LogOptions -> *pb.LogOpts, the container
+// is set by the caller with the auth
+func ConvertTpLogOpts(opts types.LogOptions) *pb.LogOpts {
+	o := &pb.LogOpts{
+		Follow:     opts.Follow,
+		Tail:       opts.Tail,
+		Timestamps: opts.Timestamps,
+		Stream:     opts.Stream,
+	}
+	if !opts.Since.IsZero() {
+		o.Since = opts.Since.UnixNano()
+	}
+	if !opts.Until.IsZero() {
+		o.Until = opts.Until.UnixNano()
+	}
+	return o
+}
+
+// ConvertPbProcesses *pb.Processes -> []types.Process
+func ConvertPbProcesses(ps *pb.Processes) []types.Process {
+	processes := make([]types.Process, 0, len(ps.Ps))
+	for _, p := range ps.Ps {
+		processes = append(processes, types.Process{
+			PID:        int(p.Pid),
+			User:       p.User,
+			CPUPercent: p.CpuPercent,
+			Memory:     p.Memory,
+			Command:    p.Command,
+			NoSignal:   p.NoSignal,
+		})
+	}
+	return processes
+}
+
+// ConvertTpProcesses []types.Process -> *pb.Processes
+func ConvertTpProcesses(processes []types.Process) *pb.Processes {
+	ps := &pb.Processes{Ps: make([]*pb.Process, 0, len(processes))}
+	for _, p := range processes {
+		ps.Ps = append(ps.Ps, &pb.Process{
+			Pid:        int32(p.PID),
+			User:       p.User,
+			CpuPercent: p.CPUPercent,
+			Memory:     p.Memory,
+			Command:    p.Command,
+			NoSignal:   p.NoSignal,
+		})
+	}
+	return ps
+}
+
+// ShellQuote quotes the argv into a shell command line
+func ShellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// envPattern is the KEY= of an environment
+var envPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// ShellEnv quotes the environments "A=1 B=2" into the words of the shell
+// `export`, the words without a KEY= belong to the value before them,
+// e.g. "A=a b" is A='a b'
+func ShellEnv(env string) (string, error) {
+	quoted := []string{}
+	values := []string{}
+	for _, word := range strings.Fields(env) {
+		if key := envPattern.FindString(word); key != "" {
+			quoted = append(quoted, key)
+			values = append(values, word[len(key):])
+			continue
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("bad env %q, should be KEY=VALUE", word)
+		}
+		values[len(values)-1] += " " + word
+	}
+	for i := range quoted {
+		quoted[i] += "'" + strings.ReplaceAll(values[i], "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " "), nil
+}
+
 func HomeDIR() string {
 	if h := os.Getenv("HOME"); h != "" {
 		return h
@@ -82,6 +233,22 @@ func KubeConfigPath() string {
 	return filepath.Join(home, ".kube", "config")
 }
 
+func DockerConfigDir() string {
+	home := HomeDIR()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".docker")
+}
+
+func KnownHostsPath() string {
+	home := HomeDIR()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".ssh", "known_hosts")
+}
+
 func EnvVars(e string) []string {
 	e = strings.ToUpper(e)
 	return []string{"WEB_TTY_" + strings.Replace(e, "-", "_", -1)}
@@ -2,10 +2,226 @@ package util
 
 import (
 	"fmt"
+	"os/exec"
+	"reflect"
 	"testing"
+	"time"
+
+	pb "github.com/wrfly/container-web-tty/proxy/pb"
+	"github.com/wrfly/container-web-tty/types"
 )
 
 func TestID(t *testing.T) {
 	fmt.Println(ID("hello-world-1234-qwer"))
 	fmt.Println(ID("11"))
 }
+
+func TestShortID(t *testing.T) {
+	for id, expect := range map[string]string{
+		"0123456789abcdef": "0123456",
+		"redis":            "redis", // containerd IDs are named by the user
+		"":                 "",
+	} {
+		if got := ShortID(id, 7); got != expect {
+			t.Errorf("short ID of %q: expect %q, got %q", id, expect, got)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	quoted := ShellQuote([]string{"echo", "a b", "it's"})
+	if quoted != `'echo' 'a b' 'it'\''s'` {
+		t.Errorf("unexpected quoted %s", quoted)
+	}
+}
+
+func TestShellEnv(t *testing.T) {
+	env := "A=1 B=$(id) C=1;echo D=it's E=a  b\tc"
+	quoted, err := ShellEnv(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `A='1' B='$(id)' C='1;echo' D='it'\''s' E='a b c'`
+	if quoted != expect {
+		t.Errorf("expect %s, got %s", expect, quoted)
+	}
+
+	// the values are not evaluated by the shell
+	output, err := exec.Command("sh", "-c",
+		"export "+quoted+`; printf '%s|' "$A" "$B" "$C" "$D" "$E"`).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != "1|$(id)|1;echo|it's|a b c|" {
+		t.Errorf("unexpected output %q", output)
+	}
+
+	for _, env := range []string{"$(id) A=1", "1A=2", ";reboot"} {
+		if _, err := ShellEnv(env); err == nil {
+			t.Errorf("expect error of the bad env %q", env)
+		}
+	}
+}
+
+func TestConvertContainer(t *testing.T) {
+	c := types.Container{
+		ID:       "0123456789ab",
+		Cluster:  "prod",
+		Workload: "deployment/web",
+		Stale:    true,
+		Exec: types.ExecOptions{
+			Args:  []string{"echo", "a b"},
+			Env:   "A=1",
+			Debug: true,
+		},
+	}
+	got := ConvertPbContainer(ConvertTpContainer(c))
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("expect %+v, got %+v", c, got)
+	}
+}
+
+func TestConvertStats(t *testing.T) {
+	for _, s := range []types.Stats{
+		{ID: "0123456789ab"},
+		{
+			ID:          "0123456789ab",
+			Time:        time.Date(2024, 5, 1, 10, 0, 0, 1, time.UTC),
+			CPUPercent:  12.5,
+			MemoryUsage: 64 << 20,
+			MemoryLimit: 1 << 30,
+			NetworkRx:   1,
+			NetworkTx:   2,
+			BlockRead:   3,
+			BlockWrite:  4,
+		},
+	} {
+		got := ConvertPbStats(ConvertTpStats(s))
+		if !reflect.DeepEqual(got, s) {
+			t.Errorf("expect %+v, got %+v", s, got)
+		}
+	}
+}
+
+func TestParsePs(t *testing.T) {
+	procps := `    PID USER     %CPU   RSS COMMAND
+      1 root      0.0  3884 /bin/sh -c sleep  infinity
+     42 www-data 12.5 10240 nginx: worker process
+`
+	busybox := `PID   USER     TIME  COMMAND
+    1 root      0:00 sleep 3600
+    7 nobody    0:01 httpd -f`
+	rss := `PID   USER     RSS  COMMAND
+    1 root     1.5m sleep 3600
+    9 root      512 top`
+
+	for _, tc := range []struct {
+		out    string
+		expect []types.Process
+	}{
+		{procps, []types.Process{
+			{PID: 1, User: "root", Memory: 3884 << 10, Command: "/bin/sh -c sleep  infinity"},
+			{PID: 42, User: "www-data", CPUPercent: 12.5, Memory: 10 << 20, Command: "nginx: worker process"},
+		}},
+		{busybox, []types.Process{
+			{PID: 1, User: "root", Command: "sleep 3600"},
+			{PID: 7, User: "nobody", Command: "httpd -f"},
+		}},
+		{rss, []types.Process{
+			{PID: 1, User: "root", Memory: 3 << 19, Command: "sleep 3600"},
+			{PID: 9, User: "root", Memory: 512 << 10, Command: "top"},
+		}},
+	} {
+		got := ParsePs(tc.out)
+		if !reflect.DeepEqual(got, tc.expect) {
+			t.Errorf("expect %+v, got %+v", tc.expect, got)
+		}
+	}
+}
+
+func TestParseTop(t *testing.T) {
+	// the default of `docker top`, i.e. `ps -ef`
+	titles := []string{"UID", "PID", "PPID", "C", "STIME", "TTY", "TIME", "CMD"}
+	rows := [][]string{
+		{"root", "1234", "1200", "3", "10:00", "?", "00:00:01", "nginx -g daemon off;"},
+	}
+	expect := []types.Process{
+		{PID: 1234, User: "root", CPUPercent: 3, Command: "nginx -g daemon off;"},
+	}
+	if got := ParseTop(titles, rows); !reflect.DeepEqual(got, expect) {
+		t.Errorf("expect %+v, got %+v", expect, got)
+	}
+}
+
+func TestConvertProcesses(t *testing.T) {
+	processes := []types.Process{
+		{PID: 1, User: "root", CPUPercent: 0.5, Memory: 4 << 20, Command: "nginx -g daemon off;"},
+		{PID: 7, User: "nobody", Command: "nginx: worker process"},
+	}
+	got := ConvertPbProcesses(ConvertTpProcesses(processes))
+	if !reflect.DeepEqual(got, processes) {
+		t.Errorf("expect %+v, got %+v", processes, got)
+	}
+}
+
+func TestConvertLogOpts(t *testing.T) {
+	for _, opts := range []types.LogOptions{
+		{ID: "0123456789ab", Follow: true, Tail: "10"},
+		{
+			ID:         "0123456789ab",
+			Tail:       "all",
+			Since:      time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Until:      time.Date(2024, 5, 1, 11, 0, 0, 1, time.UTC),
+			Timestamps: true,
+			Stream:     "stderr",
+		},
+	} {
+		o := ConvertTpLogOpts(opts)
+		o.C = &pb.ContainerID{Id: opts.ID}
+		got := ConvertPbLogOpts(o)
+		if !reflect.DeepEqual(got, opts) {
+			t.Errorf("expect %+v, got %+v", opts, got)
+		}
+	}
+}
+
+func TestParseLogTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	for value, expect := range map[string]time.Time{
+		"":                          {},
+		"10m":                       now.Add(-time.Minute * 10),
+		"1h30m":                     now.Add(-time.Minute * 90),
+		"2024-04-30T08:00:00Z":      time.Date(2024, 4, 30, 8, 0, 0, 0, time.UTC),
+		"2024-04-30T08:00:00.5Z":    time.Date(2024, 4, 30, 8, 0, 0, 5e8, time.UTC),
+		"2024-04-30T10:00:00+02:00": time.Date(2024, 4, 30, 8, 0, 0, 0, time.UTC),
+	} {
+		got, err := ParseLogTime(value, now)
+		if err != nil {
+			t.Errorf("parse %q error: %s", value, err)
+			continue
+		}
+		if !got.Equal(expect) {
+			t.Errorf("parse %q: expect %s, got %s", value, expect, got)
+		}
+	}
+
+	for _, value := range []string{"yesterday", "2024-04-30", "10"} {
+		if _, err := ParseLogTime(value, now); err == nil {
+			t.Errorf("expect error of %q", value)
+		}
+	}
+}
+
+func TestColorStderr(t *testing.T) {
+	for line, expect := range map[string]string{
+		"":          "",
+		"\r\n":      "\r\n",
+		"error":     "\x1b[31merror\x1b[0m",
+		"error\r\n": "\x1b[31merror\x1b[0m\r\n",
+		"a\r\nb\n":  "\x1b[31ma\r\nb\x1b[0m\n",
+	} {
+		if got := string(ColorStderr([]byte(line))); got != expect {
+			t.Errorf("color %q: expect %q, got %q", line, expect, got)
+		}
+	}
+}